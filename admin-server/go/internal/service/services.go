@@ -257,6 +257,24 @@ func initScylla(ctx context.Context, wg *sync.WaitGroup) error {
 			return err
 		}
 	}
+	keyspace, err := tf.Scylla.KeyspaceMetadata(clusterCfg.Keyspace)
+	if err != nil {
+		tf.Scylla.Close()
+		return err
+	}
+	for _, col := range scylla.GetScyllaSchemaColumns() {
+		table, ok := keyspace.Tables[col.Table]
+		if !ok {
+			continue
+		}
+		if _, exists := table.Columns[col.Column]; exists {
+			continue
+		}
+		if err = tf.Scylla.Query(fmt.Sprintf("alter table %s add %s %s", col.Table, col.Column, col.Type)).Exec(); err != nil {
+			tf.Scylla.Close()
+			return err
+		}
+	}
 
 	go util.ShutdownHandler(ctx, wg, func() { tf.Scylla.Close() })
 	return nil
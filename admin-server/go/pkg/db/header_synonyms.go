@@ -0,0 +1,55 @@
+package db
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/tf"
+)
+
+func GetHeaderSynonym(id string) (*model.HeaderSynonym, error) {
+	if len(id) == 0 {
+		return nil, errors.New("no header synonym ID provided")
+	}
+	var headerSynonym model.HeaderSynonym
+	err := tf.DB.First(&headerSynonym, model.ParseID(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	if !headerSynonym.ID.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &headerSynonym, nil
+}
+
+// GetHeaderSynonyms retrieves the header synonym groups of a workspace, used to match upload columns to template
+// columns
+func GetHeaderSynonyms(workspaceID string) ([]*model.HeaderSynonym, error) {
+	if len(workspaceID) == 0 {
+		return nil, errors.New("no workspace ID provided")
+	}
+	var headerSynonyms []*model.HeaderSynonym
+	err := tf.DB.Where("workspace_id = ?", model.ParseID(workspaceID)).
+		Order("term asc").
+		Find(&headerSynonyms).Error
+	if err != nil {
+		return nil, err
+	}
+	return headerSynonyms, nil
+}
+
+func GetHeaderSynonymsWithUsers(workspaceID string) ([]*model.HeaderSynonym, error) {
+	if len(workspaceID) == 0 {
+		return nil, errors.New("no workspace ID provided")
+	}
+	var headerSynonyms []*model.HeaderSynonym
+	err := tf.DB.Preload("CreatedByUser", userPreloadArgs).
+		Preload("UpdatedByUser", userPreloadArgs).
+		Where("workspace_id = ?", model.ParseID(workspaceID)).
+		Order("term asc").
+		Find(&headerSynonyms).Error
+	if err != nil {
+		return nil, err
+	}
+	return headerSynonyms, nil
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/tf"
+)
+
+func GetLearnedColumnMapping(id string) (*model.LearnedColumnMapping, error) {
+	if len(id) == 0 {
+		return nil, errors.New("no learned column mapping ID provided")
+	}
+	var mapping model.LearnedColumnMapping
+	err := tf.DB.First(&mapping, model.ParseID(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	if !mapping.ID.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &mapping, nil
+}
+
+func GetLearnedColumnMappings(importerID string) ([]*model.LearnedColumnMapping, error) {
+	if len(importerID) == 0 {
+		return nil, errors.New("no importer ID provided")
+	}
+	var mappings []*model.LearnedColumnMapping
+	err := tf.DB.Where("importer_id = ?", model.ParseID(importerID)).
+		Order("upload_column_name asc").
+		Find(&mappings).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// LearnColumnMappings saves the column mappings confirmed on an upload of the importer, from the normalized upload
+// column names to the template column keys. A mapping confirmed again is counted. A mapping to a different key, or an
+// upload column left unmapped, counts against the learned mapping, which is only replaced or forgotten once it's no
+// longer confirmed, so a single upload can't erase what was learned from several.
+func LearnColumnMappings(importerID model.ID, mappings map[string]string, unmappedNames []string) error {
+	return tf.DB.Transaction(func(tx *gorm.DB) error {
+		if len(mappings) != 0 {
+			valuesStmt := make([]string, 0, len(mappings))
+			values := make([]interface{}, 0, len(mappings)*3)
+			for name, key := range mappings {
+				valuesStmt = append(valuesStmt, "(?::uuid, ?, ?)")
+				values = append(values, importerID, name, key)
+			}
+			sql := fmt.Sprintf(`
+				insert into learned_column_mappings as lcm (importer_id, upload_column_name, template_column_key)
+				values %s
+				on conflict (importer_id, upload_column_name) do update
+					set times_confirmed     = case
+					                              when lcm.template_column_key = excluded.template_column_key
+					                                  then lcm.times_confirmed + 1
+					                              when lcm.times_confirmed <= 1
+					                                  then 1
+					                              else lcm.times_confirmed - 1
+					                          end,
+					    template_column_key = case
+					                              when lcm.times_confirmed <= 1
+					                                  then excluded.template_column_key
+					                              else lcm.template_column_key
+					                          end,
+					    updated_at          = now();`,
+				strings.Join(valuesStmt, ","))
+			if err := tx.Exec(sql, values...).Error; err != nil {
+				return err
+			}
+		}
+		if len(unmappedNames) != 0 {
+			err := tx.Model(&model.LearnedColumnMapping{}).
+				Where("importer_id = ? and upload_column_name in ?", importerID, unmappedNames).
+				Updates(map[string]interface{}{"times_confirmed": gorm.Expr("times_confirmed - 1"), "updated_at": gorm.Expr("now()")}).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Where("importer_id = ? and upload_column_name in ? and times_confirmed <= 0", importerID, unmappedNames).
+				Delete(&model.LearnedColumnMapping{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func DeleteLearnedColumnMapping(id string) error {
+	if len(id) == 0 {
+		return errors.New("no learned column mapping ID provided")
+	}
+	return tf.DB.Delete(&model.LearnedColumnMapping{}, model.ParseID(id)).Error
+}
+
+func DeleteLearnedColumnMappings(importerID string) error {
+	if len(importerID) == 0 {
+		return errors.New("no importer ID provided")
+	}
+	return tf.DB.Where("importer_id = ?", model.ParseID(importerID)).Delete(&model.LearnedColumnMapping{}).Error
+}
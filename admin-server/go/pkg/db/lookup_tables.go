@@ -0,0 +1,147 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"tableflow/go/pkg/evaluator"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/tf"
+)
+
+// GetLookupTable retrieves a lookup table without the rows
+func GetLookupTable(id string) (*model.LookupTable, error) {
+	if len(id) == 0 {
+		return nil, errors.New("no lookup table ID provided")
+	}
+	var lookupTable model.LookupTable
+	err := tf.DB.Omit("rows").First(&lookupTable, model.ParseID(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	if !lookupTable.ID.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &lookupTable, nil
+}
+
+func GetLookupTableWithUsers(id string) (*model.LookupTable, error) {
+	if len(id) == 0 {
+		return nil, errors.New("no lookup table ID provided")
+	}
+	var lookupTable model.LookupTable
+	err := tf.DB.Omit("rows").
+		Preload("CreatedByUser", userPreloadArgs).
+		Preload("UpdatedByUser", userPreloadArgs).
+		First(&lookupTable, model.ParseID(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	if !lookupTable.ID.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &lookupTable, nil
+}
+
+func GetLookupTablesWithUsers(workspaceID string) ([]*model.LookupTable, error) {
+	if len(workspaceID) == 0 {
+		return nil, errors.New("no workspace ID provided")
+	}
+	var lookupTables []*model.LookupTable
+	err := tf.DB.Omit("rows").
+		Preload("CreatedByUser", userPreloadArgs).
+		Preload("UpdatedByUser", userPreloadArgs).
+		Where("workspace_id = ?", model.ParseID(workspaceID)).
+		Order("name asc").
+		Find(&lookupTables).Error
+	if err != nil {
+		return nil, err
+	}
+	return lookupTables, nil
+}
+
+// GetLookupTableForEvaluator retrieves a lookup table with the rows, used as the evaluator.LookupTableProvider
+func GetLookupTableForEvaluator(id string) (*evaluator.LookupTable, error) {
+	if len(id) == 0 {
+		return nil, errors.New("no lookup table ID provided")
+	}
+	var lookupTable model.LookupTable
+	err := tf.DB.First(&lookupTable, model.ParseID(id)).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("the lookup table %s does not exist", id)
+		}
+		return nil, err
+	}
+	rows := make([][]string, 0, lookupTable.NumRows)
+	if rawRows, ok := lookupTable.Rows.Data.([]interface{}); ok {
+		for _, rawRow := range rawRows {
+			cells, _ := rawRow.([]interface{})
+			rows = append(rows, lo.Map(cells, func(cell interface{}, _ int) string {
+				s, _ := cell.(string)
+				return s
+			}))
+		}
+	}
+	return &evaluator.LookupTable{
+		ID:          lookupTable.ID.String(),
+		WorkspaceID: lookupTable.WorkspaceID.String(),
+		Name:        lookupTable.Name,
+		Columns:     lookupTable.Columns,
+		Rows:        rows,
+	}, nil
+}
+
+// ValidateLookupTableReference checks that the lookup table of a lookup validation belongs to the workspace and
+// contains the columns referenced in the options. Other validations are ignored.
+func ValidateLookupTableReference(workspaceID string, validation *model.Validation) error {
+	e, ok := validation.Evaluator.(*evaluator.LookupEvaluator)
+	if !ok {
+		return nil
+	}
+	lookupTable, err := GetLookupTable(e.TableID)
+	if err != nil || lookupTable.WorkspaceID.String() != workspaceID {
+		return fmt.Errorf("The lookup table %s does not exist", e.TableID)
+	}
+	for _, column := range []string{e.Column, e.ReplaceColumn} {
+		if len(column) != 0 && !lo.Contains(lookupTable.Columns, column) {
+			return fmt.Errorf("The column %s does not exist on the lookup table %s", column, lookupTable.Name)
+		}
+	}
+	return nil
+}
+
+// CountLookupTableValidations returns the number of lookup validations on template columns using the lookup table
+func CountLookupTableValidations(id string) (int64, error) {
+	var count int64
+	err := tf.DB.Raw(`
+		select count(*)
+		from validations v
+		     join template_columns tc on v.template_column_id = tc.id
+		where v.validate = 'lookup'
+		  and v.options ->> 'table_id' = ?
+		  and v.deleted_at is null
+		  and tc.deleted_at is null;
+	`, id).Scan(&count).Error
+	return count, err
+}
+
+// GetLookupTableReferencedColumns returns the columns of the lookup table referenced by the lookup validations on
+// template columns, in the column or replace_column options
+func GetLookupTableReferencedColumns(id string) ([]string, error) {
+	var columns []string
+	err := tf.DB.Raw(`
+		select distinct c.column_name
+		from validations v
+		     join template_columns tc on v.template_column_id = tc.id
+		     cross join lateral (values (v.options ->> 'column'), (v.options ->> 'replace_column')) c(column_name)
+		where v.validate = 'lookup'
+		  and v.options ->> 'table_id' = ?
+		  and v.deleted_at is null
+		  and tc.deleted_at is null
+		  and coalesce(c.column_name, '') != ''
+		order by c.column_name;
+	`, id).Scan(&columns).Error
+	return columns, err
+}
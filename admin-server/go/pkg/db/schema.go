@@ -335,6 +335,56 @@ func GetDatabaseSchemaInitSQL() string {
 		);
 		create index if not exists validations_template_column_id_idx on validations(template_column_id);
 
+		create table if not exists lookup_tables (
+			id           uuid primary key         not null default gen_random_uuid(),
+			workspace_id uuid                     not null,
+			name         text                     not null,
+			columns      text[]                   not null default '{}',
+			num_rows     int                      not null default 0,
+			rows         jsonb                    not null default '[]'::jsonb, -- The rows of the CSV (without the header) as an array of string arrays
+			created_by   uuid                     not null,
+			created_at   timestamp with time zone not null,
+			updated_by   uuid                     not null,
+			updated_at   timestamp with time zone not null,
+			deleted_by   uuid,
+			deleted_at   timestamp with time zone,
+			constraint fk_workspace_id
+				foreign key (workspace_id)
+					references workspaces(id)
+		);
+		create unique index if not exists lookup_tables_workspace_id_name_idx on lookup_tables(workspace_id, name) where (deleted_at is null);
+
+		create table if not exists learned_column_mappings (
+			id                  uuid primary key         not null default gen_random_uuid(),
+			importer_id         uuid                     not null,
+			upload_column_name  text                     not null, -- The normalized header of the upload column
+			template_column_key text                     not null,
+			times_confirmed     int                      not null default 1,
+			created_at          timestamp with time zone not null default now(),
+			updated_at          timestamp with time zone not null default now(),
+			constraint fk_importer_id
+				foreign key (importer_id)
+					references importers(id)
+		);
+		create unique index if not exists learned_column_mappings_importer_id_upload_column_name_idx on learned_column_mappings(importer_id, upload_column_name);
+
+		create table if not exists header_synonyms (
+			id           uuid primary key         not null default gen_random_uuid(),
+			workspace_id uuid                     not null,
+			term         text                     not null,
+			synonyms     text[]                   not null default '{}',
+			created_by   uuid                     not null,
+			created_at   timestamp with time zone not null,
+			updated_by   uuid                     not null,
+			updated_at   timestamp with time zone not null,
+			deleted_by   uuid,
+			deleted_at   timestamp with time zone,
+			constraint fk_workspace_id
+				foreign key (workspace_id)
+					references workspaces(id)
+		);
+		create unique index if not exists header_synonyms_workspace_id_term_idx on header_synonyms(workspace_id, term) where (deleted_at is null);
+
 
 		/* Schema Update SQL */
 
@@ -349,5 +399,68 @@ func GetDatabaseSchemaInitSQL() string {
 
 		alter table workspaces
 			add column if not exists allowed_import_domains text[] not null default '{}';
+
+		alter table imports
+			add column if not exists column_formats jsonb;
+
+		alter table imports
+			add column if not exists num_warning_rows integer,
+			add column if not exists num_info_rows integer;
+
+		alter table template_columns
+			add column if not exists transforms jsonb not null default '[]'::jsonb;
+
+		alter table template_columns
+			add column if not exists default_value jsonb;
+
+		alter table template_columns
+			add column if not exists formula text;
+
+		alter table template_columns
+			add column if not exists is_list boolean not null default false,
+			add column if not exists delimiter text not null default '';
+
+		alter table imports
+			add column if not exists list_delimiters jsonb;
+
+		alter table upload_columns
+			add column if not exists inferred_data_type text,
+			add column if not exists inferred_data_type_confidence double precision;
+
+		alter table uploads
+			add column if not exists column_mappings jsonb not null default '{}'::jsonb;
+
+		create table if not exists schema_migrations (
+			version    text primary key not null,
+			applied_at timestamptz      not null default now()
+		);
+
+		-- Required columns imply a not_blank check on each cell, remove the not_blank validations that duplicate it once.
+		-- The imports already processed read their validations unscoped, so their stored row errors still resolve. The
+		-- first version of this migration skipped the importers with imports that had errors, which it now includes.
+		do $$
+		begin
+			if not exists (select 1 from schema_migrations where version = 'remove_required_not_blank_validations_v2') then
+				update validations v
+				set deleted_at = now()
+				from template_columns tc
+				where v.template_column_id = tc.id
+				  and tc.required
+				  and v.validate = 'not_blank'
+				  and v.severity = 'error'
+				  and coalesce(v.message, '') in ('', 'The cell must contain a value')
+				  and v.deleted_at is null;
+				insert into schema_migrations (version) values ('remove_required_not_blank_validations_v2');
+			end if;
+		end $$;
+
+		alter table uploads
+			add column if not exists matched_header_row_confidence double precision;
+
+		alter table uploads
+			add column if not exists header_row_end_index integer;
+
+		alter table template_columns
+			add column if not exists synonyms text[] not null default '{}';
 	`
 }
@@ -90,6 +90,10 @@ func ClearUploadColumnTemplateColumnIDs(upload *model.Upload) error {
 
 func SetTemplateColumnIDs(upload *model.Upload, columnMapping map[string]string) error {
 	numParams := len(columnMapping)
+	if numParams == 0 {
+		// Only splits or merges were mapped
+		return nil
+	}
 	valuesStmt := make([]string, numParams, numParams)
 	values := make([]interface{}, numParams*2, numParams*2)
 	var si, vi int
@@ -118,3 +122,15 @@ func SetTemplateColumnIDs(upload *model.Upload, columnMapping map[string]string)
 	}
 	return err
 }
+
+// SetUploadColumnMappings sets the splits and merges of the column mapping on the upload
+func SetUploadColumnMappings(upload *model.Upload, mappings model.UploadColumnMappings) error {
+	if upload == nil || !upload.ID.Valid {
+		return errors.New("no upload provided")
+	}
+	err := tf.DB.Model(&model.Upload{}).Where("id = ?", upload.ID).Update("column_mappings", mappings).Error
+	if err == nil {
+		upload.ColumnMappings = mappings
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/araddon/dateparse"
+	"strings"
+	"tableflow/go/pkg/util"
+	"time"
+)
+
+type CompareEvaluator struct {
+	Operator string
+	Key      string
+	// CellDateParser and OtherDateParser parse the dates of the cell and of the other cell with the formats of their
+	// columns. Without them, dates in any recognizable format are compared.
+	CellDateParser  DateParser
+	OtherDateParser DateParser
+}
+
+var compareOperators = map[string]string{
+	"eq":  "equal to",
+	"neq": "different from",
+	"gt":  "greater than",
+	"gte": "greater than or equal to",
+	"lt":  "less than",
+	"lte": "less than or equal to",
+}
+
+func (e *CompareEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return errors.New("not provided")
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	operator, _ := optionsMap["operator"].(string)
+	key, _ := optionsMap["key"].(string)
+	operator = strings.ToLower(strings.TrimSpace(operator))
+	if _, ok = compareOperators[operator]; !ok {
+		return fmt.Errorf("operator must be one of eq, neq, gt, gte, lt, lte")
+	}
+	if len(key) == 0 {
+		return errors.New("key is required")
+	}
+	e.Operator = operator
+	e.Key = key
+	return nil
+}
+
+func (e CompareEvaluator) Evaluate(cell string) (bool, string, error) {
+	return e.EvaluateRow(cell, nil)
+}
+
+func (e CompareEvaluator) EvaluateRow(cell string, row map[string]string) (bool, string, error) {
+	if len(e.Operator) == 0 {
+		return false, cell, errors.New("uninitialized compare evaluator")
+	}
+	other := row[e.Key]
+	// Blank values are not compared, not_blank should be used to require them
+	if util.IsBlankUnicode(cell) || util.IsBlankUnicode(other) {
+		return true, cell, nil
+	}
+	cmp := compareCellValues(cell, other, e.CellDateParser, e.OtherDateParser)
+	switch e.Operator {
+	case "eq":
+		return cmp == 0, cell, nil
+	case "neq":
+		return cmp != 0, cell, nil
+	case "gt":
+		return cmp > 0, cell, nil
+	case "gte":
+		return cmp >= 0, cell, nil
+	case "lt":
+		return cmp < 0, cell, nil
+	case "lte":
+		return cmp <= 0, cell, nil
+	}
+	return false, cell, fmt.Errorf("invalid operator %s", e.Operator)
+}
+
+func (e *CompareEvaluator) SetDateParsers(key string, parsers map[string]DateParser) {
+	e.CellDateParser = parsers[key]
+	e.OtherDateParser = parsers[e.Key]
+}
+
+func (e CompareEvaluator) ReferencedKeys() []string {
+	return []string{e.Key}
+}
+
+func (e CompareEvaluator) DefaultMessage() string {
+	return fmt.Sprintf("The value must be %s the value of %s", compareOperators[e.Operator], e.Key)
+}
+
+func (e CompareEvaluator) AllowedDataTypes() []string {
+	return []string{"string", "number", "date", "integer", "decimal", "datetime", "enum"}
+}
+
+// compareCellValues compares two cells as numbers if both are numeric, as dates if both are dates, and otherwise as
+// trimmed strings. The date parsers of the cells are used if set.
+func compareCellValues(a, b string, aDateParser, bDateParser DateParser) int {
+	a = strings.TrimSpace(a)
+	b = strings.TrimSpace(b)
+	if af, ok := util.StringToBigFloat(a); ok {
+		if bf, ok := util.StringToBigFloat(b); ok {
+			return af.Cmp(bf.Float)
+		}
+	}
+	if at, ok := parseCompareDate(a, aDateParser); ok {
+		if bt, ok := parseCompareDate(b, bDateParser); ok {
+			return at.Compare(bt)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// parseCompareDate parses the cell with the date parser of its column. Cells the parser can't parse are parsed in any
+// recognizable format, as they're already normalized (i.e. RFC3339) once the date validation of the column passes.
+func parseCompareDate(cell string, parser DateParser) (time.Time, bool) {
+	if parser != nil {
+		if t, err := parser.ParseDate(cell); err == nil {
+			return t, true
+		}
+	}
+	t, err := dateparse.ParseAny(cell)
+	return t, err == nil
+}
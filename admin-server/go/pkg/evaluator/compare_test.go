@@ -0,0 +1,105 @@
+package evaluator
+
+import "testing"
+
+func TestCompareEvaluator(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator string
+		cell     string
+		other    string
+		wantPass bool
+	}{
+		{name: "numbers equal", operator: "eq", cell: "10", other: "10.0", wantPass: true},
+		{name: "numbers not equal", operator: "eq", cell: "10", other: "11", wantPass: false},
+		{name: "numbers are not compared as strings", operator: "gt", cell: "10", other: "9", wantPass: true},
+		{name: "grouped numbers", operator: "lte", cell: "1,000", other: "1000", wantPass: true},
+		{name: "dates", operator: "lt", cell: "2024-01-31", other: "2024-02-01", wantPass: true},
+		{name: "dates in different formats", operator: "eq", cell: "2024-02-01", other: "February 1, 2024", wantPass: true},
+		{name: "datetime and date", operator: "gte", cell: "2024-02-01T10:00:00Z", other: "2024-02-01", wantPass: true},
+		{name: "strings", operator: "neq", cell: "abc", other: " abd ", wantPass: true},
+		{name: "strings trimmed", operator: "eq", cell: " abc", other: "abc ", wantPass: true},
+		{name: "blank cell", operator: "gt", cell: " ", other: "10", wantPass: true},
+		{name: "blank other cell", operator: "gt", cell: "10", other: "", wantPass: true},
+		{name: "greater than or equal", operator: "gte", cell: "5", other: "5", wantPass: true},
+		{name: "less than", operator: "lt", cell: "5", other: "5", wantPass: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &CompareEvaluator{}
+			if err := e.Initialize(map[string]interface{}{"operator": tt.operator, "key": "other"}); err != nil {
+				t.Fatalf("Initialize returned error: %v", err)
+			}
+			passed, value, err := e.EvaluateRow(tt.cell, map[string]string{"other": tt.other})
+			if err != nil {
+				t.Fatalf("EvaluateRow(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass {
+				t.Errorf("EvaluateRow(%q, %q) passed = %v, want %v", tt.cell, tt.other, passed, tt.wantPass)
+			}
+			if value != tt.cell {
+				t.Errorf("EvaluateRow(%q) value = %q, want the cell unchanged", tt.cell, value)
+			}
+		})
+	}
+}
+
+func TestCompareEvaluatorDateFormats(t *testing.T) {
+	dayFirst := &DateEvaluator{}
+	if err := dayFirst.Initialize(map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY"}}); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	tests := []struct {
+		name     string
+		parsers  map[string]DateParser
+		cell     string
+		other    string
+		wantPass bool
+	}{
+		// 03/04/2024 is the 3rd of April with the format of the columns, and the 4th of March otherwise
+		{name: "month-first without the formats", parsers: nil, cell: "03/04/2024", other: "15/03/2024", wantPass: false},
+		{name: "formats of the columns", parsers: map[string]DateParser{"start": dayFirst, "end": dayFirst}, cell: "03/04/2024", other: "15/03/2024", wantPass: true},
+		{name: "normalized other cell", parsers: map[string]DateParser{"start": dayFirst, "end": dayFirst}, cell: "03/04/2024", other: "2024-03-15", wantPass: true},
+		{name: "format of one column", parsers: map[string]DateParser{"start": dayFirst}, cell: "03/04/2024", other: "03/15/2024", wantPass: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &CompareEvaluator{}
+			if err := e.Initialize(map[string]interface{}{"operator": "gt", "key": "end"}); err != nil {
+				t.Fatalf("Initialize returned error: %v", err)
+			}
+			e.SetDateParsers("start", tt.parsers)
+			passed, _, err := e.EvaluateRow(tt.cell, map[string]string{"end": tt.other})
+			if err != nil {
+				t.Fatalf("EvaluateRow(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass {
+				t.Errorf("EvaluateRow(%q, %q) passed = %v, want %v", tt.cell, tt.other, passed, tt.wantPass)
+			}
+		})
+	}
+}
+
+func TestCompareEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+		wantErr bool
+	}{
+		{name: "valid", options: map[string]interface{}{"operator": "gte", "key": "start_date"}},
+		{name: "operator is case insensitive", options: map[string]interface{}{"operator": " LT ", "key": "start_date"}},
+		{name: "nil", options: nil, wantErr: true},
+		{name: "not an object", options: "gt", wantErr: true},
+		{name: "invalid operator", options: map[string]interface{}{"operator": "greater", "key": "start_date"}, wantErr: true},
+		{name: "missing key", options: map[string]interface{}{"operator": "gt"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &CompareEvaluator{}
+			err := e.Initialize(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
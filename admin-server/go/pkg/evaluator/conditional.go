@@ -0,0 +1,206 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"strings"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/util"
+)
+
+// ConditionalEvaluator runs another evaluator on the cell only when all conditions on other cells in the row are met
+// Example options:
+// {"when": {"key": "country", "operator": "equals", "value": "US"}, "validate": "regex", "options": "^\\d{5}$"}
+type ConditionalEvaluator struct {
+	Conditions []ConditionalEvaluatorCondition
+	Validate   string
+	Evaluator  Evaluator
+}
+
+type ConditionalEvaluatorCondition struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+func (e *ConditionalEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return errors.New("not provided")
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+
+	var conditionsRaw []interface{}
+	switch v := optionsMap["when"].(type) {
+	case map[string]interface{}:
+		conditionsRaw = []interface{}{v}
+	case []interface{}:
+		conditionsRaw = v
+	default:
+		return errors.New("when must be a condition object or an array of condition objects")
+	}
+	if len(conditionsRaw) == 0 {
+		return errors.New("at least one condition is required")
+	}
+	conditions := make([]ConditionalEvaluatorCondition, 0, len(conditionsRaw))
+	for _, c := range conditionsRaw {
+		condition, err := parseCondition(c)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	validate, _ := optionsMap["validate"].(string)
+	if len(validate) == 0 {
+		return errors.New("validate is required")
+	}
+	innerOptions, err := jsonb.FromInterface(optionsMap["options"])
+	if err != nil {
+		return errors.New("invalid options json")
+	}
+	inner, err := Parse(validate, innerOptions)
+	if err != nil {
+		return err
+	}
+
+	e.Conditions = conditions
+	e.Validate = validate
+	e.Evaluator = inner
+	return nil
+}
+
+func parseCondition(raw interface{}) (ConditionalEvaluatorCondition, error) {
+	condition := ConditionalEvaluatorCondition{}
+	conditionMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return condition, errors.New("each condition must be an object")
+	}
+	condition.Key, _ = conditionMap["key"].(string)
+	condition.Operator, _ = conditionMap["operator"].(string)
+	condition.Operator = strings.ToLower(strings.TrimSpace(condition.Operator))
+	if len(condition.Key) == 0 {
+		return condition, errors.New("each condition requires a key")
+	}
+	if len(condition.Operator) == 0 {
+		condition.Operator = "equals"
+	}
+
+	switch v := conditionMap["value"].(type) {
+	case nil:
+	case string:
+		condition.Values = []string{v}
+	case float64, bool:
+		condition.Values = []string{fmt.Sprint(v)}
+	case []interface{}:
+		for _, value := range v {
+			condition.Values = append(condition.Values, fmt.Sprint(value))
+		}
+	default:
+		return condition, fmt.Errorf("invalid value for the condition on %s", condition.Key)
+	}
+
+	switch condition.Operator {
+	case "equals", "not_equals":
+		if len(condition.Values) != 1 {
+			return condition, fmt.Errorf("the operator %s requires a single value", condition.Operator)
+		}
+	case "in", "not_in":
+		if len(condition.Values) == 0 {
+			return condition, fmt.Errorf("the operator %s requires an array of values", condition.Operator)
+		}
+	case "blank", "not_blank":
+	default:
+		return condition, fmt.Errorf("invalid condition operator %s", condition.Operator)
+	}
+	return condition, nil
+}
+
+func (c ConditionalEvaluatorCondition) matches(row map[string]string) bool {
+	value := strings.TrimSpace(row[c.Key])
+	equalsAny := lo.ContainsBy(c.Values, func(v string) bool {
+		return strings.EqualFold(value, strings.TrimSpace(v))
+	})
+	switch c.Operator {
+	case "equals", "in":
+		return equalsAny
+	case "not_equals", "not_in":
+		return !equalsAny
+	case "blank":
+		return util.IsBlankUnicode(value)
+	case "not_blank":
+		return !util.IsBlankUnicode(value)
+	}
+	return false
+}
+
+func (c ConditionalEvaluatorCondition) String() string {
+	quoted := lo.Map(c.Values, func(v string, _ int) string { return fmt.Sprintf("'%s'", v) })
+	switch c.Operator {
+	case "equals":
+		return fmt.Sprintf("%s is %s", c.Key, quoted[0])
+	case "not_equals":
+		return fmt.Sprintf("%s is not %s", c.Key, quoted[0])
+	case "in":
+		return fmt.Sprintf("%s is one of %s", c.Key, strings.Join(quoted, ", "))
+	case "not_in":
+		return fmt.Sprintf("%s is not one of %s", c.Key, strings.Join(quoted, ", "))
+	case "blank":
+		return fmt.Sprintf("%s is blank", c.Key)
+	case "not_blank":
+		return fmt.Sprintf("%s is not blank", c.Key)
+	}
+	return c.Key
+}
+
+func (e ConditionalEvaluator) Evaluate(cell string) (bool, string, error) {
+	return e.EvaluateRow(cell, nil)
+}
+
+func (e ConditionalEvaluator) EvaluateRow(cell string, row map[string]string) (bool, string, error) {
+	if e.Evaluator == nil {
+		return false, cell, errors.New("uninitialized conditional evaluator")
+	}
+	for _, condition := range e.Conditions {
+		if !condition.matches(row) {
+			return true, cell, nil
+		}
+	}
+	if rowEvaluator, ok := e.Evaluator.(RowEvaluator); ok {
+		return rowEvaluator.EvaluateRow(cell, row)
+	}
+	return e.Evaluator.Evaluate(cell)
+}
+
+func (e *ConditionalEvaluator) SetDateParsers(key string, parsers map[string]DateParser) {
+	if dateComparer, ok := e.Evaluator.(DateComparer); ok {
+		dateComparer.SetDateParsers(key, parsers)
+	}
+}
+
+func (e ConditionalEvaluator) ReferencedKeys() []string {
+	keys := lo.Map(e.Conditions, func(c ConditionalEvaluatorCondition, _ int) string { return c.Key })
+	if rowEvaluator, ok := e.Evaluator.(RowEvaluator); ok {
+		keys = append(keys, rowEvaluator.ReferencedKeys()...)
+	}
+	return lo.Uniq(keys)
+}
+
+func (e ConditionalEvaluator) DefaultMessage() string {
+	conditions := lo.Map(e.Conditions, func(c ConditionalEvaluatorCondition, _ int) string { return c.String() })
+	message := "The cell is invalid"
+	if e.Evaluator != nil {
+		message = e.Evaluator.DefaultMessage()
+	}
+	return fmt.Sprintf("%s when %s", message, strings.Join(conditions, " and "))
+}
+
+func (e ConditionalEvaluator) AllowedDataTypes() []string {
+	if e.Evaluator == nil {
+		return AllDataTypes
+	}
+	return e.Evaluator.AllowedDataTypes()
+}
@@ -0,0 +1,203 @@
+package evaluator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionalEvaluator(t *testing.T) {
+	zipCode := map[string]interface{}{"validate": "regex", "options": "^\\d{5}$"}
+	withWhen := func(options map[string]interface{}, when interface{}) map[string]interface{} {
+		merged := map[string]interface{}{"when": when}
+		for k, v := range options {
+			merged[k] = v
+		}
+		return merged
+	}
+	tests := []struct {
+		name     string
+		options  map[string]interface{}
+		cell     string
+		row      map[string]string
+		wantPass bool
+	}{
+		{
+			name:     "condition met and cell passes",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "value": "US"}),
+			cell:     "12345",
+			row:      map[string]string{"country": "US"},
+			wantPass: true,
+		},
+		{
+			name:     "condition met and cell fails",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "value": "US"}),
+			cell:     "SW1A 1AA",
+			row:      map[string]string{"country": "US"},
+			wantPass: false,
+		},
+		{
+			name:     "condition is case insensitive and trimmed",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "value": "US"}),
+			cell:     "SW1A 1AA",
+			row:      map[string]string{"country": " us "},
+			wantPass: false,
+		},
+		{
+			name:     "condition not met",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "value": "US"}),
+			cell:     "SW1A 1AA",
+			row:      map[string]string{"country": "GB"},
+			wantPass: true,
+		},
+		{
+			name:     "not_equals",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "operator": "not_equals", "value": "GB"}),
+			cell:     "SW1A 1AA",
+			row:      map[string]string{"country": "US"},
+			wantPass: false,
+		},
+		{
+			name:     "in",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "operator": "in", "value": []interface{}{"US", "PR"}}),
+			cell:     "abc",
+			row:      map[string]string{"country": "PR"},
+			wantPass: false,
+		},
+		{
+			name:     "not_in",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "country", "operator": "not_in", "value": []interface{}{"US", "PR"}}),
+			cell:     "abc",
+			row:      map[string]string{"country": "PR"},
+			wantPass: true,
+		},
+		{
+			name:     "blank",
+			options:  withWhen(map[string]interface{}{"validate": "not_blank"}, map[string]interface{}{"key": "phone", "operator": "blank"}),
+			cell:     "",
+			row:      map[string]string{"phone": " "},
+			wantPass: false,
+		},
+		{
+			name:     "not_blank on an unmapped key",
+			options:  withWhen(map[string]interface{}{"validate": "not_blank"}, map[string]interface{}{"key": "phone", "operator": "not_blank"}),
+			cell:     "",
+			row:      map[string]string{},
+			wantPass: true,
+		},
+		{
+			name: "all conditions must be met",
+			options: withWhen(zipCode, []interface{}{
+				map[string]interface{}{"key": "country", "value": "US"},
+				map[string]interface{}{"key": "type", "value": "home"},
+			}),
+			cell:     "abc",
+			row:      map[string]string{"country": "US", "type": "work"},
+			wantPass: true,
+		},
+		{
+			name:     "numeric condition value",
+			options:  withWhen(zipCode, map[string]interface{}{"key": "region", "value": float64(1)}),
+			cell:     "abc",
+			row:      map[string]string{"region": "1"},
+			wantPass: false,
+		},
+		{
+			name: "row evaluator",
+			options: withWhen(
+				map[string]interface{}{"validate": "compare", "options": map[string]interface{}{"operator": "gte", "key": "start_date"}},
+				map[string]interface{}{"key": "status", "value": "closed"},
+			),
+			cell:     "2024-01-01",
+			row:      map[string]string{"status": "closed", "start_date": "2024-02-01"},
+			wantPass: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ConditionalEvaluator{}
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, _, err := e.EvaluateRow(tt.cell, tt.row)
+			if err != nil {
+				t.Fatalf("EvaluateRow(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass {
+				t.Errorf("EvaluateRow(%q, %v) passed = %v, want %v", tt.cell, tt.row, passed, tt.wantPass)
+			}
+		})
+	}
+}
+
+func TestConditionalEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+		wantErr bool
+	}{
+		{name: "valid", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "value": "US"}, "validate": "not_blank"}},
+		{name: "nil", options: nil, wantErr: true},
+		{name: "missing when", options: map[string]interface{}{"validate": "not_blank"}, wantErr: true},
+		{name: "no conditions", options: map[string]interface{}{"when": []interface{}{}, "validate": "not_blank"}, wantErr: true},
+		{name: "condition without a key", options: map[string]interface{}{"when": map[string]interface{}{"value": "US"}, "validate": "not_blank"}, wantErr: true},
+		{name: "invalid operator", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "operator": "like", "value": "US"}, "validate": "not_blank"}, wantErr: true},
+		{name: "equals with several values", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "value": []interface{}{"US", "PR"}}, "validate": "not_blank"}, wantErr: true},
+		{name: "in without values", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "operator": "in"}, "validate": "not_blank"}, wantErr: true},
+		{name: "missing validate", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "value": "US"}}, wantErr: true},
+		{name: "unknown validate", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "value": "US"}, "validate": "zip"}, wantErr: true},
+		{name: "invalid inner options", options: map[string]interface{}{"when": map[string]interface{}{"key": "country", "value": "US"}, "validate": "regex"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ConditionalEvaluator{}
+			err := e.Initialize(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConditionalEvaluatorReferencedKeys(t *testing.T) {
+	e := &ConditionalEvaluator{}
+	err := e.Initialize(map[string]interface{}{
+		"when":     []interface{}{map[string]interface{}{"key": "status", "value": "closed"}, map[string]interface{}{"key": "end_date", "operator": "not_blank"}},
+		"validate": "compare",
+		"options":  map[string]interface{}{"operator": "lte", "key": "end_date"},
+	})
+	if err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	want := []string{"status", "end_date"}
+	if got := e.ReferencedKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReferencedKeys() = %v, want %v", got, want)
+	}
+	wantMessage := "The value must be less than or equal to the value of end_date when status is 'closed' and end_date is not blank"
+	if got := e.DefaultMessage(); got != wantMessage {
+		t.Errorf("DefaultMessage() = %q, want %q", got, wantMessage)
+	}
+}
+
+func TestConditionalEvaluatorDateParsers(t *testing.T) {
+	dayFirst := &DateEvaluator{}
+	if err := dayFirst.Initialize(map[string]interface{}{"formats": "DD/MM/YYYY"}); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	e := &ConditionalEvaluator{}
+	err := e.Initialize(map[string]interface{}{
+		"when":     map[string]interface{}{"key": "status", "value": "closed"},
+		"validate": "compare",
+		"options":  map[string]interface{}{"operator": "gt", "key": "start_date"},
+	})
+	if err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	e.SetDateParsers("end_date", map[string]DateParser{"start_date": dayFirst, "end_date": dayFirst})
+	passed, _, err := e.EvaluateRow("03/04/2024", map[string]string{"status": "closed", "start_date": "15/03/2024"})
+	if err != nil {
+		t.Fatalf("EvaluateRow returned error: %v", err)
+	}
+	if !passed {
+		t.Errorf("EvaluateRow did not pass, want the dates compared with the formats of the columns")
+	}
+}
@@ -0,0 +1,74 @@
+package evaluator
+
+import (
+	"errors"
+	"golang.org/x/text/language"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// CountryCodeEvaluator validates ISO 3166-1 country codes. Both alpha-2 (US) and alpha-3 (USA) codes are accepted and
+// normalized to the uppercase code of the output format, which is alpha2 unless provided.
+// Example options: {"format": "alpha3"}
+type CountryCodeEvaluator struct {
+	Format string
+}
+
+const (
+	CountryCodeFormatAlpha2 = "alpha2"
+	CountryCodeFormatAlpha3 = "alpha3"
+)
+
+func (e *CountryCodeEvaluator) Initialize(options interface{}) error {
+	e.Format = CountryCodeFormatAlpha2
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	if v, exists := optionsMap["format"]; exists && v != nil {
+		format, _ := v.(string)
+		if format != CountryCodeFormatAlpha2 && format != CountryCodeFormatAlpha3 {
+			return errors.New("format must be alpha2 or alpha3")
+		}
+		e.Format = format
+	}
+	return nil
+}
+
+func (e CountryCodeEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	trimmed := strings.TrimSpace(cell)
+	if len(trimmed) != 2 && len(trimmed) != 3 {
+		return false, cell, nil
+	}
+	for _, r := range trimmed {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') {
+			// Numeric (UN M.49) codes are not country codes
+			return false, cell, nil
+		}
+	}
+	region, err := language.ParseRegion(trimmed)
+	if err != nil || !region.IsCountry() {
+		return false, cell, nil
+	}
+	if e.Format == CountryCodeFormatAlpha3 {
+		return true, region.ISO3(), nil
+	}
+	return true, region.String(), nil
+}
+
+func (e CountryCodeEvaluator) DefaultMessage() string {
+	if e.Format == CountryCodeFormatAlpha3 {
+		return "The cell must be a valid ISO 3166-1 country code (i.e. USA)"
+	}
+	return "The cell must be a valid ISO 3166-1 country code (i.e. US)"
+}
+
+func (e CountryCodeEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
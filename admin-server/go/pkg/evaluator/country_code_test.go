@@ -0,0 +1,19 @@
+package evaluator
+
+import "testing"
+
+func TestCountryCodeEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &CountryCodeEvaluator{} }, []evaluateTest{
+		{name: "alpha-2", cell: "us", wantPass: true, wantValue: "US"},
+		{name: "alpha-3 normalized to alpha-2", cell: " DEU ", wantPass: true, wantValue: "DE"},
+		{name: "alpha-3 output", options: map[string]interface{}{"format": "alpha3"}, cell: "de", wantPass: true, wantValue: "DEU"},
+		{name: "not a country", cell: "ZZ", wantPass: false, wantValue: "ZZ"},
+		{name: "numeric code", cell: "840", wantPass: false, wantValue: "840"},
+		{name: "name", cell: "Germany", wantPass: false, wantValue: "Germany"},
+		{name: "blank", cell: "", wantPass: false, wantValue: ""},
+	})
+	runInitializeTests(t, func() Evaluator { return &CountryCodeEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil},
+		{name: "invalid format", options: map[string]interface{}{"format": "numeric"}, wantErr: true},
+	})
+}
@@ -0,0 +1,51 @@
+package evaluator
+
+import (
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// CreditCardEvaluator validates credit card numbers using the Luhn checksum. Spaces and dashes are allowed between the
+// digits, and are removed from the value.
+type CreditCardEvaluator struct{}
+
+func (e *CreditCardEvaluator) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (e CreditCardEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	number := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(cell))
+	if len(number) < 12 || len(number) > 19 {
+		return false, cell, nil
+	}
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		digit := number[len(number)-1-i]
+		if digit < '0' || digit > '9' {
+			return false, cell, nil
+		}
+		d := int(digit - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return false, cell, nil
+	}
+	return true, number, nil
+}
+
+func (e CreditCardEvaluator) DefaultMessage() string {
+	return "The cell must be a valid credit card number"
+}
+
+func (e CreditCardEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
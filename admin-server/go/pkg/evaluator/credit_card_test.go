@@ -0,0 +1,15 @@
+package evaluator
+
+import "testing"
+
+func TestCreditCardEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &CreditCardEvaluator{} }, []evaluateTest{
+		{name: "digits", cell: "4111111111111111", wantPass: true, wantValue: "4111111111111111"},
+		{name: "spaces and dashes", cell: " 4111 1111-1111 1111 ", wantPass: true, wantValue: "4111111111111111"},
+		{name: "15 digits", cell: "378282246310005", wantPass: true, wantValue: "378282246310005"},
+		{name: "checksum", cell: "4111111111111112", wantPass: false, wantValue: "4111111111111112"},
+		{name: "too short", cell: "42424242424", wantPass: false, wantValue: "42424242424"},
+		{name: "letters", cell: "4111a11111111111", wantPass: false, wantValue: "4111a11111111111"},
+		{name: "blank", cell: "", wantPass: false, wantValue: ""},
+	})
+}
@@ -0,0 +1,37 @@
+package evaluator
+
+import (
+	"golang.org/x/text/currency"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// CurrencyCodeEvaluator validates ISO 4217 currency codes, normalizing them to uppercase
+type CurrencyCodeEvaluator struct{}
+
+func (e *CurrencyCodeEvaluator) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (e CurrencyCodeEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	trimmed := strings.TrimSpace(cell)
+	if len(trimmed) != 3 {
+		return false, cell, nil
+	}
+	unit, err := currency.ParseISO(trimmed)
+	if err != nil {
+		return false, cell, nil
+	}
+	return true, unit.String(), nil
+}
+
+func (e CurrencyCodeEvaluator) DefaultMessage() string {
+	return "The cell must be a valid ISO 4217 currency code (i.e. USD)"
+}
+
+func (e CurrencyCodeEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
@@ -0,0 +1,13 @@
+package evaluator
+
+import "testing"
+
+func TestCurrencyCodeEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &CurrencyCodeEvaluator{} }, []evaluateTest{
+		{name: "uppercase", cell: "USD", wantPass: true, wantValue: "USD"},
+		{name: "lowercase", cell: " eur ", wantPass: true, wantValue: "EUR"},
+		{name: "unknown", cell: "ABC", wantPass: false, wantValue: "ABC"},
+		{name: "symbol", cell: "$", wantPass: false, wantValue: "$"},
+		{name: "blank", cell: "", wantPass: false, wantValue: ""},
+	})
+}
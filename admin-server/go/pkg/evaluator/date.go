@@ -1,26 +1,237 @@
 package evaluator
 
 import (
+	"errors"
+	"fmt"
 	"github.com/araddon/dateparse"
+	"github.com/samber/lo"
+	"strings"
 	"time"
+	_ "time/tzdata"
 )
 
-type DateEvaluator struct{}
+// DateEvaluator parses the cell as a date. All options are optional, without options any recognizable date is accepted
+// and formatted as RFC3339.
+// Example options: {"formats": ["DD/MM/YYYY"], "day_first": true, "output": "date", "timezone": "Europe/Berlin",
+// "min": "2020-01-01", "max": "2030-12-31"}
+type DateEvaluator struct {
+	Formats      []string
+	Layouts      []string
+	DayFirst     bool
+	OutputLayout string
+	Location     *time.Location
+	Min          *time.Time
+	Max          *time.Time
+}
+
+const (
+	dateOutputDate     = "date"
+	dateOutputDateTime = "datetime"
+	dateOnlyLayout     = "2006-01-02"
+)
+
+// dateLayoutTokens convert the format tokens admins are familiar with (i.e. YYYY-MM-DD) to Go layouts
+var dateLayoutTokens = map[string]string{
+	"YYYY": "2006",
+	"YY":   "06",
+	"MMMM": "January",
+	"MMM":  "Jan",
+	"MM":   "01",
+	"M":    "1",
+	"DD":   "02",
+	"D":    "2",
+	"HH":   "15",
+	"hh":   "03",
+	"h":    "3",
+	"mm":   "04",
+	"ss":   "05",
+	"A":    "PM",
+	"Z":    "Z07:00",
+}
+
+func (e *DateEvaluator) Initialize(options interface{}) error {
+	e.OutputLayout = time.RFC3339
+	e.Location = time.UTC
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+
+	switch v := optionsMap["formats"].(type) {
+	case nil:
+	case string:
+		e.Formats = []string{v}
+	case []interface{}:
+		for _, f := range v {
+			format, ok := f.(string)
+			if !ok || len(strings.TrimSpace(format)) == 0 {
+				return errors.New("formats must be an array of strings")
+			}
+			e.Formats = append(e.Formats, format)
+		}
+	default:
+		return errors.New("formats must be an array of strings")
+	}
+	for _, format := range e.Formats {
+		e.Layouts = append(e.Layouts, toGoDateLayout(format))
+	}
 
-func (e *DateEvaluator) Initialize(_ interface{}) error {
+	if v, exists := optionsMap["day_first"]; exists && v != nil {
+		if e.DayFirst, ok = v.(bool); !ok {
+			return errors.New("day_first must be a boolean")
+		}
+	}
+
+	if v, exists := optionsMap["timezone"]; exists && v != nil {
+		timezone, ok := v.(string)
+		if !ok {
+			return errors.New("timezone must be a string")
+		}
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %s", timezone)
+		}
+		e.Location = location
+	}
+
+	if v, exists := optionsMap["output"]; exists && v != nil {
+		output, ok := v.(string)
+		if !ok {
+			return errors.New("output must be a string")
+		}
+		switch output {
+		case dateOutputDate:
+			e.OutputLayout = dateOnlyLayout
+		case dateOutputDateTime:
+			e.OutputLayout = time.RFC3339
+		default:
+			e.OutputLayout = toGoDateLayout(output)
+		}
+	}
+
+	// Parse min and max after the other options so the bounds are parsed the same way as the cells
+	var err error
+	if e.Min, err = e.parseBound(optionsMap, "min"); err != nil {
+		return err
+	}
+	if e.Max, err = e.parseBound(optionsMap, "max"); err != nil {
+		return err
+	}
+	if e.Min != nil && e.Max != nil && e.Min.After(*e.Max) {
+		return errors.New("min cannot be after the max")
+	}
 	return nil
 }
 
+func (e *DateEvaluator) parseBound(optionsMap map[string]interface{}, key string) (*time.Time, error) {
+	v, exists := optionsMap[key]
+	if !exists || v == nil {
+		return nil, nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a date string", key)
+	}
+	// Bounds can always be provided as ISO dates, regardless of the accepted formats
+	t, err := time.ParseInLocation(dateOnlyLayout, str, e.Location)
+	if err == nil {
+		if key == "max" {
+			// A max date without a time includes the whole day
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return &t, nil
+	}
+	if t, err = e.parse(str); err != nil {
+		return nil, fmt.Errorf("invalid %s date %s", key, str)
+	}
+	return &t, nil
+}
+
+// toGoDateLayout converts a format such as DD/MM/YYYY to a Go layout. Formats that are already Go layouts (containing
+// the reference year 2006) are returned unchanged.
+func toGoDateLayout(format string) string {
+	if strings.Contains(format, "2006") {
+		return format
+	}
+	var sb strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		token := string(runes[i:j])
+		if layout, ok := dateLayoutTokens[token]; ok {
+			sb.WriteString(layout)
+		} else {
+			sb.WriteString(token)
+		}
+		i = j
+	}
+	return sb.String()
+}
+
+func (e DateEvaluator) parse(cell string) (time.Time, error) {
+	cell = strings.TrimSpace(cell)
+	location := e.Location
+	if location == nil {
+		location = time.UTC
+	}
+	if len(e.Layouts) != 0 {
+		for _, layout := range e.Layouts {
+			if t, err := time.ParseInLocation(layout, cell, location); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, errors.New("the date does not match any of the accepted formats")
+	}
+	return dateparse.ParseIn(cell, location, dateparse.PreferMonthFirst(!e.DayFirst), dateparse.RetryAmbiguousDateWithSwap(true))
+}
+
+// ParseDate parses the cell with the formats of the evaluator, without checking the min and max
+func (e DateEvaluator) ParseDate(cell string) (time.Time, error) {
+	return e.parse(cell)
+}
+
 func (e DateEvaluator) Evaluate(cell string) (bool, string, error) {
-	t, err := dateparse.ParseAny(cell)
+	t, err := e.parse(cell)
 	if err != nil {
 		return false, cell, nil
 	}
-	return true, t.Format(time.RFC3339), nil
+	if e.Min != nil && t.Before(*e.Min) {
+		return false, cell, nil
+	}
+	if e.Max != nil && t.After(*e.Max) {
+		return false, cell, nil
+	}
+	if e.Location != nil {
+		t = t.In(e.Location)
+	}
+	layout := e.OutputLayout
+	if len(layout) == 0 {
+		layout = time.RFC3339
+	}
+	return true, t.Format(layout), nil
 }
 
 func (e DateEvaluator) DefaultMessage() string {
-	return "The cell must be a date"
+	message := "The cell must be a date"
+	if len(e.Formats) != 0 {
+		message += fmt.Sprintf(" in the format %s", strings.Join(e.Formats, " or "))
+	}
+	boundLayout := lo.Ternary(e.OutputLayout == dateOnlyLayout || e.OutputLayout == time.RFC3339, dateOnlyLayout, e.OutputLayout)
+	switch {
+	case e.Min != nil && e.Max != nil:
+		message += fmt.Sprintf(" between %s and %s", e.Min.Format(boundLayout), e.Max.Format(boundLayout))
+	case e.Min != nil:
+		message += fmt.Sprintf(" on or after %s", e.Min.Format(boundLayout))
+	case e.Max != nil:
+		message += fmt.Sprintf(" on or before %s", e.Max.Format(boundLayout))
+	}
+	return message
 }
 
 func (e DateEvaluator) AllowedDataTypes() []string {
@@ -0,0 +1,141 @@
+package evaluator
+
+import "testing"
+
+func TestToGoDateLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "YYYY-MM-DD", want: "2006-01-02"},
+		{format: "DD/MM/YYYY", want: "02/01/2006"},
+		{format: "D/M/YY", want: "2/1/06"},
+		{format: "MMMM D, YYYY", want: "January 2, 2006"},
+		{format: "DD MMM YYYY", want: "02 Jan 2006"},
+		{format: "YYYY-MM-DD HH:mm:ss", want: "2006-01-02 15:04:05"},
+		{format: "hh:mm A", want: "03:04 PM"},
+		{format: "h:mm A", want: "3:04 PM"},
+		{format: "YYYY-MM-DDTHH:mm:ssZ", want: "2006-01-02T15:04:05Z07:00"},
+		{format: "DD.MM.YYYY", want: "02.01.2006"},
+		{format: "02/01/2006", want: "02/01/2006"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := toGoDateLayout(tt.format); got != tt.want {
+				t.Errorf("toGoDateLayout(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   map[string]interface{}
+		cell      string
+		wantPass  bool
+		wantValue string
+	}{
+		{name: "any format", cell: "2024-03-04", wantPass: true, wantValue: "2024-03-04T00:00:00Z"},
+		{name: "month-first by default", cell: "03/04/2024", wantPass: true, wantValue: "2024-03-04T00:00:00Z"},
+		{name: "day-first", options: map[string]interface{}{"day_first": true}, cell: "03/04/2024", wantPass: true, wantValue: "2024-04-03T00:00:00Z"},
+		{name: "day-first with an unambiguous month-first date", options: map[string]interface{}{"day_first": true}, cell: "12/31/2024", wantPass: true, wantValue: "2024-12-31T00:00:00Z"},
+		{name: "invalid date", cell: "not a date", wantPass: false, wantValue: "not a date"},
+		{name: "format", options: map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY"}}, cell: "03/04/2024", wantPass: true, wantValue: "2024-04-03T00:00:00Z"},
+		{name: "second format", options: map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY", "YYYY-MM-DD"}}, cell: "2024-04-03", wantPass: true, wantValue: "2024-04-03T00:00:00Z"},
+		{name: "no matching format", options: map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY"}}, cell: "2024-04-03", wantPass: false, wantValue: "2024-04-03"},
+		{name: "output date", options: map[string]interface{}{"output": "date"}, cell: "March 4, 2024", wantPass: true, wantValue: "2024-03-04"},
+		{name: "output format", options: map[string]interface{}{"output": "DD.MM.YYYY"}, cell: "2024-03-04", wantPass: true, wantValue: "04.03.2024"},
+		{name: "timezone", options: map[string]interface{}{"timezone": "Europe/Berlin"}, cell: "2024-03-04 10:00", wantPass: true, wantValue: "2024-03-04T10:00:00+01:00"},
+		{name: "min", options: map[string]interface{}{"min": "2024-01-01", "output": "date"}, cell: "2023-12-31", wantPass: false, wantValue: "2023-12-31"},
+		{name: "on the min", options: map[string]interface{}{"min": "2024-01-01", "output": "date"}, cell: "2024-01-01", wantPass: true, wantValue: "2024-01-01"},
+		{name: "max includes the whole day", options: map[string]interface{}{"max": "2024-01-01"}, cell: "2024-01-01 23:59", wantPass: true, wantValue: "2024-01-01T23:59:00Z"},
+		{name: "after the max", options: map[string]interface{}{"max": "2024-01-01", "output": "date"}, cell: "2024-01-02", wantPass: false, wantValue: "2024-01-02"},
+		{name: "min in the format", options: map[string]interface{}{"formats": "DD/MM/YYYY", "min": "01/02/2024", "output": "date"}, cell: "31/01/2024", wantPass: false, wantValue: "31/01/2024"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &DateEvaluator{}
+			var options interface{}
+			if tt.options != nil {
+				options = tt.options
+			}
+			if err := e.Initialize(options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestDateEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+		wantErr bool
+	}{
+		{name: "no options", options: nil},
+		{name: "all options", options: map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY"}, "day_first": true, "output": "date", "timezone": "UTC", "min": "2020-01-01", "max": "2030-12-31"}},
+		{name: "not an object", options: "DD/MM/YYYY", wantErr: true},
+		{name: "formats not strings", options: map[string]interface{}{"formats": []interface{}{float64(1)}}, wantErr: true},
+		{name: "blank format", options: map[string]interface{}{"formats": []interface{}{" "}}, wantErr: true},
+		{name: "day_first not a boolean", options: map[string]interface{}{"day_first": "yes"}, wantErr: true},
+		{name: "invalid timezone", options: map[string]interface{}{"timezone": "Mars/Olympus"}, wantErr: true},
+		{name: "invalid min", options: map[string]interface{}{"min": "someday"}, wantErr: true},
+		{name: "min after the max", options: map[string]interface{}{"min": "2030-01-01", "max": "2020-01-01"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &DateEvaluator{}
+			err := e.Initialize(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatetimeEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   map[string]interface{}
+		cell      string
+		wantPass  bool
+		wantValue string
+	}{
+		{name: "UTC by default", cell: "2024-03-04 10:30", wantPass: true, wantValue: "2024-03-04T10:30:00Z"},
+		{name: "converted to UTC", cell: "2024-03-04T10:30:00-05:00", wantPass: true, wantValue: "2024-03-04T15:30:00Z"},
+		{name: "converted to the timezone", options: map[string]interface{}{"timezone": "America/New_York"}, cell: "2024-03-04T15:30:00Z", wantPass: true, wantValue: "2024-03-04T10:30:00-05:00"},
+		{name: "day-first format", options: map[string]interface{}{"formats": []interface{}{"DD/MM/YYYY HH:mm"}}, cell: "03/04/2024 09:15", wantPass: true, wantValue: "2024-04-03T09:15:00Z"},
+		{name: "invalid", cell: "yesterday", wantPass: false, wantValue: "yesterday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &DatetimeEvaluator{}
+			var options interface{}
+			if tt.options != nil {
+				options = tt.options
+			}
+			if err := e.Initialize(options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+
+	if err := (&DatetimeEvaluator{}).Initialize(map[string]interface{}{"output": "date"}); err == nil {
+		t.Error("Initialize with an output did not return an error")
+	}
+}
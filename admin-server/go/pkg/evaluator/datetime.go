@@ -0,0 +1,36 @@
+package evaluator
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DatetimeEvaluator parses the cell as a date and time, and formats it as RFC3339 with the offset of the timezone (UTC
+// by default). Cells without a timezone are parsed in the timezone. The options of the date data type are supported,
+// except output.
+// Example options: {"formats": ["DD/MM/YYYY HH:mm"], "timezone": "America/New_York", "min": "2020-01-01"}
+type DatetimeEvaluator struct {
+	DateEvaluator
+}
+
+func (e *DatetimeEvaluator) Initialize(options interface{}) error {
+	if optionsMap, ok := options.(map[string]interface{}); ok {
+		if _, exists := optionsMap["output"]; exists {
+			return errors.New("output is not supported on datetimes, they are always formatted as RFC3339")
+		}
+	}
+	if err := e.DateEvaluator.Initialize(options); err != nil {
+		return err
+	}
+	e.OutputLayout = time.RFC3339
+	return nil
+}
+
+func (e DatetimeEvaluator) DefaultMessage() string {
+	return strings.Replace(e.DateEvaluator.DefaultMessage(), "The cell must be a date", "The cell must be a date and time", 1)
+}
+
+func (e DatetimeEvaluator) AllowedDataTypes() []string {
+	return []string{"datetime"}
+}
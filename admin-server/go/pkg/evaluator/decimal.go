@@ -0,0 +1,89 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"tableflow/go/pkg/util"
+)
+
+// DecimalEvaluator parses the cell as a number with a fixed number of decimal places (the scale), i.e. 12.5 with a
+// scale of 2 is normalized to 12.50. Cells with more decimal places than the scale don't pass, unless round is set, in
+// which case halves are rounded away from zero. The locale and separator options of the number data type are supported.
+// Example options: {"scale": 2, "round": true, "locale": "de-DE"}
+type DecimalEvaluator struct {
+	NumberEvaluator
+	Scale int
+	Round bool
+}
+
+const (
+	decimalDefaultScale = 2
+	decimalMaxScale     = 18
+)
+
+// decimalRegex matches decimals in the canonical form without an exponent. Exponents aren't allowed as a cell like
+// "1e1000000" would be expanded to a million digits.
+var decimalRegex = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+
+func (e *DecimalEvaluator) Initialize(options interface{}) error {
+	e.Scale = decimalDefaultScale
+	if optionsMap, ok := options.(map[string]interface{}); ok {
+		for _, key := range []string{"integer_only", "max_decimal_places"} {
+			if _, exists := optionsMap[key]; exists {
+				return fmt.Errorf("%s is not supported on decimals, use scale instead", key)
+			}
+		}
+		if v, exists := optionsMap["scale"]; exists && v != nil {
+			scale, ok := v.(float64)
+			if !ok || scale < 0 || scale > decimalMaxScale || scale != float64(int(scale)) {
+				return fmt.Errorf("scale must be a whole number from 0 to %d", decimalMaxScale)
+			}
+			e.Scale = int(scale)
+		}
+		if v, exists := optionsMap["round"]; exists && v != nil {
+			if e.Round, ok = v.(bool); !ok {
+				return errors.New("round must be a boolean")
+			}
+		}
+	}
+	return e.NumberEvaluator.Initialize(options)
+}
+
+func (e DecimalEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return true, "", nil
+	}
+	normalized, err := util.NormalizeNumberString(cell, e.NumberFormat())
+	if err != nil || !decimalRegex.MatchString(normalized) {
+		return false, cell, nil
+	}
+	// Rationals represent the decimal exactly, so the scale can be checked without floating point errors
+	r, ok := new(big.Rat).SetString(normalized)
+	if !ok {
+		return false, cell, nil
+	}
+	if !e.Round {
+		scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e.Scale)), nil)))
+		if !scaled.IsInt() {
+			return false, cell, nil
+		}
+	}
+	return true, r.FloatString(e.Scale), nil
+}
+
+func (e DecimalEvaluator) DefaultMessage() string {
+	// The message of the number evaluator with the same format, including the scale unless cells are rounded to it
+	number := e.NumberEvaluator
+	if !e.Round {
+		scale := e.Scale
+		number.MaxDecimalPlaces = &scale
+		number.IntegerOnly = scale == 0
+	}
+	return number.DefaultMessage()
+}
+
+func (e DecimalEvaluator) AllowedDataTypes() []string {
+	return []string{"decimal"}
+}
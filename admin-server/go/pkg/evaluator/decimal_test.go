@@ -0,0 +1,70 @@
+package evaluator
+
+import "testing"
+
+func TestDecimalEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   interface{}
+		cell      string
+		wantPass  bool
+		wantValue string
+	}{
+		{name: "pads to the scale", cell: "12.5", wantPass: true, wantValue: "12.50"},
+		{name: "integer", cell: "12", wantPass: true, wantValue: "12.00"},
+		{name: "group separator", cell: "1,234.5", wantPass: true, wantValue: "1234.50"},
+		{name: "leading decimal point", cell: ".5", wantPass: true, wantValue: "0.50"},
+		{name: "trailing decimal point", cell: "5.", wantPass: true, wantValue: "5.00"},
+		{name: "negative", cell: "-0.25", wantPass: true, wantValue: "-0.25"},
+		{name: "blank", cell: " ", wantPass: true, wantValue: ""},
+		{name: "more places than the scale", cell: "12.345", wantPass: false, wantValue: "12.345"},
+		{name: "trailing zeros beyond the scale", cell: "12.3400", wantPass: true, wantValue: "12.34"},
+		{name: "rounded half away from zero", options: map[string]interface{}{"round": true}, cell: "12.345", wantPass: true, wantValue: "12.35"},
+		{name: "negative rounded half away from zero", options: map[string]interface{}{"round": true}, cell: "-12.345", wantPass: true, wantValue: "-12.35"},
+		{name: "scale 0", options: map[string]interface{}{"scale": float64(0)}, cell: "7", wantPass: true, wantValue: "7"},
+		{name: "locale", options: map[string]interface{}{"locale": "de-DE"}, cell: "1.234,5", wantPass: true, wantValue: "1234.50"},
+		{name: "exponent", cell: "1e3", wantPass: false, wantValue: "1e3"},
+		{name: "large exponent", cell: "1e1000000", wantPass: false, wantValue: "1e1000000"},
+		{name: "fraction", cell: "1/3", wantPass: false, wantValue: "1/3"},
+		{name: "text", cell: "twelve", wantPass: false, wantValue: "twelve"},
+		{name: "multiple decimal separators", cell: "1.2.3", wantPass: false, wantValue: "1.2.3"},
+		{name: "hexadecimal", cell: "0x10", wantPass: false, wantValue: "0x10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &DecimalEvaluator{}
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestDecimalEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+	}{
+		{name: "scale too large", options: map[string]interface{}{"scale": float64(decimalMaxScale + 1)}},
+		{name: "negative scale", options: map[string]interface{}{"scale": float64(-1)}},
+		{name: "fractional scale", options: map[string]interface{}{"scale": 1.5}},
+		{name: "round not a boolean", options: map[string]interface{}{"round": "yes"}},
+		{name: "max_decimal_places", options: map[string]interface{}{"max_decimal_places": float64(2)}},
+		{name: "integer_only", options: map[string]interface{}{"integer_only": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &DecimalEvaluator{}
+			if err := e.Initialize(tt.options); err == nil {
+				t.Errorf("Initialize(%v) returned no error", tt.options)
+			}
+		})
+	}
+}
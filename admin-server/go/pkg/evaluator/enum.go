@@ -0,0 +1,24 @@
+package evaluator
+
+import (
+	"errors"
+)
+
+// EnumEvaluator is the validation of the enum data type, the cell must be one of the values of the enum. It supports
+// the same options as the list validation, and the values are required as there is no default.
+// Example options: ["Active", "Inactive"]
+// or {"values": ["Active", {"value": "Inactive", "aliases": ["Disabled"]}], "fuzzy": true}
+type EnumEvaluator struct {
+	ListEvaluator
+}
+
+func (e *EnumEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return errors.New("the values of the enum must be provided")
+	}
+	return e.ListEvaluator.Initialize(options)
+}
+
+func (e EnumEvaluator) AllowedDataTypes() []string {
+	return []string{"enum"}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"tableflow/go/pkg/model/jsonb"
+	"time"
 )
 
 var AllDataTypes = []string{
@@ -11,71 +12,61 @@ var AllDataTypes = []string{
 	"number",
 	"boolean",
 	"date",
+	"integer",
+	"decimal",
+	"datetime",
+	"enum",
+	"json",
 }
 
-var dataTypesWithEvaluators = []string{
-	"number",
-	"boolean",
-	"date",
+type Evaluator interface {
+	Initialize(options interface{}) error
+	Evaluate(cell string) (passed bool, value string, err error)
+	DefaultMessage() string
+	AllowedDataTypes() []string
 }
 
-var AllValidations = []string{
-	"number",
-	"boolean",
-	"date",
-	"not_blank",
-	"regex",
-	"email",
-	"phone",
-	"length",
-	"range",
-	"list",
+// RowEvaluator is implemented by evaluators that need the other values in the row to evaluate a cell, such as
+// cross-column rules. These are run after all the cells in the row have been evaluated.
+type RowEvaluator interface {
+	Evaluator
+	EvaluateRow(cell string, row map[string]string) (passed bool, value string, err error)
+	ReferencedKeys() []string
 }
 
-// TODO: Standardize this list so the slices for all data types, validations, and allowed types methods use this instead
-var DataTypeValidations = map[string][]string{
-	"string":  {"regex", "email", "phone", "length", "list"},
-	"number":  {"range"},
-	"boolean": {},
-	"date":    {},
+// BatchEvaluator is an Evaluator that can evaluate the cells of a column in batches before they're evaluated one at a
+// time, i.e. to reduce the number of requests to a remote service. The results are cached by the evaluator.
+type BatchEvaluator interface {
+	Evaluator
+	Prefetch(cells []string) error
 }
 
-type Evaluator interface {
-	Initialize(options interface{}) error
-	Evaluate(cell string) (passed bool, value string, err error)
-	DefaultMessage() string
-	AllowedDataTypes() []string
+// SuggestionEvaluator is an Evaluator that can suggest values for the cells that don't pass
+type SuggestionEvaluator interface {
+	Evaluator
+	Suggestions(cell string) []string
+}
+
+// DateParser is implemented by the evaluators of the date data types to parse cells with the formats of the column
+type DateParser interface {
+	ParseDate(cell string) (time.Time, error)
+}
+
+// DateComparer is implemented by row evaluators that compare dates. SetDateParsers is called with the key of the column
+// the validation is on and the date parsers of the template columns by key, so the dates are parsed with the formats
+// configured on their columns (i.e. DD/MM/YYYY) rather than as month-first.
+type DateComparer interface {
+	SetDateParsers(key string, parsers map[string]DateParser)
 }
 
 // TODO: Consider adding an "allow duplicate" flag so certain validations, i.e. not_blank, can only be added once
 
 func Parse(validate string, options jsonb.JSONB) (Evaluator, error) {
-	var e Evaluator
-	switch validate {
-	case "number":
-		e = &NumberEvaluator{}
-	case "boolean":
-		e = &BooleanEvaluator{}
-	case "date":
-		e = &DateEvaluator{}
-	case "not_blank":
-		e = &NotBlankEvaluator{}
-	case "regex":
-		e = &RegexEvaluator{}
-	case "email":
-		e = &EmailEvaluator{}
-	case "phone":
-		e = &PhoneEvaluator{}
-	case "length":
-		e = &LengthEvaluator{}
-	case "range":
-		e = &RangeEvaluator{}
-	case "list":
-		e = &ListEvaluator{}
-	default:
-		return nil, fmt.Errorf("The validate type %s is invalid", validate)
+	e, err := newFromRegistry(validate)
+	if err != nil {
+		return nil, err
 	}
-	err := e.Initialize(options.Data)
+	err = e.Initialize(options.Data)
 	if err != nil {
 		return nil, fmt.Errorf("Invalid %s validate options: %s", validate, err.Error())
 	}
@@ -83,12 +74,8 @@ func Parse(validate string, options jsonb.JSONB) (Evaluator, error) {
 }
 
 func IsDataTypeEvaluator(validate string) bool {
-	for _, dataType := range dataTypesWithEvaluators {
-		if dataType == validate {
-			return true
-		}
-	}
-	return false
+	d, ok := Lookup(validate)
+	return ok && d.DataType
 }
 
 type MinMaxEvaluatorOptions struct {
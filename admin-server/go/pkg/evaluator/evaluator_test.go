@@ -0,0 +1,52 @@
+package evaluator
+
+import "testing"
+
+// evaluateTest is a cell evaluated with the options of a validation, and the expected result
+type evaluateTest struct {
+	name      string
+	options   interface{}
+	cell      string
+	wantPass  bool
+	wantValue string
+}
+
+// runEvaluateTests initializes a new evaluator with the options of each test and evaluates the cell
+func runEvaluateTests(t *testing.T, newEvaluator func() Evaluator, tests []evaluateTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEvaluator()
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+}
+
+// initializeTest are the options of a validation, and whether they're invalid
+type initializeTest struct {
+	name    string
+	options interface{}
+	wantErr bool
+}
+
+// runInitializeTests initializes a new evaluator with the options of each test
+func runInitializeTests(t *testing.T, newEvaluator func() Evaluator, tests []initializeTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newEvaluator().Initialize(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
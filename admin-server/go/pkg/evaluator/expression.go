@@ -0,0 +1,84 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"tableflow/go/pkg/expr"
+)
+
+// ExpressionEvaluator evaluates a boolean expression over the cell value and the other cells in the row
+// Example options: "size(value) == 10 && value.startsWith(\"AC\")" or {"expression": "amount <= credit_limit"}
+type ExpressionEvaluator struct {
+	Expression string
+	Program    *expr.Program
+}
+
+// expressionReservedIdentifiers are the variables available to expressions that aren't template column keys
+var expressionReservedIdentifiers = []string{"value", "row"}
+
+func (e *ExpressionEvaluator) Initialize(options interface{}) error {
+	var expression string
+	switch v := options.(type) {
+	case string:
+		expression = v
+	case map[string]interface{}:
+		expression, _ = v["expression"].(string)
+	case nil:
+		return errors.New("not provided")
+	default:
+		return errors.New("must be an expression string or an object with an expression")
+	}
+	if len(expression) == 0 {
+		return errors.New("expression is required")
+	}
+	program, err := expr.Compile(expression)
+	if err != nil {
+		return err
+	}
+	e.Expression = expression
+	e.Program = program
+	return nil
+}
+
+func (e ExpressionEvaluator) Evaluate(cell string) (bool, string, error) {
+	return e.EvaluateRow(cell, nil)
+}
+
+func (e ExpressionEvaluator) EvaluateRow(cell string, row map[string]string) (bool, string, error) {
+	if e.Program == nil {
+		return false, cell, errors.New("uninitialized expression evaluator")
+	}
+	rowVars := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		rowVars[k] = v
+	}
+	vars := map[string]interface{}{
+		"value": cell,
+		"row":   rowVars,
+	}
+	for _, key := range e.ReferencedKeys() {
+		// Unmapped columns are treated as blank, the same as the other row validations
+		vars[key] = row[key]
+	}
+	passed, err := e.Program.EvalBool(vars, expr.DefaultLimits)
+	if err != nil {
+		return false, cell, err
+	}
+	return passed, cell, nil
+}
+
+func (e ExpressionEvaluator) ReferencedKeys() []string {
+	if e.Program == nil {
+		return nil
+	}
+	return lo.Without(e.Program.Identifiers(), expressionReservedIdentifiers...)
+}
+
+func (e ExpressionEvaluator) DefaultMessage() string {
+	return fmt.Sprintf("The cell must satisfy the expression %s", e.Expression)
+}
+
+func (e ExpressionEvaluator) AllowedDataTypes() []string {
+	return AllDataTypes
+}
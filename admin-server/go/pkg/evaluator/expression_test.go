@@ -0,0 +1,87 @@
+package evaluator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"tableflow/go/pkg/expr"
+	"testing"
+)
+
+func TestExpressionEvaluator(t *testing.T) {
+	row := map[string]string{"amount": "250", "credit_limit": "1000", "country": "US", "code": "AC-1001"}
+	tests := []struct {
+		name     string
+		options  interface{}
+		cell     string
+		wantPass bool
+		wantErr  bool
+	}{
+		{name: "value", options: `size(value) == 7 && value.startsWith("AC")`, cell: "AC-1001", wantPass: true},
+		{name: "value fails", options: `value.startsWith("AC")`, cell: "BC-1001", wantPass: false},
+		{name: "other cells", options: map[string]interface{}{"expression": "amount <= credit_limit"}, cell: "250", wantPass: true},
+		{name: "row variable", options: `row.country == "US" && row["code"].endsWith("1001")`, cell: "x", wantPass: true},
+		{name: "unmapped column is blank", options: `isBlank(discount)`, cell: "x", wantPass: true},
+		{name: "non-boolean result", options: `value + "!"`, cell: "x", wantErr: true},
+		{name: "evaluation error", options: `value * 2 > 1`, cell: "abc", wantErr: true},
+		{name: "cost limit", options: `split(value, "").size() > 0`, cell: strings.Repeat("a", expr.DefaultLimits.MaxCost+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ExpressionEvaluator{}
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.EvaluateRow(tt.cell, row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluateRow(%q) error = %v, wantErr %v", tt.cell, err, tt.wantErr)
+			}
+			if passed != tt.wantPass || value != tt.cell {
+				t.Errorf("EvaluateRow(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.cell)
+			}
+		})
+	}
+}
+
+func TestExpressionEvaluatorCostLimit(t *testing.T) {
+	e := &ExpressionEvaluator{}
+	if err := e.Initialize(`split(value, "").size() > 0`); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	_, _, err := e.Evaluate(strings.Repeat("a", expr.DefaultLimits.MaxCost+1))
+	if !errors.Is(err, expr.ErrCostLimitExceeded) {
+		t.Errorf("Evaluate error = %v, want %v", err, expr.ErrCostLimitExceeded)
+	}
+}
+
+func TestExpressionEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+	}{
+		{name: "nil", options: nil},
+		{name: "empty string", options: ""},
+		{name: "object without an expression", options: map[string]interface{}{"expr": "value == 1"}},
+		{name: "not a string or object", options: float64(1)},
+		{name: "syntax error", options: "value =="},
+		{name: "unknown function", options: "reverse(value) == value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ExpressionEvaluator{}
+			if err := e.Initialize(tt.options); err == nil {
+				t.Errorf("Initialize(%v) returned no error", tt.options)
+			}
+		})
+	}
+}
+
+func TestExpressionEvaluatorReferencedKeys(t *testing.T) {
+	e := &ExpressionEvaluator{}
+	if err := e.Initialize("value <= credit_limit && row.country == country"); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	if got, want := e.ReferencedKeys(), []string{"country", "credit_limit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReferencedKeys() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,334 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"tableflow/go/pkg/util"
+	"time"
+)
+
+// HTTPEvaluator validates cells with a remote endpoint, i.e. to check that an account ID exists in another system.
+// The values are sent in batches as a POST request with the body {"values": ["A-1001", "A-1002"]}, and the endpoint
+// responds with a result for each value in the same order, i.e.
+// {"results": [{"valid": true}, {"valid": true, "value": "A-1002-X"}]}. If a result contains a value, the cell is
+// replaced with it.
+//
+// Results are cached for the lifetime of the evaluator, which is parsed once per import. If the endpoint can't be
+// reached after the retries, cells pass with on_error "fail_open" or don't pass with "fail_closed" (the default), and
+// the endpoint isn't called again for the rest of the import.
+//
+// The headers are only used server-side and aren't returned with the template to the importer. Requests can't be made
+// to loopback, private, link-local or other internal addresses, which are checked after the host is resolved.
+// Example options: {"url": "https://example.com/validate-account", "headers": {"Authorization": "Bearer abc"},
+// "timeout_ms": 5000, "retries": 2, "batch_size": 100, "on_error": "fail_open"}
+type HTTPEvaluator struct {
+	URL       string
+	Headers   map[string]string
+	Timeout   time.Duration
+	Retries   int
+	BatchSize int
+	FailOpen  bool
+
+	state *httpEvaluatorState
+}
+
+const (
+	HTTPValidationFailOpen   = "fail_open"
+	HTTPValidationFailClosed = "fail_closed"
+)
+
+const (
+	httpValidationDefaultTimeout   = 5 * time.Second
+	httpValidationMaxTimeout       = 30 * time.Second
+	httpValidationDefaultRetries   = 2
+	httpValidationMaxRetries       = 5
+	httpValidationDefaultBatchSize = 100
+	httpValidationMaxBatchSize     = 1000
+	httpValidationMaxCacheSize     = 100000
+	httpValidationMaxResponseSize  = 10 * 1024 * 1024
+	httpValidationRetryBackoff     = 250 * time.Millisecond
+)
+
+type httpValidationResult struct {
+	Valid bool    `json:"valid"`
+	Value *string `json:"value"`
+}
+
+type httpEvaluatorState struct {
+	sync.Mutex
+	client *http.Client
+	cache  map[string]httpValidationResult
+	err    error // Set once the endpoint couldn't be reached, so it isn't retried for every remaining cell
+}
+
+func (e *HTTPEvaluator) Initialize(options interface{}) error {
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("must be an object with the url")
+	}
+	urlStr, _ := optionsMap["url"].(string)
+	u, err := url.ParseRequestURI(urlStr)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Hostname()) == 0 {
+		return errors.New("url must be a valid http or https URL")
+	}
+	if !isAllowedHTTPValidationHost(u.Hostname()) {
+		return errors.New("url must not point to a local or private network address")
+	}
+	e.URL = urlStr
+	e.Headers = make(map[string]string)
+	if v, exists := optionsMap["headers"]; exists && v != nil {
+		headers, ok := v.(map[string]interface{})
+		if !ok {
+			return errors.New("headers must be an object of strings")
+		}
+		for name, value := range headers {
+			if e.Headers[name], ok = value.(string); !ok {
+				return errors.New("headers must be an object of strings")
+			}
+		}
+	}
+	e.Timeout = httpValidationDefaultTimeout
+	if v, exists := optionsMap["timeout_ms"]; exists && v != nil {
+		timeout, ok := v.(float64)
+		if !ok || timeout <= 0 || time.Duration(timeout)*time.Millisecond > httpValidationMaxTimeout {
+			return fmt.Errorf("timeout_ms must be a number greater than 0 and at most %d", httpValidationMaxTimeout.Milliseconds())
+		}
+		e.Timeout = time.Duration(timeout) * time.Millisecond
+	}
+	e.Retries = httpValidationDefaultRetries
+	if v, exists := optionsMap["retries"]; exists && v != nil {
+		retries, ok := v.(float64)
+		if !ok || retries < 0 || retries > httpValidationMaxRetries || retries != float64(int(retries)) {
+			return fmt.Errorf("retries must be a whole number from 0 to %d", httpValidationMaxRetries)
+		}
+		e.Retries = int(retries)
+	}
+	e.BatchSize = httpValidationDefaultBatchSize
+	if v, exists := optionsMap["batch_size"]; exists && v != nil {
+		batchSize, ok := v.(float64)
+		if !ok || batchSize < 1 || batchSize > httpValidationMaxBatchSize || batchSize != float64(int(batchSize)) {
+			return fmt.Errorf("batch_size must be a whole number from 1 to %d", httpValidationMaxBatchSize)
+		}
+		e.BatchSize = int(batchSize)
+	}
+	if v, exists := optionsMap["on_error"]; exists && v != nil {
+		onError, _ := v.(string)
+		switch onError {
+		case HTTPValidationFailOpen:
+			e.FailOpen = true
+		case HTTPValidationFailClosed:
+		default:
+			return fmt.Errorf("on_error must be %s or %s", HTTPValidationFailOpen, HTTPValidationFailClosed)
+		}
+	}
+	e.state = &httpEvaluatorState{
+		client: newHTTPValidationClient(e.Timeout),
+		cache:  make(map[string]httpValidationResult),
+	}
+	return nil
+}
+
+// newHTTPValidationClient returns a client that refuses to connect to internal addresses. The address is checked when
+// dialing, after the host is resolved, so a hostname (or a redirect) resolving to an internal address is refused too.
+func newHTTPValidationClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isAllowedHTTPValidationAddr(addrPort.Addr()) {
+				return fmt.Errorf("the address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// carrierGradeNATPrefix is the shared address space (RFC 6598), which isn't covered by netip.Addr.IsPrivate
+var carrierGradeNATPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// isAllowedHTTPValidationHost checks the host of the url before it's resolved, i.e. to reject "localhost" or an IP
+func isAllowedHTTPValidationHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || host == "metadata.google.internal" {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return isAllowedHTTPValidationAddr(addr)
+	}
+	return true
+}
+
+// isAllowedHTTPValidationAddr returns false for loopback, private, link-local (including the cloud metadata address
+// 169.254.169.254), multicast and unspecified addresses
+func isAllowedHTTPValidationAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!carrierGradeNATPrefix.Contains(addr)
+}
+
+func (e HTTPEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	if e.state == nil {
+		return false, cell, errors.New("uninitialized http evaluator")
+	}
+	result, cached := e.cachedResult(cell)
+	if !cached {
+		// The cell wasn't prefetched, i.e. a single cell is edited
+		if err := e.Prefetch([]string{cell}); err != nil {
+			return e.FailOpen, cell, err
+		}
+		if result, cached = e.cachedResult(cell); !cached {
+			return e.FailOpen, cell, errors.New("no result was returned for the cell")
+		}
+	}
+	if !result.Valid {
+		return false, cell, nil
+	}
+	if result.Value != nil {
+		return true, *result.Value, nil
+	}
+	return true, cell, nil
+}
+
+// Prefetch sends the values that aren't cached to the endpoint in batches and caches the results
+func (e HTTPEvaluator) Prefetch(cells []string) error {
+	if e.state == nil {
+		return errors.New("uninitialized http evaluator")
+	}
+	e.state.Lock()
+	defer e.state.Unlock()
+	if e.state.err != nil {
+		return e.state.err
+	}
+	values := e.uncachedValues(cells)
+	if len(e.state.cache)+len(values) > httpValidationMaxCacheSize {
+		// Keep memory bounded on very large imports, only the results of the cells being prefetched are kept
+		e.state.cache = make(map[string]httpValidationResult)
+		values = e.uncachedValues(cells)
+	}
+	for start := 0; start < len(values); start += e.BatchSize {
+		batch := values[start:lo.Min([]int{start + e.BatchSize, len(values)})]
+		results, err := e.requestWithRetries(batch)
+		if err != nil {
+			e.state.err = fmt.Errorf("the validation endpoint request failed: %w", err)
+			return e.state.err
+		}
+		for i, value := range batch {
+			e.state.cache[value] = results[i]
+		}
+	}
+	return nil
+}
+
+// uncachedValues returns the unique non-blank cells without a cached result, the state must be locked
+func (e HTTPEvaluator) uncachedValues(cells []string) []string {
+	values := make([]string, 0, len(cells))
+	seen := make(map[string]bool, len(cells))
+	for _, cell := range cells {
+		if _, cached := e.state.cache[cell]; cached || seen[cell] || util.IsBlankUnicode(cell) {
+			continue
+		}
+		seen[cell] = true
+		values = append(values, cell)
+	}
+	return values
+}
+
+func (e HTTPEvaluator) cachedResult(cell string) (httpValidationResult, bool) {
+	e.state.Lock()
+	defer e.state.Unlock()
+	result, ok := e.state.cache[cell]
+	return result, ok
+}
+
+func (e HTTPEvaluator) requestWithRetries(values []string) ([]httpValidationResult, error) {
+	var err error
+	for attempt := 0; attempt <= e.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(httpValidationRetryBackoff * time.Duration(1<<(attempt-1)))
+		}
+		var results []httpValidationResult
+		var retryable bool
+		if results, retryable, err = e.request(values); err == nil {
+			return results, nil
+		}
+		if !retryable {
+			break
+		}
+	}
+	return nil, err
+}
+
+// request performs a single request, returning whether the request can be retried if it wasn't successful
+func (e HTTPEvaluator) request(values []string) ([]httpValidationResult, bool, error) {
+	requestBytes, err := json.Marshal(map[string]interface{}{"values": values})
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.URL, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.Headers {
+		req.Header.Set(name, value)
+	}
+	response, err := e.state.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, httpValidationMaxResponseSize))
+	if err != nil {
+		return nil, true, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		retryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+		return nil, retryable, fmt.Errorf("received status code %d", response.StatusCode)
+	}
+	var responseData struct {
+		Results []httpValidationResult `json:"results"`
+	}
+	if err = json.Unmarshal(responseBody, &responseData); err != nil {
+		return nil, false, fmt.Errorf("invalid response body: %w", err)
+	}
+	if len(responseData.Results) != len(values) {
+		return nil, false, fmt.Errorf("received %d results for %d values", len(responseData.Results), len(values))
+	}
+	return responseData.Results, false, nil
+}
+
+func (e HTTPEvaluator) DefaultMessage() string {
+	return "The cell did not pass the remote validation"
+}
+
+func (e HTTPEvaluator) AllowedDataTypes() []string {
+	return []string{"string", "number", "boolean", "date", "integer", "decimal", "datetime", "enum"}
+}
@@ -0,0 +1,229 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+// newTestHTTPEvaluator initializes the evaluator with the options and points it to the test server, which is on a
+// loopback address so the client used by the evaluator would refuse to connect to it
+func newTestHTTPEvaluator(t *testing.T, server *httptest.Server, options map[string]interface{}) *HTTPEvaluator {
+	t.Helper()
+	options["url"] = "https://validate.example.com"
+	e := &HTTPEvaluator{}
+	if err := e.Initialize(options); err != nil {
+		t.Fatalf("Initialize(%v) returned error: %v", options, err)
+	}
+	e.URL = server.URL
+	e.state.client = server.Client()
+	return e
+}
+
+func TestHTTPEvaluator(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Values []string `json:"values"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		results := make([]map[string]interface{}, len(body.Values))
+		for i, v := range body.Values {
+			results[i] = map[string]interface{}{"valid": strings.HasPrefix(v, "A-")}
+			if v == "A-1002" {
+				results[i]["value"] = "A-1002-X"
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer server.Close()
+
+	e := newTestHTTPEvaluator(t, server, map[string]interface{}{
+		"headers":    map[string]interface{}{"Authorization": "Bearer abc"},
+		"batch_size": float64(2),
+	})
+	if err := e.Prefetch([]string{"A-1001", "A-1002", "B-1003", "A-1001", " "}); err != nil {
+		t.Fatalf("Prefetch returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("Prefetch made %d requests for 3 unique values in batches of 2, want 2", got)
+	}
+
+	tests := []struct {
+		cell      string
+		wantPass  bool
+		wantValue string
+	}{
+		{cell: "A-1001", wantPass: true, wantValue: "A-1001"},
+		{cell: "A-1002", wantPass: true, wantValue: "A-1002-X"},
+		{cell: "B-1003", wantPass: false, wantValue: "B-1003"},
+		{cell: "A-1004", wantPass: true, wantValue: "A-1004"},
+		{cell: "", wantPass: false, wantValue: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+	// Only the cell that wasn't prefetched is requested
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("made %d requests, want 3", got)
+	}
+}
+
+func TestHTTPEvaluatorOnError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		onError  string
+		wantPass bool
+	}{
+		{onError: HTTPValidationFailClosed, wantPass: false},
+		{onError: HTTPValidationFailOpen, wantPass: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.onError, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			e := newTestHTTPEvaluator(t, server, map[string]interface{}{"on_error": tt.onError})
+			for _, cell := range []string{"A-1001", "A-1002"} {
+				passed, _, err := e.Evaluate(cell)
+				if err == nil {
+					t.Errorf("Evaluate(%q) returned no error", cell)
+				}
+				if passed != tt.wantPass {
+					t.Errorf("Evaluate(%q) passed = %v, want %v", cell, passed, tt.wantPass)
+				}
+			}
+			// Client errors aren't retried, and the endpoint isn't called again once it failed
+			if got := atomic.LoadInt32(&requests); got != 1 {
+				t.Errorf("made %d requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestHTTPEvaluatorRefusesInternalAddresses(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []map[string]interface{}{{"valid": true}}})
+	}))
+	defer server.Close()
+
+	e := &HTTPEvaluator{}
+	if err := e.Initialize(map[string]interface{}{"url": "https://validate.example.com", "retries": float64(0)}); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	// The test server listens on a loopback address, which the client must refuse to dial
+	e.URL = server.URL
+	if passed, _, err := e.Evaluate("A-1001"); passed || err == nil {
+		t.Errorf("Evaluate = %v, %v, want the request to be refused", passed, err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("made %d requests to a loopback address, want 0", got)
+	}
+}
+
+func TestHTTPEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+		wantErr bool
+	}{
+		{name: "valid", options: map[string]interface{}{"url": "https://example.com/validate"}},
+		{name: "all options", options: map[string]interface{}{"url": "http://example.com", "headers": map[string]interface{}{"X-Key": "abc"}, "timeout_ms": float64(1000), "retries": float64(0), "batch_size": float64(10), "on_error": "fail_open"}},
+		{name: "not an object", options: "https://example.com", wantErr: true},
+		{name: "missing url", options: map[string]interface{}{}, wantErr: true},
+		{name: "unsupported scheme", options: map[string]interface{}{"url": "ftp://example.com"}, wantErr: true},
+		{name: "localhost", options: map[string]interface{}{"url": "http://localhost:8080"}, wantErr: true},
+		{name: "loopback address", options: map[string]interface{}{"url": "http://127.0.0.1"}, wantErr: true},
+		{name: "private address", options: map[string]interface{}{"url": "http://10.0.0.5/validate"}, wantErr: true},
+		{name: "metadata address", options: map[string]interface{}{"url": "http://169.254.169.254/latest/meta-data"}, wantErr: true},
+		{name: "metadata host", options: map[string]interface{}{"url": "http://metadata.google.internal"}, wantErr: true},
+		{name: "IPv6 loopback", options: map[string]interface{}{"url": "http://[::1]:8080"}, wantErr: true},
+		{name: "header not a string", options: map[string]interface{}{"url": "https://example.com", "headers": map[string]interface{}{"X-Key": 1.0}}, wantErr: true},
+		{name: "timeout too long", options: map[string]interface{}{"url": "https://example.com", "timeout_ms": float64(60000)}, wantErr: true},
+		{name: "too many retries", options: map[string]interface{}{"url": "https://example.com", "retries": float64(6)}, wantErr: true},
+		{name: "fractional batch size", options: map[string]interface{}{"url": "https://example.com", "batch_size": 1.5}, wantErr: true},
+		{name: "invalid on_error", options: map[string]interface{}{"url": "https://example.com", "on_error": "ignore"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &HTTPEvaluator{}
+			if err := e.Initialize(tt.options); (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsAllowedHTTPValidationAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.216.34", want: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.1.2.3", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isAllowedHTTPValidationAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("isAllowedHTTPValidationAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicOptions(t *testing.T) {
+	options := jsonb.FromMap(map[string]interface{}{"url": "https://example.com", "headers": map[string]interface{}{"Authorization": "Bearer abc"}})
+	publicOptions, ok := PublicOptions("http", options).AsMap()
+	if !ok {
+		t.Fatal("PublicOptions didn't return an object")
+	}
+	if _, exists := publicOptions["headers"]; exists {
+		t.Error("PublicOptions returned the headers of the http validation")
+	}
+	if publicOptions["url"] != "https://example.com" {
+		t.Errorf("PublicOptions url = %v, want https://example.com", publicOptions["url"])
+	}
+	regexOptions, _ := jsonb.FromString(`"^\\d+$"`)
+	if got := PublicOptions("regex", regexOptions); got.ToString() != regexOptions.ToString() {
+		t.Errorf("PublicOptions changed the options of the regex validation to %s", got.ToString())
+	}
+}
@@ -0,0 +1,15 @@
+package evaluator
+
+import "testing"
+
+func TestIBANEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &IBANEvaluator{} }, []evaluateTest{
+		{name: "electronic format", cell: "DE89370400440532013000", wantPass: true, wantValue: "DE89370400440532013000"},
+		{name: "print format", cell: "gb82 west 1234 5698 7654 32", wantPass: true, wantValue: "GB82WEST12345698765432"},
+		{name: "check digits", cell: "DE88370400440532013000", wantPass: false, wantValue: "DE88370400440532013000"},
+		{name: "length of the country", cell: "DE8937040044053201300", wantPass: false, wantValue: "DE8937040044053201300"},
+		{name: "unknown country", cell: "XX89370400440532013000", wantPass: false, wantValue: "XX89370400440532013000"},
+		{name: "punctuation", cell: "DE89-3704-0044-0532-0130-00", wantPass: false, wantValue: "DE89-3704-0044-0532-0130-00"},
+		{name: "blank", cell: "", wantPass: false, wantValue: ""},
+	})
+}
@@ -0,0 +1,30 @@
+package evaluator
+
+import (
+	"errors"
+)
+
+// IntegerEvaluator parses the cell as a whole number, rejecting fractions (i.e. 10.5), and normalizes it to the
+// canonical form (i.e. 1.000,0 with the de-DE locale is normalized to 1000). The locale and separator options of the
+// number data type are supported.
+// Example options: {"locale": "de-DE"} or {"decimal_separator": ",", "group_separator": " "}
+type IntegerEvaluator struct {
+	NumberEvaluator
+}
+
+func (e *IntegerEvaluator) Initialize(options interface{}) error {
+	if optionsMap, ok := options.(map[string]interface{}); ok {
+		if _, exists := optionsMap["max_decimal_places"]; exists {
+			return errors.New("max_decimal_places is not supported on integers")
+		}
+	}
+	if err := e.NumberEvaluator.Initialize(options); err != nil {
+		return err
+	}
+	e.IntegerOnly = true
+	return nil
+}
+
+func (e IntegerEvaluator) AllowedDataTypes() []string {
+	return []string{"integer"}
+}
@@ -0,0 +1,59 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// IPEvaluator validates IPv4 and IPv6 addresses, normalizing them to their canonical form (i.e. IPv6 addresses are
+// lowercased and compressed). If a version is provided, only addresses of that version are allowed.
+// Example options: {"version": 6}
+type IPEvaluator struct {
+	Version int
+}
+
+func (e *IPEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	if v, exists := optionsMap["version"]; exists && v != nil {
+		version, _ := v.(float64)
+		if version != 4 && version != 6 {
+			return errors.New("version must be 4 or 6")
+		}
+		e.Version = int(version)
+	}
+	return nil
+}
+
+func (e IPEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(cell))
+	if err != nil {
+		return false, cell, nil
+	}
+	if (e.Version == 4 && !addr.Is4()) || (e.Version == 6 && !addr.Is6()) {
+		return false, cell, nil
+	}
+	return true, addr.String(), nil
+}
+
+func (e IPEvaluator) DefaultMessage() string {
+	if e.Version != 0 {
+		return fmt.Sprintf("The cell must be a valid IPv%d address", e.Version)
+	}
+	return "The cell must be a valid IP address"
+}
+
+func (e IPEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
@@ -0,0 +1,19 @@
+package evaluator
+
+import "testing"
+
+func TestIPEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &IPEvaluator{} }, []evaluateTest{
+		{name: "IPv4", cell: " 192.168.0.1 ", wantPass: true, wantValue: "192.168.0.1"},
+		{name: "IPv6 compressed and lowercased", cell: "2001:0DB8:0000:0000:0000:0000:0000:0001", wantPass: true, wantValue: "2001:db8::1"},
+		{name: "out of range", cell: "256.1.1.1", wantPass: false, wantValue: "256.1.1.1"},
+		{name: "CIDR", cell: "10.0.0.0/8", wantPass: false, wantValue: "10.0.0.0/8"},
+		{name: "IPv4 only", options: map[string]interface{}{"version": float64(4)}, cell: "::1", wantPass: false, wantValue: "::1"},
+		{name: "IPv6 only", options: map[string]interface{}{"version": float64(6)}, cell: "::1", wantPass: true, wantValue: "::1"},
+		{name: "blank", cell: " ", wantPass: false, wantValue: ""},
+	})
+	runInitializeTests(t, func() Evaluator { return &IPEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil},
+		{name: "version 5", options: map[string]interface{}{"version": float64(5)}, wantErr: true},
+	})
+}
@@ -0,0 +1,72 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// JSONEvaluator checks the cell is a valid JSON value and normalizes it to compact JSON. The type option restricts the
+// value to an object or an array.
+// Example options: {"type": "object"}
+type JSONEvaluator struct {
+	Type string
+}
+
+const (
+	jsonTypeObject = "object"
+	jsonTypeArray  = "array"
+)
+
+func (e *JSONEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	if v, exists := optionsMap["type"]; exists && v != nil {
+		e.Type, _ = v.(string)
+		if e.Type != jsonTypeObject && e.Type != jsonTypeArray {
+			return fmt.Errorf("type must be %s or %s", jsonTypeObject, jsonTypeArray)
+		}
+	}
+	return nil
+}
+
+func (e JSONEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return true, "", nil
+	}
+	trimmed := strings.TrimSpace(cell)
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(trimmed)); err != nil {
+		return false, cell, nil
+	}
+	switch e.Type {
+	case jsonTypeObject:
+		if trimmed[0] != '{' {
+			return false, cell, nil
+		}
+	case jsonTypeArray:
+		if trimmed[0] != '[' {
+			return false, cell, nil
+		}
+	}
+	return true, compacted.String(), nil
+}
+
+func (e JSONEvaluator) DefaultMessage() string {
+	if len(e.Type) != 0 {
+		return fmt.Sprintf("The cell must be a JSON %s", e.Type)
+	}
+	return "The cell must be valid JSON"
+}
+
+func (e JSONEvaluator) AllowedDataTypes() []string {
+	return []string{"json"}
+}
@@ -0,0 +1,38 @@
+package evaluator
+
+import (
+	"golang.org/x/text/language"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// LanguageCodeEvaluator validates ISO 639 language codes. Two-letter (ISO 639-1) and three-letter (ISO 639-2/3) codes
+// are accepted, and normalized to the lowercase two-letter code if one exists (i.e. "ENG" is normalized to "en").
+type LanguageCodeEvaluator struct{}
+
+func (e *LanguageCodeEvaluator) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (e LanguageCodeEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	trimmed := strings.ToLower(strings.TrimSpace(cell))
+	if len(trimmed) != 2 && len(trimmed) != 3 {
+		return false, cell, nil
+	}
+	base, err := language.ParseBase(trimmed)
+	if err != nil || base.String() == "und" {
+		return false, cell, nil
+	}
+	return true, base.String(), nil
+}
+
+func (e LanguageCodeEvaluator) DefaultMessage() string {
+	return "The cell must be a valid ISO 639 language code (i.e. en)"
+}
+
+func (e LanguageCodeEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
@@ -0,0 +1,14 @@
+package evaluator
+
+import "testing"
+
+func TestLanguageCodeEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &LanguageCodeEvaluator{} }, []evaluateTest{
+		{name: "two letters", cell: "EN", wantPass: true, wantValue: "en"},
+		{name: "three letters normalized to two", cell: "deu", wantPass: true, wantValue: "de"},
+		{name: "three letters without a two letter code", cell: "haw", wantPass: true, wantValue: "haw"},
+		{name: "unknown", cell: "xx", wantPass: false, wantValue: "xx"},
+		{name: "tag with a region", cell: "en-US", wantPass: false, wantValue: "en-US"},
+		{name: "blank", cell: " ", wantPass: false, wantValue: ""},
+	})
+}
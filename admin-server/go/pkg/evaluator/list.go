@@ -3,34 +3,90 @@ package evaluator
 import (
 	"errors"
 	"fmt"
+	"github.com/samber/lo"
+	"sort"
 	"strings"
 	"tableflow/go/pkg/util"
 )
 
+// ListEvaluator checks the cell against a list of options, ignoring case, and replaces the cell with the option. Each
+// option can have aliases which are replaced with the option (i.e. "CA" with "California"). If fuzzy matching is
+// enabled, cells similar to an option or alias by at least the threshold are replaced as well, and if suggestions is
+// set, up to that number of the most similar options are returned with the error.
+// Example options: ["Active", "Inactive"]
+// or {"values": ["Oregon", {"value": "California", "aliases": ["CA", "Calif."]}], "fuzzy": true, "threshold": 0.8,
+// "suggestions": 3}
 type ListEvaluator struct {
-	Options []string
-	Message string
+	Options        []string
+	Aliases        map[string]string // The canonical option by lowercase alias
+	Fuzzy          bool
+	Threshold      float32
+	MaxSuggestions int
+	Message        string
 }
 
+const (
+	listDefaultFuzzyThreshold = 0.8
+	listMaxSuggestions        = 10
+	// listSuggestionMinSimilarity excludes options that have little in common with the cell from the suggestions
+	listSuggestionMinSimilarity = 0.4
+)
+
 func (e *ListEvaluator) Initialize(options interface{}) error {
 	if options == nil {
 		return errors.New("not provided")
 	}
 
-	var opts []string
+	var values interface{}
 	switch v := options.(type) {
+	case []string, []interface{}:
+		values = v
+	case map[string]interface{}:
+		values = v["values"]
+		if err := e.initializeMatchOptions(v); err != nil {
+			return err
+		}
+	default:
+		return errors.New("must be an array of strings or an object with the values")
+	}
+
+	var opts []string
+	aliases := make(map[string][]string)
+	switch v := values.(type) {
 	case []string:
 		opts = v
 	case []interface{}:
 		for _, opt := range v {
-			str, ok := opt.(string)
-			if !ok {
-				return errors.New("all elements in the array must be strings")
+			switch o := opt.(type) {
+			case string:
+				opts = append(opts, o)
+			case map[string]interface{}:
+				str, ok := o["value"].(string)
+				if !ok {
+					return errors.New("all objects in the array must have a value string")
+				}
+				opts = append(opts, str)
+				if rawAliases, exists := o["aliases"]; exists && rawAliases != nil {
+					optAliases, ok := rawAliases.([]interface{})
+					if !ok {
+						return fmt.Errorf("the aliases of %s must be an array of strings", str)
+					}
+					for _, a := range optAliases {
+						alias, ok := a.(string)
+						if !ok {
+							return fmt.Errorf("the aliases of %s must be an array of strings", str)
+						}
+						aliases[str] = append(aliases[str], alias)
+					}
+				}
+			default:
+				return errors.New("all elements in the array must be strings or objects with a value")
 			}
-			opts = append(opts, str)
 		}
+	case nil:
+		return errors.New("no list values provided")
 	default:
-		return errors.New("must be an array of strings")
+		return errors.New("values must be an array")
 	}
 
 	if len(opts) == 0 {
@@ -59,11 +115,54 @@ func (e *ListEvaluator) Initialize(options interface{}) error {
 		return errors.New("no non-blank list values provided")
 	}
 
+	// Aliases can't be the same as another option or alias, as the cell would match more than one option
+	e.Aliases = make(map[string]string)
+	for option, optionAliases := range aliases {
+		option = strings.TrimSpace(option)
+		for _, alias := range optionAliases {
+			lower := strings.ToLower(strings.TrimSpace(alias))
+			if util.IsBlankUnicode(lower) || lower == strings.ToLower(option) {
+				continue
+			}
+			if keys[lower] {
+				return fmt.Errorf("the alias %s of %s cannot be the same as another value or alias (case-insensitive)", alias, option)
+			}
+			keys[lower] = true
+			e.Aliases[lower] = option
+		}
+	}
+
 	e.Options = parsedOptions
 	e.Message = parseDefaultMessage(parsedOptions)
 	return nil
 }
 
+func (e *ListEvaluator) initializeMatchOptions(optionsMap map[string]interface{}) error {
+	if v, exists := optionsMap["fuzzy"]; exists && v != nil {
+		fuzzy, ok := v.(bool)
+		if !ok {
+			return errors.New("fuzzy must be a boolean")
+		}
+		e.Fuzzy = fuzzy
+	}
+	e.Threshold = listDefaultFuzzyThreshold
+	if v, exists := optionsMap["threshold"]; exists && v != nil {
+		threshold, ok := v.(float64)
+		if !ok || threshold <= 0 || threshold > 1 {
+			return errors.New("threshold must be a number greater than 0 and at most 1")
+		}
+		e.Threshold = float32(threshold)
+	}
+	if v, exists := optionsMap["suggestions"]; exists && v != nil {
+		suggestions, ok := v.(float64)
+		if !ok || suggestions < 0 || suggestions > listMaxSuggestions || suggestions != float64(int(suggestions)) {
+			return fmt.Errorf("suggestions must be a whole number from 0 to %d", listMaxSuggestions)
+		}
+		e.MaxSuggestions = int(suggestions)
+	}
+	return nil
+}
+
 func (e ListEvaluator) Evaluate(cell string) (bool, string, error) {
 	if len(e.Options) == 0 {
 		return false, cell, errors.New("uninitialized list evaluator")
@@ -75,9 +174,61 @@ func (e ListEvaluator) Evaluate(cell string) (bool, string, error) {
 			return true, option, nil
 		}
 	}
+	if option, ok := e.Aliases[lower]; ok {
+		return true, option, nil
+	}
+	if e.Fuzzy && !util.IsBlankUnicode(lower) {
+		similarities := e.similarities(lower)
+		if len(similarities) != 0 && similarities[0].similarity >= e.Threshold {
+			// Cells equally similar to more than one option are ambiguous
+			if len(similarities) == 1 || similarities[1].similarity < similarities[0].similarity {
+				return true, similarities[0].option, nil
+			}
+		}
+	}
 	return false, cell, nil
 }
 
+// Suggestions returns the options most similar to the cell, for the cells that don't pass
+func (e ListEvaluator) Suggestions(cell string) []string {
+	lower := strings.ToLower(strings.TrimSpace(cell))
+	if e.MaxSuggestions == 0 || util.IsBlankUnicode(lower) {
+		return nil
+	}
+	suggestions := make([]string, 0, e.MaxSuggestions)
+	for _, s := range e.similarities(lower) {
+		if s.similarity < listSuggestionMinSimilarity || len(suggestions) == e.MaxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, s.option)
+	}
+	return suggestions
+}
+
+type listOptionSimilarity struct {
+	option     string
+	similarity float32
+}
+
+// similarities returns the highest similarity of the cell to each option or its aliases, most similar first
+func (e ListEvaluator) similarities(lower string) []listOptionSimilarity {
+	byOption := make(map[string]float32, len(e.Options))
+	for _, option := range e.Options {
+		byOption[option] = util.StringSimilarity(lower, strings.ToLower(option))
+	}
+	for alias, option := range e.Aliases {
+		byOption[option] = lo.Max([]float32{byOption[option], util.StringSimilarity(lower, alias)})
+	}
+	similarities := make([]listOptionSimilarity, 0, len(e.Options))
+	for _, option := range e.Options {
+		similarities = append(similarities, listOptionSimilarity{option: option, similarity: byOption[option]})
+	}
+	sort.SliceStable(similarities, func(i, j int) bool {
+		return similarities[i].similarity > similarities[j].similarity
+	})
+	return similarities
+}
+
 func (e ListEvaluator) DefaultMessage() string {
 	return e.Message
 }
@@ -99,5 +250,5 @@ func parseDefaultMessage(options []string) string {
 	if len(quotedOptions) == 2 {
 		return fmt.Sprintf("The cell must be %s or %s", quotedOptions[0], quotedOptions[1])
 	}
-	return fmt.Sprintf("The cell must be '%s'", quotedOptions[0])
+	return fmt.Sprintf("The cell must be %s", quotedOptions[0])
 }
@@ -0,0 +1,132 @@
+package evaluator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListEvaluator(t *testing.T) {
+	states := map[string]interface{}{
+		"values": []interface{}{
+			"Oregon",
+			map[string]interface{}{"value": "California", "aliases": []interface{}{"CA", "Calif."}},
+		},
+	}
+	fuzzyStates := map[string]interface{}{
+		"values": []interface{}{
+			"Oregon",
+			map[string]interface{}{"value": "California", "aliases": []interface{}{"CA", "Calif."}},
+		},
+		"fuzzy": true,
+	}
+	runEvaluateTests(t, func() Evaluator { return &ListEvaluator{} }, []evaluateTest{
+		{name: "option", options: []interface{}{"Active", "Inactive"}, cell: "Active", wantPass: true, wantValue: "Active"},
+		{name: "option case-insensitive", options: []interface{}{"Active", "Inactive"}, cell: " inACTIVE ", wantPass: true, wantValue: "Inactive"},
+		{name: "not an option", options: []interface{}{"Active", "Inactive"}, cell: "Pending", wantPass: false, wantValue: "Pending"},
+		{name: "alias replaced with the option", options: states, cell: "ca", wantPass: true, wantValue: "California"},
+		{name: "other alias", options: states, cell: "CALIF.", wantPass: true, wantValue: "California"},
+		{name: "misspelling without fuzzy", options: states, cell: "Califronia", wantPass: false, wantValue: "Califronia"},
+		{name: "misspelling with fuzzy", options: fuzzyStates, cell: "Califronia", wantPass: true, wantValue: "California"},
+		{name: "similar to an alias", options: fuzzyStates, cell: "Calif", wantPass: true, wantValue: "California"},
+		{name: "below the threshold", options: fuzzyStates, cell: "Oreg", wantPass: false, wantValue: "Oreg"},
+		{
+			name:      "custom threshold",
+			options:   map[string]interface{}{"values": []interface{}{"Oregon"}, "fuzzy": true, "threshold": 0.6},
+			cell:      "Oreg",
+			wantPass:  true,
+			wantValue: "Oregon",
+		},
+		{
+			name:      "ambiguous",
+			options:   map[string]interface{}{"values": []interface{}{"cat", "bat"}, "fuzzy": true, "threshold": 0.6},
+			cell:      "hat",
+			wantPass:  false,
+			wantValue: "hat",
+		},
+		{name: "blank with fuzzy", options: fuzzyStates, cell: " ", wantPass: false, wantValue: " "},
+	})
+	runInitializeTests(t, func() Evaluator { return &ListEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil, wantErr: true},
+		{name: "empty", options: []interface{}{}, wantErr: true},
+		{name: "only blank values", options: []interface{}{" ", ""}, wantErr: true},
+		{name: "duplicate values", options: []interface{}{"Active", "active"}, wantErr: true},
+		{name: "object without values", options: map[string]interface{}{"fuzzy": true}, wantErr: true},
+		{
+			name: "alias of another value",
+			options: []interface{}{
+				"Oregon",
+				map[string]interface{}{"value": "California", "aliases": []interface{}{"oregon"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate alias",
+			options: []interface{}{
+				map[string]interface{}{"value": "Oregon", "aliases": []interface{}{"OR"}},
+				map[string]interface{}{"value": "California", "aliases": []interface{}{"or"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "aliases not an array",
+			options: []interface{}{map[string]interface{}{"value": "California", "aliases": "CA"}},
+			wantErr: true,
+		},
+		{name: "threshold above 1", options: map[string]interface{}{"values": []interface{}{"a"}, "threshold": 1.5}, wantErr: true},
+		{name: "fractional suggestions", options: map[string]interface{}{"values": []interface{}{"a"}, "suggestions": 1.5}, wantErr: true},
+		{name: "too many suggestions", options: map[string]interface{}{"values": []interface{}{"a"}, "suggestions": float64(11)}, wantErr: true},
+	})
+}
+
+func TestListEvaluatorSuggestions(t *testing.T) {
+	options := map[string]interface{}{
+		"values": []interface{}{
+			"Oregon",
+			"Nevada",
+			map[string]interface{}{"value": "California", "aliases": []interface{}{"Calif."}},
+		},
+		"suggestions": float64(2),
+	}
+	tests := []struct {
+		name string
+		cell string
+		want []string
+	}{
+		{name: "most similar first", cell: "Califor", want: []string{"California"}},
+		{name: "similar to an alias", cell: "Calif", want: []string{"California"}},
+		{name: "limited to the number of suggestions", cell: "Nevgon", want: []string{"Oregon", "Nevada"}},
+		{name: "nothing similar", cell: "Texas", want: []string{}},
+		{name: "blank", cell: " ", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ListEvaluator{}
+			if err := e.Initialize(options); err != nil {
+				t.Fatalf("Initialize() error = %v", err)
+			}
+			if got := e.Suggestions(tt.cell); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggestions(%q) = %v, want %v", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEvaluatorDefaultMessage(t *testing.T) {
+	tests := []struct {
+		options []interface{}
+		want    string
+	}{
+		{options: []interface{}{"Active"}, want: "The cell must be 'Active'"},
+		{options: []interface{}{"Active", "Inactive"}, want: "The cell must be 'Active' or 'Inactive'"},
+		{options: []interface{}{"Red", "Green", "Blue"}, want: "The cell must be 'Red', 'Green', or 'Blue'"},
+	}
+	for _, tt := range tests {
+		e := &ListEvaluator{}
+		if err := e.Initialize(tt.options); err != nil {
+			t.Fatalf("Initialize(%v) error = %v", tt.options, err)
+		}
+		if got := e.DefaultMessage(); got != tt.want {
+			t.Errorf("DefaultMessage() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/samber/lo"
+	"strings"
+	"sync"
+	"tableflow/go/pkg/util"
+	"time"
+)
+
+// LookupEvaluator checks the cell against the values of a column in a workspace lookup table. Values are matched
+// case-insensitively and ignoring surrounding whitespace, or exactly. If replace_column is set, the cell is replaced
+// with the value of that column from the matching row.
+// Example options:
+// {"table_id": "3b0a3b1e-5a57-4d5f-b3c5-cd1b0a0dc0a3", "column": "sku", "match": "exact", "replace_column": "name"}
+type LookupEvaluator struct {
+	TableID       string
+	Column        string
+	ReplaceColumn string
+	Exact         bool
+}
+
+const (
+	LookupMatchCaseInsensitive = "case_insensitive"
+	LookupMatchExact           = "exact"
+)
+
+// LookupTable is the data of a lookup table. The evaluator package doesn't access the database, the tables are
+// retrieved with the LookupTableProvider.
+type LookupTable struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Columns     []string
+	Rows        [][]string
+}
+
+// LookupTableProvider retrieves a lookup table by ID
+type LookupTableProvider func(id string) (*LookupTable, error)
+
+// lookupTableCacheTTL limits how long tables are cached, as other instances may replace a table
+const lookupTableCacheTTL = 5 * time.Minute
+
+type lookupTableCacheEntry struct {
+	table    *LookupTable
+	err      error // Errors are cached as well, so a missing table isn't retrieved for every cell
+	loadedAt time.Time
+	indexes  map[string]map[string]int // The row index of the first row containing each value, by column and match
+}
+
+var lookupTables = struct {
+	sync.Mutex
+	provider LookupTableProvider
+	cache    map[string]*lookupTableCacheEntry
+}{
+	cache: make(map[string]*lookupTableCacheEntry),
+}
+
+// SetLookupTableProvider sets the function used to retrieve lookup tables
+func SetLookupTableProvider(provider LookupTableProvider) {
+	lookupTables.Lock()
+	defer lookupTables.Unlock()
+	lookupTables.provider = provider
+	lookupTables.cache = make(map[string]*lookupTableCacheEntry)
+}
+
+// InvalidateLookupTable removes a lookup table from the cache once it is replaced or deleted
+func InvalidateLookupTable(id string) {
+	lookupTables.Lock()
+	defer lookupTables.Unlock()
+	delete(lookupTables.cache, id)
+}
+
+// lookupTableIndex returns the table and the index of the column values, loading the table if it isn't cached
+func lookupTableIndex(id, column string, exact bool) (*LookupTable, map[string]int, error) {
+	lookupTables.Lock()
+	defer lookupTables.Unlock()
+
+	entry, ok := lookupTables.cache[id]
+	if !ok || time.Since(entry.loadedAt) > lookupTableCacheTTL {
+		if lookupTables.provider == nil {
+			return nil, nil, errors.New("no lookup table provider is set")
+		}
+		table, err := lookupTables.provider(id)
+		entry = &lookupTableCacheEntry{table: table, err: err, loadedAt: time.Now(), indexes: make(map[string]map[string]int)}
+		lookupTables.cache[id] = entry
+	}
+	if entry.err != nil {
+		return nil, nil, entry.err
+	}
+
+	indexKey := fmt.Sprintf("%s:%v", column, exact)
+	if index, ok := entry.indexes[indexKey]; ok {
+		return entry.table, index, nil
+	}
+	columnIndex := lo.IndexOf(entry.table.Columns, column)
+	if columnIndex == -1 {
+		return nil, nil, fmt.Errorf("the column %s does not exist on the lookup table %s", column, entry.table.Name)
+	}
+	index := make(map[string]int, len(entry.table.Rows))
+	for i, row := range entry.table.Rows {
+		if columnIndex >= len(row) {
+			continue
+		}
+		value := normalizeLookupValue(row[columnIndex], exact)
+		if _, exists := index[value]; !exists {
+			index[value] = i
+		}
+	}
+	entry.indexes[indexKey] = index
+	return entry.table, index, nil
+}
+
+func normalizeLookupValue(value string, exact bool) string {
+	if exact {
+		return value
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func (e *LookupEvaluator) Initialize(options interface{}) error {
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("must be an object with the table_id and column")
+	}
+	tableID, _ := optionsMap["table_id"].(string)
+	if _, err := uuid.FromString(tableID); err != nil {
+		return errors.New("table_id must be the ID of a lookup table")
+	}
+	e.TableID = tableID
+	if e.Column, _ = optionsMap["column"].(string); len(e.Column) == 0 {
+		return errors.New("column is required")
+	}
+	if v, exists := optionsMap["replace_column"]; exists && v != nil {
+		if e.ReplaceColumn, ok = v.(string); !ok {
+			return errors.New("replace_column must be a string")
+		}
+	}
+	if v, exists := optionsMap["match"]; exists && v != nil {
+		match, _ := v.(string)
+		switch match {
+		case LookupMatchCaseInsensitive:
+		case LookupMatchExact:
+			e.Exact = true
+		default:
+			return fmt.Errorf("match must be %s or %s", LookupMatchCaseInsensitive, LookupMatchExact)
+		}
+	}
+	// The table is loaded when the first cell is evaluated, so templates can be retrieved if a table is deleted
+	return nil
+}
+
+func (e LookupEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, cell, nil
+	}
+	table, index, err := lookupTableIndex(e.TableID, e.Column, e.Exact)
+	if err != nil {
+		return false, cell, err
+	}
+	rowIndex, ok := index[normalizeLookupValue(cell, e.Exact)]
+	if !ok {
+		return false, cell, nil
+	}
+	if len(e.ReplaceColumn) == 0 {
+		return true, cell, nil
+	}
+	replaceIndex := lo.IndexOf(table.Columns, e.ReplaceColumn)
+	if replaceIndex == -1 {
+		return false, cell, fmt.Errorf("the column %s does not exist on the lookup table %s", e.ReplaceColumn, table.Name)
+	}
+	row := table.Rows[rowIndex]
+	if replaceIndex >= len(row) {
+		return true, "", nil
+	}
+	return true, row[replaceIndex], nil
+}
+
+func (e LookupEvaluator) DefaultMessage() string {
+	return fmt.Sprintf("The cell must be a value of the column %s in the lookup table", e.Column)
+}
+
+func (e LookupEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
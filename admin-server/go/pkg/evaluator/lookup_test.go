@@ -0,0 +1,136 @@
+package evaluator
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	testLookupTableID        = "3b0a3b1e-5a57-4d5f-b3c5-cd1b0a0dc0a3"
+	testMissingLookupTableID = "0e5b7c55-2a55-4f0b-9a4c-6b8a1c0f7f2e"
+)
+
+func setTestLookupTableProvider(t *testing.T) *int {
+	t.Helper()
+	loads := 0
+	SetLookupTableProvider(func(id string) (*LookupTable, error) {
+		loads++
+		if id != testLookupTableID {
+			return nil, errors.New("lookup table not found")
+		}
+		return &LookupTable{
+			ID:      id,
+			Name:    "Products",
+			Columns: []string{"sku", "name", "category"},
+			Rows: [][]string{
+				{"AB-100", "Widget", "tools"},
+				{"ab-100", "Duplicate widget", "tools"},
+				{" CD-200 ", "Gadget"},
+			},
+		}, nil
+	})
+	t.Cleanup(func() { SetLookupTableProvider(nil) })
+	return &loads
+}
+
+func TestLookupEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   map[string]interface{}
+		cell      string
+		wantPass  bool
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "case insensitive", options: map[string]interface{}{"column": "sku"}, cell: " ab-100", wantPass: true, wantValue: " ab-100"},
+		{name: "not in the table", options: map[string]interface{}{"column": "sku"}, cell: "EF-300", wantPass: false, wantValue: "EF-300"},
+		{name: "blank", options: map[string]interface{}{"column": "sku"}, cell: " ", wantPass: false, wantValue: " "},
+		{name: "exact", options: map[string]interface{}{"column": "sku", "match": "exact"}, cell: "ab-100", wantPass: true, wantValue: "ab-100"},
+		{name: "exact with a different case", options: map[string]interface{}{"column": "sku", "match": "exact"}, cell: "Ab-100", wantPass: false, wantValue: "Ab-100"},
+		{name: "exact with whitespace", options: map[string]interface{}{"column": "sku", "match": "exact"}, cell: "CD-200", wantPass: false, wantValue: "CD-200"},
+		{name: "replaced with the first matching row", options: map[string]interface{}{"column": "sku", "replace_column": "name"}, cell: "AB-100", wantPass: true, wantValue: "Widget"},
+		{name: "exact replaced with the matching row", options: map[string]interface{}{"column": "sku", "match": "exact", "replace_column": "name"}, cell: "ab-100", wantPass: true, wantValue: "Duplicate widget"},
+		{name: "replaced with a missing cell", options: map[string]interface{}{"column": "sku", "replace_column": "category"}, cell: "cd-200", wantPass: true, wantValue: ""},
+		{name: "missing column", options: map[string]interface{}{"column": "price"}, cell: "AB-100", wantPass: false, wantValue: "AB-100", wantErr: true},
+		{name: "missing replace column", options: map[string]interface{}{"column": "sku", "replace_column": "price"}, cell: "AB-100", wantPass: false, wantValue: "AB-100", wantErr: true},
+	}
+	setTestLookupTableProvider(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options["table_id"] = testLookupTableID
+			e := &LookupEvaluator{}
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate(%q) error = %v, wantErr %v", tt.cell, err, tt.wantErr)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestLookupEvaluatorCache(t *testing.T) {
+	loads := setTestLookupTableProvider(t)
+	evaluate := func(tableID, cell string) error {
+		e := &LookupEvaluator{}
+		if err := e.Initialize(map[string]interface{}{"table_id": tableID, "column": "sku"}); err != nil {
+			t.Fatalf("Initialize returned error: %v", err)
+		}
+		_, _, err := e.Evaluate(cell)
+		return err
+	}
+
+	for _, cell := range []string{"AB-100", "CD-200", "EF-300"} {
+		if err := evaluate(testLookupTableID, cell); err != nil {
+			t.Fatalf("Evaluate(%q) returned error: %v", cell, err)
+		}
+	}
+	if *loads != 1 {
+		t.Errorf("The table was loaded %d times, want once", *loads)
+	}
+	InvalidateLookupTable(testLookupTableID)
+	if err := evaluate(testLookupTableID, "AB-100"); err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+	if *loads != 2 {
+		t.Errorf("The table was loaded %d times after it was invalidated, want twice", *loads)
+	}
+
+	// Missing tables are cached as well
+	for i := 0; i < 2; i++ {
+		if err := evaluate(testMissingLookupTableID, "AB-100"); err == nil {
+			t.Error("Evaluate on a missing table did not return an error")
+		}
+	}
+	if *loads != 3 {
+		t.Errorf("The tables were loaded %d times, want the missing table loaded once", *loads)
+	}
+}
+
+func TestLookupEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+		wantErr bool
+	}{
+		{name: "valid", options: map[string]interface{}{"table_id": testLookupTableID, "column": "sku", "match": "exact", "replace_column": "name"}},
+		{name: "not an object", options: "sku", wantErr: true},
+		{name: "invalid table ID", options: map[string]interface{}{"table_id": "products", "column": "sku"}, wantErr: true},
+		{name: "missing column", options: map[string]interface{}{"table_id": testLookupTableID}, wantErr: true},
+		{name: "replace column not a string", options: map[string]interface{}{"table_id": testLookupTableID, "column": "sku", "replace_column": float64(1)}, wantErr: true},
+		{name: "invalid match", options: map[string]interface{}{"table_id": testLookupTableID, "column": "sku", "match": "fuzzy"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &LookupEvaluator{}
+			err := e.Initialize(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Initialize(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+			}
+		})
+	}
+}
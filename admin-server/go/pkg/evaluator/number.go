@@ -1,20 +1,124 @@
 package evaluator
 
-import "tableflow/go/pkg/util"
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"math/big"
+	"strings"
+	"tableflow/go/pkg/util"
+)
 
-type NumberEvaluator struct{}
+// NumberEvaluator parses the cell as a number and normalizes it to the canonical form (i.e. 1234.5). All options are
+// optional, without options numbers are parsed with a "." decimal separator and "," group separator.
+// Example options: {"locale": "de-DE", "integer_only": false, "max_decimal_places": 2}
+// or {"decimal_separator": ",", "group_separator": " "}
+type NumberEvaluator struct {
+	Format           util.NumberFormat
+	IntegerOnly      bool
+	MaxDecimalPlaces *int
+}
+
+func (e *NumberEvaluator) Initialize(options interface{}) error {
+	e.Format = util.DefaultNumberFormat
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+
+	if v, exists := optionsMap["locale"]; exists && v != nil {
+		locale, ok := v.(string)
+		if !ok {
+			return errors.New("locale must be a string")
+		}
+		format, err := util.NumberFormatForLocale(locale)
+		if err != nil {
+			return err
+		}
+		e.Format = format
+	}
+	// Separators override the locale
+	if v, exists := optionsMap["decimal_separator"]; exists && v != nil {
+		if e.Format.DecimalSeparator, ok = v.(string); !ok {
+			return errors.New("decimal_separator must be a string")
+		}
+	}
+	if v, exists := optionsMap["group_separator"]; exists && v != nil {
+		if e.Format.GroupSeparator, ok = v.(string); !ok {
+			return errors.New("group_separator must be a string")
+		}
+	}
+	if err := e.Format.Validate(); err != nil {
+		return err
+	}
 
-func (e *NumberEvaluator) Initialize(_ interface{}) error {
+	if v, exists := optionsMap["integer_only"]; exists && v != nil {
+		if e.IntegerOnly, ok = v.(bool); !ok {
+			return errors.New("integer_only must be a boolean")
+		}
+	}
+	if v, exists := optionsMap["max_decimal_places"]; exists && v != nil {
+		f, ok := v.(float64)
+		if !ok || f < 0 || f != float64(int(f)) {
+			return errors.New("max_decimal_places must be a positive integer")
+		}
+		maxDecimalPlaces := int(f)
+		e.MaxDecimalPlaces = &maxDecimalPlaces
+	}
 	return nil
 }
 
 func (e NumberEvaluator) Evaluate(cell string) (bool, string, error) {
-	_, str, err := util.StringToNumberOrNil(cell)
-	return err == nil, str, nil
+	num, str, err := util.StringToNumberWithFormat(cell, e.NumberFormat())
+	if err != nil {
+		return false, cell, nil
+	}
+	if num == nil || (!e.IntegerOnly && e.MaxDecimalPlaces == nil) {
+		return true, str, nil
+	}
+	f, ok := util.StringToBigFloat(str)
+	if !ok {
+		return false, cell, nil
+	}
+	if e.IntegerOnly && !f.IsInt() {
+		return false, cell, nil
+	}
+	if e.IntegerOnly {
+		// Normalize integers written with a fraction or exponent, i.e. 10.0 or 1e3
+		i, _ := f.Int(new(big.Int))
+		return true, i.String(), nil
+	}
+	if e.MaxDecimalPlaces != nil && util.DecimalPlaces(f.Float) > *e.MaxDecimalPlaces {
+		return false, cell, nil
+	}
+	return true, str, nil
+}
+
+// NumberFormat returns the format the evaluator parses numbers with
+func (e NumberEvaluator) NumberFormat() util.NumberFormat {
+	if len(e.Format.DecimalSeparator) == 0 {
+		return util.DefaultNumberFormat
+	}
+	return e.Format
 }
 
 func (e NumberEvaluator) DefaultMessage() string {
-	return "The cell must be a number"
+	message := "The cell must be a number"
+	if e.IntegerOnly {
+		message = "The cell must be a whole number"
+	} else if e.MaxDecimalPlaces != nil {
+		message += fmt.Sprintf(" with at most %d decimal place%s", *e.MaxDecimalPlaces, lo.Ternary(*e.MaxDecimalPlaces == 1, "", "s"))
+	}
+	if e.Format != util.DefaultNumberFormat && len(e.Format.DecimalSeparator) != 0 {
+		message += fmt.Sprintf(" using '%s' as the decimal separator", e.Format.DecimalSeparator)
+		if len(strings.TrimSpace(e.Format.GroupSeparator)) != 0 {
+			message += fmt.Sprintf(" and '%s' as the group separator", e.Format.GroupSeparator)
+		}
+	}
+	return message
 }
 
 func (e NumberEvaluator) AllowedDataTypes() []string {
@@ -1,17 +1,67 @@
 package evaluator
 
 import (
+	"errors"
+	"fmt"
 	"github.com/samber/lo"
-	"regexp"
 	"strings"
+	"tableflow/go/pkg/phone"
 	"tableflow/go/pkg/util"
 )
 
-type PhoneEvaluator struct{}
+// PhoneEvaluator validates phone numbers using the embedded phone metadata and normalizes them to the output format.
+// Numbers without a calling code are parsed as numbers of the default region.
+// Example options: {"default_region": "GB", "allowed_regions": ["GB", "IE"], "format": "international"}
+type PhoneEvaluator struct {
+	DefaultRegion  string
+	AllowedRegions []string
+	Format         string
+}
+
+const phoneDefaultRegion = "US"
+
+func (e *PhoneEvaluator) Initialize(options interface{}) error {
+	e.DefaultRegion = phoneDefaultRegion
+	e.Format = phone.FormatE164
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+
+	if v, exists := optionsMap["default_region"]; exists && v != nil {
+		region, ok := v.(string)
+		if !ok || !phone.IsRegionSupported(region) {
+			return fmt.Errorf("default_region must be one of %s", strings.Join(phone.SupportedRegions(), ", "))
+		}
+		e.DefaultRegion = strings.ToUpper(region)
+	}
 
-var phoneRegex = regexp.MustCompile(`^(\+\d{1,2}\s?)?1?-?\.?\s?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}$`)
+	switch v := optionsMap["allowed_regions"].(type) {
+	case nil:
+	case []interface{}:
+		for _, r := range v {
+			region, ok := r.(string)
+			if !ok || !phone.IsRegionSupported(region) {
+				return fmt.Errorf("the allowed region %v is not supported", r)
+			}
+			e.AllowedRegions = append(e.AllowedRegions, strings.ToUpper(region))
+		}
+	default:
+		return errors.New("allowed_regions must be an array of region codes")
+	}
 
-func (e *PhoneEvaluator) Initialize(_ interface{}) error {
+	if v, exists := optionsMap["format"]; exists && v != nil {
+		format, _ := v.(string)
+		switch format {
+		case phone.FormatE164, phone.FormatNational, phone.FormatInternational:
+			e.Format = format
+		default:
+			return errors.New("format must be one of e164, national, international")
+		}
+	}
 	return nil
 }
 
@@ -19,12 +69,21 @@ func (e PhoneEvaluator) Evaluate(cell string) (bool, string, error) {
 	if util.IsBlankUnicode(cell) {
 		return false, "", nil
 	}
-	trimmed := strings.TrimSpace(cell)
-	isPhone := phoneRegex.MatchString(trimmed)
-	return isPhone, lo.Ternary(isPhone, trimmed, cell), nil
+	defaultRegion := lo.Ternary(len(e.DefaultRegion) != 0, e.DefaultRegion, phoneDefaultRegion)
+	number, err := phone.Parse(cell, defaultRegion)
+	if err != nil {
+		return false, cell, nil
+	}
+	if len(e.AllowedRegions) != 0 && !lo.Contains(e.AllowedRegions, number.Region.Region) {
+		return false, cell, nil
+	}
+	return true, number.Format(e.Format), nil
 }
 
 func (e PhoneEvaluator) DefaultMessage() string {
+	if len(e.AllowedRegions) != 0 {
+		return fmt.Sprintf("The cell must be a valid phone number from %s", strings.Join(e.AllowedRegions, ", "))
+	}
 	return "The cell must be a valid phone number"
 }
 
@@ -0,0 +1,60 @@
+package evaluator
+
+import "testing"
+
+func TestPhoneEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   interface{}
+		cell      string
+		wantPass  bool
+		wantValue string
+	}{
+		{name: "default region", cell: "(201) 555-0123", wantPass: true, wantValue: "+12015550123"},
+		{name: "international format", options: map[string]interface{}{"format": "international"}, cell: "201.555.0123", wantPass: true, wantValue: "+1 201-555-0123"},
+		{name: "national number of another region", cell: "020 7946 0018", wantPass: false, wantValue: "020 7946 0018"},
+		{name: "default region option", options: map[string]interface{}{"default_region": "gb", "format": "national"}, cell: "+44 20 7946 0018", wantPass: true, wantValue: "020 7946 0018"},
+		{name: "allowed region", options: map[string]interface{}{"allowed_regions": []interface{}{"GB"}}, cell: "+44 20 7946 0018", wantPass: true, wantValue: "+442079460018"},
+		{name: "not allowed region", options: map[string]interface{}{"allowed_regions": []interface{}{"GB"}}, cell: "(201) 555-0123", wantPass: false, wantValue: "(201) 555-0123"},
+		{name: "region without metadata", cell: "+372 5123 4567", wantPass: true, wantValue: "+37251234567"},
+		{name: "region without metadata not allowed", options: map[string]interface{}{"allowed_regions": []interface{}{"GB"}}, cell: "+372 5123 4567", wantPass: false, wantValue: "+372 5123 4567"},
+		{name: "blank", cell: " ", wantPass: false, wantValue: ""},
+		{name: "invalid", cell: "12345", wantPass: false, wantValue: "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &PhoneEvaluator{}
+			if err := e.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			passed, value, err := e.Evaluate(tt.cell)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.cell, err)
+			}
+			if passed != tt.wantPass || value != tt.wantValue {
+				t.Errorf("Evaluate(%q) = %v, %q, want %v, %q", tt.cell, passed, value, tt.wantPass, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestPhoneEvaluatorInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+	}{
+		{name: "unsupported default region", options: map[string]interface{}{"default_region": "XX"}},
+		{name: "unsupported allowed region", options: map[string]interface{}{"allowed_regions": []interface{}{"XX"}}},
+		{name: "allowed regions not an array", options: map[string]interface{}{"allowed_regions": "GB"}},
+		{name: "invalid format", options: map[string]interface{}{"format": "local"}},
+		{name: "not an object", options: "US"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &PhoneEvaluator{}
+			if err := e.Initialize(tt.options); err == nil {
+				t.Errorf("Initialize(%v) returned no error", tt.options)
+			}
+		})
+	}
+}
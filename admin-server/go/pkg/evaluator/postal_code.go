@@ -0,0 +1,82 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"regexp"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// PostalCodeEvaluator validates postal codes for a country: US ZIP codes (12345 or 12345-6789), Canadian postal codes
+// (A1A 1A1) or UK postcodes (SW1A 1AA). Canadian and UK postal codes are normalized to uppercase with a single space.
+// Example options: {"country": "CA"}
+type PostalCodeEvaluator struct {
+	Country string
+}
+
+var postalCodeCountries = []string{"US", "CA", "GB"}
+
+var (
+	usPostalCodeRegex = regexp.MustCompile(`^(\d{5})(?:[- ]?(\d{4}))?$`)
+	caPostalCodeRegex = regexp.MustCompile(`^([ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z])(\d[ABCEGHJ-NPRSTV-Z]\d)$`)
+	gbPostalCodeRegex = regexp.MustCompile(`^([A-Z]{1,2}\d[A-Z\d]?)(\d[A-Z]{2})$`)
+)
+
+func (e *PostalCodeEvaluator) Initialize(options interface{}) error {
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("must be an object with the country")
+	}
+	country, _ := optionsMap["country"].(string)
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "UK" {
+		country = "GB"
+	}
+	if !lo.Contains(postalCodeCountries, country) {
+		return fmt.Errorf("country must be one of %s", strings.Join(postalCodeCountries, ", "))
+	}
+	e.Country = country
+	return nil
+}
+
+func (e PostalCodeEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	trimmed := strings.TrimSpace(cell)
+	switch e.Country {
+	case "US":
+		matches := usPostalCodeRegex.FindStringSubmatch(trimmed)
+		if matches == nil {
+			return false, cell, nil
+		}
+		if len(matches[2]) != 0 {
+			return true, matches[1] + "-" + matches[2], nil
+		}
+		return true, matches[1], nil
+	case "CA", "GB":
+		regex := lo.Ternary(e.Country == "CA", caPostalCodeRegex, gbPostalCodeRegex)
+		matches := regex.FindStringSubmatch(strings.ToUpper(strings.Join(strings.Fields(trimmed), "")))
+		if matches == nil {
+			return false, cell, nil
+		}
+		return true, matches[1] + " " + matches[2], nil
+	}
+	return false, cell, fmt.Errorf("unsupported postal code country %s", e.Country)
+}
+
+func (e PostalCodeEvaluator) DefaultMessage() string {
+	switch e.Country {
+	case "CA":
+		return "The cell must be a valid Canadian postal code (i.e. A1A 1A1)"
+	case "GB":
+		return "The cell must be a valid UK postcode (i.e. SW1A 1AA)"
+	}
+	return "The cell must be a valid US ZIP code (i.e. 12345 or 12345-6789)"
+}
+
+func (e PostalCodeEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
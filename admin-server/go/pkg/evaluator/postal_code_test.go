@@ -0,0 +1,25 @@
+package evaluator
+
+import "testing"
+
+func TestPostalCodeEvaluator(t *testing.T) {
+	us := map[string]interface{}{"country": "US"}
+	ca := map[string]interface{}{"country": "ca"}
+	gb := map[string]interface{}{"country": "UK"}
+	runEvaluateTests(t, func() Evaluator { return &PostalCodeEvaluator{} }, []evaluateTest{
+		{name: "US ZIP", options: us, cell: "78701", wantPass: true, wantValue: "78701"},
+		{name: "US ZIP+4", options: us, cell: "78701 1234", wantPass: true, wantValue: "78701-1234"},
+		{name: "US ZIP+4 without a separator", options: us, cell: "787011234", wantPass: true, wantValue: "78701-1234"},
+		{name: "US too short", options: us, cell: "7870", wantPass: false, wantValue: "7870"},
+		{name: "Canada", options: ca, cell: "k1a0b1", wantPass: true, wantValue: "K1A 0B1"},
+		{name: "Canada invalid letter", options: ca, cell: "D1A 0B1", wantPass: false, wantValue: "D1A 0B1"},
+		{name: "UK", options: gb, cell: "sw1a  1aa", wantPass: true, wantValue: "SW1A 1AA"},
+		{name: "UK short", options: gb, cell: "M1 1AE", wantPass: true, wantValue: "M1 1AE"},
+		{name: "UK invalid", options: gb, cell: "SW1A", wantPass: false, wantValue: "SW1A"},
+		{name: "blank", options: us, cell: " ", wantPass: false, wantValue: ""},
+	})
+	runInitializeTests(t, func() Evaluator { return &PostalCodeEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil, wantErr: true},
+		{name: "unsupported country", options: map[string]interface{}{"country": "DE"}, wantErr: true},
+	})
+}
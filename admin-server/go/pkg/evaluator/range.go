@@ -7,41 +7,75 @@ import (
 	"tableflow/go/pkg/util"
 )
 
+// RangeEvaluator checks the number is within the min and/or max, which can be decimals
+// Example options: {"min": 0, "max": 99.99}
 type RangeEvaluator struct {
-	Options *MinMaxEvaluatorOptions
+	Min *big.Float
+	Max *big.Float
 }
 
 func (e *RangeEvaluator) Initialize(options interface{}) error {
-	minMaxOptions, err := parseMinMaxOptions(options, 0)
-	if err != nil {
+	if options == nil {
+		return errors.New("not provided")
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	var err error
+	if e.Min, err = parseRangeBound(optionsMap, "min"); err != nil {
 		return err
 	}
-	e.Options = minMaxOptions
+	if e.Max, err = parseRangeBound(optionsMap, "max"); err != nil {
+		return err
+	}
+	if e.Min == nil && e.Max == nil {
+		return errors.New("min and/or max are required")
+	}
+	if e.Min != nil && e.Max != nil && e.Min.Cmp(e.Max) > 0 {
+		return errors.New("min cannot be greater than the max")
+	}
 	return nil
 }
 
+// parseRangeBound parses a bound provided as a JSON number or, to avoid any floating point rounding, a numeric string
+func parseRangeBound(optionsMap map[string]interface{}, key string) (*big.Float, error) {
+	var bound util.BigFloat
+	var ok bool
+	switch v := optionsMap[key].(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		bound, ok = util.StringToBigFloat(fmt.Sprint(v))
+	case string:
+		bound, ok = util.StringToBigFloat(v)
+	}
+	if !ok || bound.IsInf() {
+		return nil, fmt.Errorf("%s must be a number", key)
+	}
+	return bound.Float, nil
+}
+
 func (e *RangeEvaluator) Evaluate(cell string) (bool, string, error) {
-	if e.Options == nil {
+	if e.Min == nil && e.Max == nil {
 		return false, cell, errors.New("uninitialized range evaluator")
 	}
 
-	cellFloat, ok := util.StringToBigFloat(cell)
+	// The number validation runs first, so the cell is in the canonical number format
+	_, str, err := util.StringToNumberOrNil(cell)
+	if err != nil || len(str) == 0 {
+		return false, cell, errors.New("invalid number")
+	}
+	cellFloat, ok := util.StringToBigFloat(str)
 	if !ok {
 		return false, cell, errors.New("invalid number")
 	}
 
-	// TODO: Consider storing min and max options as big.Float
-	if e.Options.Min != nil {
-		min := new(big.Float).SetInt64(int64(*e.Options.Min))
-		if cellFloat.Cmp(min) < 0 {
-			return false, cell, nil
-		}
-	}
-	if e.Options.Max != nil {
-		max := new(big.Float).SetInt64(int64(*e.Options.Max))
-		if cellFloat.Cmp(max) > 0 {
-			return false, cell, nil
-		}
+	if e.Min != nil && cellFloat.Cmp(e.Min) < 0 {
+		return false, cell, nil
+	}
+	if e.Max != nil && cellFloat.Cmp(e.Max) > 0 {
+		return false, cell, nil
 	}
 	return true, cell, nil
 }
@@ -49,14 +83,14 @@ func (e *RangeEvaluator) Evaluate(cell string) (bool, string, error) {
 func (e RangeEvaluator) DefaultMessage() string {
 	minMsg, maxMsg := "", ""
 
-	if e.Options.Min != nil && e.Options.Max != nil && *e.Options.Min == *e.Options.Max {
-		return fmt.Sprintf("The value must equal %d", *e.Options.Min)
+	if e.Min != nil && e.Max != nil && e.Min.Cmp(e.Max) == 0 {
+		return fmt.Sprintf("The value must equal %s", e.Min.Text('f', -1))
 	}
-	if e.Options.Min != nil {
-		minMsg = fmt.Sprintf("greater than or equal to %d", *e.Options.Min)
+	if e.Min != nil {
+		minMsg = fmt.Sprintf("greater than or equal to %s", e.Min.Text('f', -1))
 	}
-	if e.Options.Max != nil {
-		maxMsg = fmt.Sprintf("less than or equal to %d", *e.Options.Max)
+	if e.Max != nil {
+		maxMsg = fmt.Sprintf("less than or equal to %s", e.Max.Text('f', -1))
 	}
 
 	switch {
@@ -73,5 +107,5 @@ func (e RangeEvaluator) DefaultMessage() string {
 }
 
 func (e RangeEvaluator) AllowedDataTypes() []string {
-	return []string{"number"}
+	return []string{"number", "integer", "decimal"}
 }
@@ -0,0 +1,185 @@
+package evaluator
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"regexp"
+	"sync"
+	"tableflow/go/pkg/model/jsonb"
+)
+
+// Definition describes a validate type that can be used on template column validations
+type Definition struct {
+	// Name is the validate type, i.e. "sku". It can only contain lowercase letters, numbers, and underscores.
+	Name string
+	// New returns a new, uninitialized evaluator. Initialize is called on it with the validation options.
+	New func() Evaluator
+	// AllowedDataTypes are the template column data types the validation can be added to. If not set, the
+	// AllowedDataTypes of the evaluator returned from New are used.
+	AllowedDataTypes []string
+	// OptionSchema is a JSON schema describing the options of the validation. It is returned to clients along with the
+	// available validations, the options themselves are validated by Initialize.
+	OptionSchema map[string]interface{}
+	// DataType is set if the evaluator is the default validation of a data type (i.e. "number"), these are added
+	// automatically when the data type is set and can't be added directly
+	DataType bool
+	// Hidden definitions are not returned in DataTypeValidations, i.e. when they are set through other column settings
+	Hidden bool
+	// PrivateOptions are options that are only used server-side, such as credentials. They are removed from the
+	// validation options returned to the importer.
+	PrivateOptions []string
+}
+
+var definitionNameRegex = regexp.MustCompile("^[a-z0-9_]+$")
+
+var registry = struct {
+	sync.RWMutex
+	definitions map[string]Definition
+	names       []string
+}{
+	definitions: make(map[string]Definition),
+}
+
+func init() {
+	builtIns := []Definition{
+		{Name: "number", New: func() Evaluator { return &NumberEvaluator{} }, DataType: true},
+		{Name: "boolean", New: func() Evaluator { return &BooleanEvaluator{} }, DataType: true},
+		{Name: "date", New: func() Evaluator { return &DateEvaluator{} }, DataType: true},
+		{Name: "integer", New: func() Evaluator { return &IntegerEvaluator{} }, DataType: true},
+		{Name: "decimal", New: func() Evaluator { return &DecimalEvaluator{} }, DataType: true},
+		{Name: "datetime", New: func() Evaluator { return &DatetimeEvaluator{} }, DataType: true},
+		{Name: "enum", New: func() Evaluator { return &EnumEvaluator{} }, DataType: true},
+		{Name: "json", New: func() Evaluator { return &JSONEvaluator{} }, DataType: true},
+		{Name: "not_blank", New: func() Evaluator { return &NotBlankEvaluator{} }, Hidden: true},
+		{Name: "regex", New: func() Evaluator { return &RegexEvaluator{} }},
+		{Name: "email", New: func() Evaluator { return &EmailEvaluator{} }},
+		{Name: "phone", New: func() Evaluator { return &PhoneEvaluator{} }},
+		{Name: "url", New: func() Evaluator { return &URLEvaluator{} }},
+		{Name: "uuid", New: func() Evaluator { return &UUIDEvaluator{} }},
+		{Name: "ip", New: func() Evaluator { return &IPEvaluator{} }},
+		{Name: "country_code", New: func() Evaluator { return &CountryCodeEvaluator{} }},
+		{Name: "currency_code", New: func() Evaluator { return &CurrencyCodeEvaluator{} }},
+		{Name: "language_code", New: func() Evaluator { return &LanguageCodeEvaluator{} }},
+		{Name: "iban", New: func() Evaluator { return &IBANEvaluator{} }},
+		{Name: "credit_card", New: func() Evaluator { return &CreditCardEvaluator{} }},
+		{Name: "postal_code", New: func() Evaluator { return &PostalCodeEvaluator{} }},
+		{Name: "length", New: func() Evaluator { return &LengthEvaluator{} }},
+		{Name: "range", New: func() Evaluator { return &RangeEvaluator{} }},
+		{Name: "list", New: func() Evaluator { return &ListEvaluator{} }},
+		{Name: "lookup", New: func() Evaluator { return &LookupEvaluator{} }},
+		{Name: "http", New: func() Evaluator { return &HTTPEvaluator{} }, PrivateOptions: []string{"headers"}},
+		{Name: "compare", New: func() Evaluator { return &CompareEvaluator{} }},
+		{Name: "conditional", New: func() Evaluator { return &ConditionalEvaluator{} }},
+		{Name: "expression", New: func() Evaluator { return &ExpressionEvaluator{} }},
+	}
+	for _, d := range builtIns {
+		if err := Register(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a validate type to the registry so it can be used on template column validations. Validate types can
+// only be registered once, and built-in types can't be replaced.
+func Register(d Definition) error {
+	if !definitionNameRegex.MatchString(d.Name) {
+		return fmt.Errorf("The validate type %s is invalid, it can only contain lowercase letters, numbers, and underscores", d.Name)
+	}
+	if d.New == nil {
+		return fmt.Errorf("The validate type %s requires a constructor", d.Name)
+	}
+	if len(d.AllowedDataTypes) == 0 {
+		e := d.New()
+		if e == nil {
+			return fmt.Errorf("The constructor of the validate type %s returned nil", d.Name)
+		}
+		d.AllowedDataTypes = e.AllowedDataTypes()
+	}
+	if len(d.AllowedDataTypes) == 0 {
+		return fmt.Errorf("The validate type %s must allow at least one data type", d.Name)
+	}
+	for _, dataType := range d.AllowedDataTypes {
+		if !lo.Contains(AllDataTypes, dataType) {
+			return fmt.Errorf("The validate type %s allows the invalid data type %s", d.Name, dataType)
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, exists := registry.definitions[d.Name]; exists {
+		return fmt.Errorf("The validate type %s is already registered", d.Name)
+	}
+	registry.definitions[d.Name] = d
+	registry.names = append(registry.names, d.Name)
+	return nil
+}
+
+// Lookup returns the definition of a registered validate type
+func Lookup(validate string) (Definition, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	d, ok := registry.definitions[validate]
+	return d, ok
+}
+
+// Definitions returns all registered validate types in the order they were registered
+func Definitions() []Definition {
+	registry.RLock()
+	defer registry.RUnlock()
+	return lo.Map(registry.names, func(name string, _ int) Definition {
+		return registry.definitions[name]
+	})
+}
+
+// AllValidations returns the names of all registered validate types
+func AllValidations() []string {
+	return lo.Map(Definitions(), func(d Definition, _ int) string { return d.Name })
+}
+
+// DataTypeValidations returns a map of each data type to the definitions of the validations that can be added to it
+func DataTypeValidations() map[string][]Definition {
+	dataTypeValidations := make(map[string][]Definition)
+	for _, dataType := range AllDataTypes {
+		dataTypeValidations[dataType] = make([]Definition, 0)
+	}
+	for _, d := range Definitions() {
+		if d.DataType || d.Hidden {
+			continue
+		}
+		for _, dataType := range d.AllowedDataTypes {
+			dataTypeValidations[dataType] = append(dataTypeValidations[dataType], d)
+		}
+	}
+	return dataTypeValidations
+}
+
+// PublicOptions returns the options of a validation without the private options of its validate type
+func PublicOptions(validate string, options jsonb.JSONB) jsonb.JSONB {
+	d, ok := Lookup(validate)
+	if !ok || len(d.PrivateOptions) == 0 {
+		return options
+	}
+	optionsMap, ok := options.AsMap()
+	if !ok {
+		return options
+	}
+	publicOptions := make(map[string]interface{}, len(optionsMap))
+	for k, v := range optionsMap {
+		if !lo.Contains(d.PrivateOptions, k) {
+			publicOptions[k] = v
+		}
+	}
+	return jsonb.FromMap(publicOptions)
+}
+
+func newFromRegistry(validate string) (Evaluator, error) {
+	d, ok := Lookup(validate)
+	if !ok {
+		return nil, fmt.Errorf("The validate type %s is invalid", validate)
+	}
+	e := d.New()
+	if e == nil {
+		return nil, fmt.Errorf("The validate type %s could not be created", validate)
+	}
+	return e, nil
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"reflect"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+// skuEvaluator is a custom evaluator registered by the tests
+type skuEvaluator struct{}
+
+func (e *skuEvaluator) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (e skuEvaluator) Evaluate(cell string) (bool, string, error) {
+	return len(cell) == 8, cell, nil
+}
+
+func (e skuEvaluator) DefaultMessage() string {
+	return "The cell must be a SKU"
+}
+
+func (e skuEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
+
+// invalidDataTypeEvaluator is a custom evaluator that allows a data type that doesn't exist
+type invalidDataTypeEvaluator struct{ skuEvaluator }
+
+func (e invalidDataTypeEvaluator) AllowedDataTypes() []string {
+	return []string{"text"}
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition Definition
+		wantErr    bool
+	}{
+		{name: "valid", definition: Definition{Name: "test_sku", New: func() Evaluator { return &skuEvaluator{} }}},
+		{name: "duplicate", definition: Definition{Name: "test_sku", New: func() Evaluator { return &skuEvaluator{} }}, wantErr: true},
+		{name: "built-in", definition: Definition{Name: "email", New: func() Evaluator { return &skuEvaluator{} }}, wantErr: true},
+		{name: "invalid name", definition: Definition{Name: "Test SKU", New: func() Evaluator { return &skuEvaluator{} }}, wantErr: true},
+		{name: "no constructor", definition: Definition{Name: "test_no_constructor"}, wantErr: true},
+		{name: "nil constructor result", definition: Definition{Name: "test_nil", New: func() Evaluator { return nil }}, wantErr: true},
+		{
+			name:       "invalid data type of the evaluator",
+			definition: Definition{Name: "test_invalid_evaluator_type", New: func() Evaluator { return &invalidDataTypeEvaluator{} }},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid data type of the definition",
+			definition: Definition{Name: "test_invalid_type", New: func() Evaluator { return &skuEvaluator{} }, AllowedDataTypes: []string{"text"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.definition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Register(%s) error = %v, wantErr %v", tt.definition.Name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisteredEvaluator(t *testing.T) {
+	err := Register(Definition{
+		Name:             "test_restricted_sku",
+		New:              func() Evaluator { return &skuEvaluator{} },
+		AllowedDataTypes: []string{"string"},
+		PrivateOptions:   []string{"token"},
+	})
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	e, err := Parse("test_restricted_sku", jsonb.NewNull())
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if passed, _, _ := e.Evaluate("ABC-1234"); !passed {
+		t.Error("Evaluate(\"ABC-1234\") did not pass")
+	}
+	if _, err = Parse("test_unregistered", jsonb.NewNull()); err == nil {
+		t.Error("Parse of an unregistered validate type did not return an error")
+	}
+
+	d, ok := Lookup("test_restricted_sku")
+	if !ok || !reflect.DeepEqual(d.AllowedDataTypes, []string{"string"}) {
+		t.Errorf("Lookup() = %+v, %v", d, ok)
+	}
+	found := false
+	for _, definition := range DataTypeValidations()["string"] {
+		found = found || definition.Name == "test_restricted_sku"
+	}
+	if !found {
+		t.Error("DataTypeValidations() does not contain the registered validate type on the string data type")
+	}
+
+	options := jsonb.FromMap(map[string]interface{}{"token": "secret", "prefix": "ABC"})
+	want := map[string]interface{}{"prefix": "ABC"}
+	if got, _ := PublicOptions("test_restricted_sku", options).AsMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PublicOptions() = %v, want %v", got, want)
+	}
+}
+
+func TestDataTypeValidations(t *testing.T) {
+	dataTypeValidations := DataTypeValidations()
+	for _, dataType := range AllDataTypes {
+		for _, d := range dataTypeValidations[dataType] {
+			if d.DataType || d.Hidden {
+				t.Errorf("DataTypeValidations()[%s] contains the data type or hidden validate type %s", dataType, d.Name)
+			}
+		}
+	}
+	if !IsDataTypeEvaluator("number") || IsDataTypeEvaluator("email") || IsDataTypeEvaluator("test_unregistered") {
+		t.Error("IsDataTypeEvaluator() did not match the DataType of the definitions")
+	}
+	if _, err := newFromRegistry("unknown"); err == nil {
+		t.Error("newFromRegistry(\"unknown\") did not return an error")
+	}
+}
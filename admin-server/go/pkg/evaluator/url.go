@@ -0,0 +1,77 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"net/url"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// URLEvaluator validates absolute URLs with a host, normalizing the scheme and host to lowercase. Only http and https
+// URLs are allowed unless the schemes are provided.
+// Example options: {"schemes": ["https", "ftp"]}
+type URLEvaluator struct {
+	Schemes []string
+}
+
+var defaultURLSchemes = []string{"http", "https"}
+
+func (e *URLEvaluator) Initialize(options interface{}) error {
+	e.Schemes = defaultURLSchemes
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	if v, exists := optionsMap["schemes"]; exists && v != nil {
+		schemes, ok := v.([]interface{})
+		if !ok || len(schemes) == 0 {
+			return errors.New("schemes must be a non-empty array of strings")
+		}
+		e.Schemes = make([]string, 0, len(schemes))
+		for _, s := range schemes {
+			scheme, ok := s.(string)
+			if !ok || len(strings.TrimSpace(scheme)) == 0 {
+				return errors.New("schemes must be a non-empty array of strings")
+			}
+			e.Schemes = append(e.Schemes, strings.ToLower(strings.TrimSpace(scheme)))
+		}
+	}
+	return nil
+}
+
+func (e URLEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	trimmed := strings.TrimSpace(cell)
+	if strings.ContainsAny(trimmed, " \t\r\n") {
+		return false, cell, nil
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil || len(u.Host) == 0 || len(u.Hostname()) == 0 {
+		return false, cell, nil
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	schemes := lo.Ternary(len(e.Schemes) != 0, e.Schemes, defaultURLSchemes)
+	if !lo.Contains(schemes, u.Scheme) {
+		return false, cell, nil
+	}
+	u.Host = strings.ToLower(u.Host)
+	return true, u.String(), nil
+}
+
+func (e URLEvaluator) DefaultMessage() string {
+	schemes := lo.Ternary(len(e.Schemes) != 0, e.Schemes, defaultURLSchemes)
+	return fmt.Sprintf("The cell must be a valid URL starting with %s", strings.Join(lo.Map(schemes, func(s string, _ int) string {
+		return s + "://"
+	}), " or "))
+}
+
+func (e URLEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
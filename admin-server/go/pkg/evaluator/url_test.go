@@ -0,0 +1,23 @@
+package evaluator
+
+import "testing"
+
+func TestURLEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &URLEvaluator{} }, []evaluateTest{
+		{name: "https", cell: "https://example.com/path?q=1", wantPass: true, wantValue: "https://example.com/path?q=1"},
+		{name: "scheme and host lowercased", cell: " HTTP://Example.COM/Path ", wantPass: true, wantValue: "http://example.com/Path"},
+		{name: "port", cell: "http://localhost:8080", wantPass: true, wantValue: "http://localhost:8080"},
+		{name: "no scheme", cell: "example.com", wantPass: false, wantValue: "example.com"},
+		{name: "scheme not allowed", cell: "ftp://example.com", wantPass: false, wantValue: "ftp://example.com"},
+		{name: "allowed scheme", options: map[string]interface{}{"schemes": []interface{}{"FTP"}}, cell: "ftp://example.com", wantPass: true, wantValue: "ftp://example.com"},
+		{name: "no host", cell: "https://", wantPass: false, wantValue: "https://"},
+		{name: "whitespace", cell: "https://example.com/a b", wantPass: false, wantValue: "https://example.com/a b"},
+		{name: "blank", cell: " ", wantPass: false, wantValue: ""},
+	})
+	runInitializeTests(t, func() Evaluator { return &URLEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil},
+		{name: "empty schemes", options: map[string]interface{}{"schemes": []interface{}{}}, wantErr: true},
+		{name: "blank scheme", options: map[string]interface{}{"schemes": []interface{}{" "}}, wantErr: true},
+		{name: "schemes not an array", options: map[string]interface{}{"schemes": "https"}, wantErr: true},
+	})
+}
@@ -0,0 +1,59 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// UUIDEvaluator validates UUIDs, including UUIDs without hyphens or in braces, normalizing them to the canonical
+// lowercase form. If a version is provided, only UUIDs of that version are allowed.
+// Example options: {"version": 4}
+type UUIDEvaluator struct {
+	Version int
+}
+
+func (e *UUIDEvaluator) Initialize(options interface{}) error {
+	if options == nil {
+		return nil
+	}
+	optionsMap, ok := options.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid object")
+	}
+	if v, exists := optionsMap["version"]; exists && v != nil {
+		version, ok := v.(float64)
+		if !ok || version < 1 || version > 8 || version != float64(int(version)) {
+			return errors.New("version must be a number from 1 to 8")
+		}
+		e.Version = int(version)
+	}
+	return nil
+}
+
+func (e UUIDEvaluator) Evaluate(cell string) (bool, string, error) {
+	if util.IsBlankUnicode(cell) {
+		return false, "", nil
+	}
+	u, err := uuid.FromString(strings.TrimSpace(cell))
+	if err != nil {
+		return false, cell, nil
+	}
+	if e.Version != 0 && int(u.Version()) != e.Version {
+		return false, cell, nil
+	}
+	return true, u.String(), nil
+}
+
+func (e UUIDEvaluator) DefaultMessage() string {
+	if e.Version != 0 {
+		return fmt.Sprintf("The cell must be a valid version %d UUID", e.Version)
+	}
+	return "The cell must be a valid UUID"
+}
+
+func (e UUIDEvaluator) AllowedDataTypes() []string {
+	return []string{"string"}
+}
@@ -0,0 +1,22 @@
+package evaluator
+
+import "testing"
+
+func TestUUIDEvaluator(t *testing.T) {
+	runEvaluateTests(t, func() Evaluator { return &UUIDEvaluator{} }, []evaluateTest{
+		{name: "canonical", cell: "f0797968-becc-422a-b135-19de1d8c5d46", wantPass: true, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "uppercase", cell: "F0797968-BECC-422A-B135-19DE1D8C5D46", wantPass: true, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "without hyphens", cell: "f0797968becc422ab13519de1d8c5d46", wantPass: true, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "braces", cell: "{f0797968-becc-422a-b135-19de1d8c5d46}", wantPass: true, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "too short", cell: "f0797968-becc-422a-b135", wantPass: false, wantValue: "f0797968-becc-422a-b135"},
+		{name: "version", options: map[string]interface{}{"version": float64(4)}, cell: "f0797968-becc-422a-b135-19de1d8c5d46", wantPass: true, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "other version", options: map[string]interface{}{"version": float64(1)}, cell: "f0797968-becc-422a-b135-19de1d8c5d46", wantPass: false, wantValue: "f0797968-becc-422a-b135-19de1d8c5d46"},
+		{name: "blank", cell: "", wantPass: false, wantValue: ""},
+	})
+	runInitializeTests(t, func() Evaluator { return &UUIDEvaluator{} }, []initializeTest{
+		{name: "no options", options: nil},
+		{name: "version 9", options: map[string]interface{}{"version": float64(9)}, wantErr: true},
+		{name: "fractional version", options: map[string]interface{}{"version": 4.5}, wantErr: true},
+		{name: "version not a number", options: map[string]interface{}{"version": "4"}, wantErr: true},
+	})
+}
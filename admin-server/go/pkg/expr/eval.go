@@ -0,0 +1,396 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxStringLength is the maximum length of a string produced while evaluating an expression
+const MaxStringLength = 1 << 16
+
+func (n *literalNode) eval(s *state) (interface{}, error) {
+	return n.value, s.step(1)
+}
+
+func (n *identNode) eval(s *state) (interface{}, error) {
+	return s.vars[n.name], s.step(1)
+}
+
+func (n *listNode) eval(s *state) (interface{}, error) {
+	if err := s.step(1 + len(n.elements)); err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(n.elements))
+	for _, element := range n.elements {
+		v, err := element.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func (n *unaryNode) eval(s *state) (interface{}, error) {
+	x, err := n.x.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.step(1); err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		b, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator ! requires a boolean, got %s", typeName(x))
+		}
+		return !b, nil
+	case "-":
+		f, ok := toNumber(x)
+		if !ok {
+			return nil, fmt.Errorf("operator - requires a number, got %s", typeName(x))
+		}
+		return -f, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+func (n *binaryNode) eval(s *state) (interface{}, error) {
+	left, err := n.left.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.step(1); err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires booleans, got %s", n.op, typeName(left))
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := n.right.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires booleans, got %s", n.op, typeName(right))
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		cmp, err := compare(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	case "in":
+		return contains(s, right, left)
+	case "+":
+		return add(s, left, right)
+	case "-", "*", "/", "%":
+		l, lok := toNumber(left)
+		r, rok := toNumber(right)
+		if !lok || !rok {
+			return nil, fmt.Errorf("operator %s requires numbers, got %s and %s", n.op, typeName(left), typeName(right))
+		}
+		switch n.op {
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		case "/":
+			if r == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return l / r, nil
+		default:
+			if r == 0 {
+				return nil, fmt.Errorf("modulus by zero")
+			}
+			return math.Mod(l, r), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+func (n *ternaryNode) eval(s *state) (interface{}, error) {
+	cond, err := n.cond.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := cond.(bool)
+	if !ok {
+		return nil, fmt.Errorf("the condition of ?: must be a boolean, got %s", typeName(cond))
+	}
+	if b {
+		return n.then.eval(s)
+	}
+	return n.otherwise.eval(s)
+}
+
+func (n *indexNode) eval(s *state) (interface{}, error) {
+	x, err := n.x.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.step(1); err != nil {
+		return nil, err
+	}
+	switch v := x.(type) {
+	case []interface{}:
+		f, ok := index.(float64)
+		if !ok || f != math.Trunc(f) {
+			return nil, fmt.Errorf("list index must be an integer, got %s", typeName(index))
+		}
+		i := int(f)
+		if i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("list index %d out of range", i)
+		}
+		return v[i], nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, got %s", typeName(index))
+		}
+		return v[key], nil
+	}
+	return nil, fmt.Errorf("cannot index %s", typeName(x))
+}
+
+func (n *selectNode) eval(s *state) (interface{}, error) {
+	x, err := n.x.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.step(1); err != nil {
+		return nil, err
+	}
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot select field %s on %s", n.field, typeName(x))
+	}
+	return m[n.field], nil
+}
+
+func (n *callNode) eval(s *state) (interface{}, error) {
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		v, err := arg.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	if err := s.step(1); err != nil {
+		return nil, err
+	}
+	result, err := n.fn.call(s, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	if str, ok := result.(string); ok {
+		if len(str) > MaxStringLength {
+			return nil, fmt.Errorf("%s: string result is too long", n.name)
+		}
+	}
+	return result, nil
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// toNumber converts numbers and numeric strings to a float64
+func toNumber(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// ToString formats a value as it would be stored in a cell
+func ToString(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case []interface{}:
+		parts := make([]string, 0, len(x))
+		for _, element := range x {
+			parts = append(parts, ToString(element))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(v)
+}
+
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		switch y := b.(type) {
+		case string:
+			return x == y
+		case float64:
+			f, ok := toNumber(x)
+			return ok && f == y
+		case bool:
+			return strings.EqualFold(strings.TrimSpace(x), strconv.FormatBool(y))
+		}
+	case float64:
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	case bool:
+		switch y := b.(type) {
+		case bool:
+			return x == y
+		case string:
+			return equal(y, x)
+		}
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func compare(a, b interface{}) (int, error) {
+	af, aok := toNumber(a)
+	bf, bok := toNumber(b)
+	if !aok || !bok {
+		// Strings are compared as numbers if they are both numeric, as cell values are always strings
+		as, aIsString := a.(string)
+		bs, bIsString := b.(string)
+		if aIsString && bIsString {
+			return strings.Compare(as, bs), nil
+		}
+		return 0, fmt.Errorf("cannot compare %s and %s", typeName(a), typeName(b))
+	}
+	switch {
+	case af < bf:
+		return -1, nil
+	case af > bf:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func contains(s *state, collection, element interface{}) (bool, error) {
+	switch c := collection.(type) {
+	case []interface{}:
+		if err := s.step(len(c)); err != nil {
+			return false, err
+		}
+		for _, v := range c {
+			if equal(element, v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		key, ok := element.(string)
+		if !ok {
+			return false, nil
+		}
+		_, exists := c[key]
+		return exists, nil
+	}
+	return false, fmt.Errorf("operator in requires a list or map, got %s", typeName(collection))
+}
+
+func add(s *state, left, right interface{}) (interface{}, error) {
+	switch l := left.(type) {
+	case float64:
+		if r, ok := toNumber(right); ok {
+			return l + r, nil
+		}
+	case string:
+		switch r := right.(type) {
+		case string:
+			if err := s.step((len(l) + len(r)) / 64); err != nil {
+				return nil, err
+			}
+			if len(l)+len(r) > MaxStringLength {
+				return nil, fmt.Errorf("string result is too long")
+			}
+			return l + r, nil
+		case float64:
+			if f, ok := toNumber(l); ok {
+				return f + r, nil
+			}
+		}
+	case []interface{}:
+		if r, ok := right.([]interface{}); ok {
+			if err := s.step(len(l) + len(r)); err != nil {
+				return nil, err
+			}
+			return append(append(make([]interface{}, 0, len(l)+len(r)), l...), r...), nil
+		}
+	}
+	return nil, fmt.Errorf("operator + cannot be used with %s and %s", typeName(left), typeName(right))
+}
@@ -0,0 +1,193 @@
+package expr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]interface{}{
+		"amount":  "12.5",
+		"qty":     "3",
+		"name":    " Ada Lovelace ",
+		"code":    "AC-1001",
+		"blank":   "  ",
+		"active":  "TRUE",
+		"tags":    []interface{}{"a", "b"},
+		"row":     map[string]interface{}{"first_name": "Ada", "last name": "Lovelace"},
+		"missing": nil,
+	}
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		// Precedence and associativity
+		{source: "1 + 2 * 3", want: float64(7)},
+		{source: "(1 + 2) * 3", want: float64(9)},
+		{source: "10 - 4 - 3", want: float64(3)},
+		{source: "12 / 3 / 2", want: float64(2)},
+		{source: "-2 * 3", want: float64(-6)},
+		{source: "7 % 4 + 1", want: float64(4)},
+		{source: "1 + 1 == 2 && 2 < 3", want: true},
+		{source: "true || false && false", want: true},
+		{source: "!(1 > 2) == true", want: true},
+		{source: "false ? 1 : true ? 2 : 3", want: float64(2)},
+		{source: "1 + 2 in [3, 4]", want: true},
+		{source: "1.5e2", want: float64(150)},
+
+		// Strings are converted to numbers when used with numbers
+		{source: "amount * qty", want: float64(37.5)},
+		{source: "amount > 10", want: true},
+		{source: "qty + 1", want: float64(4)},
+		{source: "1 + qty", want: float64(4)},
+		{source: "qty == 3", want: true},
+		{source: "qty < '10'", want: true},
+		{source: "'b' > 'a'", want: true},
+		{source: "active == true", want: true},
+		{source: "code + '-X'", want: "AC-1001-X"},
+		{source: "missing == null", want: true},
+		{source: "qty == null", want: false},
+		{source: "[1, '2'] == ['1', 2]", want: true},
+		{source: "[1, 2] + [3]", want: []interface{}{float64(1), float64(2), float64(3)}},
+
+		// Short-circuiting skips the errors of the other operand
+		{source: "false && 1 / 0 > 0", want: false},
+		{source: "true || 'a' > 1", want: true},
+
+		// Access
+		{source: "row.first_name", want: "Ada"},
+		{source: "row['last name']", want: "Lovelace"},
+		{source: "row.middle_name", want: nil},
+		{source: "tags[1]", want: "b"},
+		{source: "'first_name' in row", want: true},
+		{source: "undefined", want: nil},
+
+		// Functions, globally and as methods
+		{source: "size(code)", want: float64(7)},
+		{source: "'héllo'.size()", want: float64(5)},
+		{source: "tags.size()", want: float64(2)},
+		{source: "size(missing)", want: float64(0)},
+		{source: "code.startsWith('AC')", want: true},
+		{source: "code.endsWith('1001')", want: true},
+		{source: "name.contains('Ada')", want: true},
+		{source: "tags.contains('b')", want: true},
+		{source: "code.matches('^[A-Z]{2}-\\d+$')", want: true},
+		{source: "name.trim().lower()", want: "ada lovelace"},
+		{source: "upper(code)", want: "AC-1001"},
+		{source: "replace(code, '-', '')", want: "AC1001"},
+		{source: "substring(code, 3)", want: "1001"},
+		{source: "substring(code, 0, 2)", want: "AC"},
+		{source: "split(code, '-')", want: []interface{}{"AC", "1001"}},
+		{source: "split('', ',')", want: []interface{}{""}},
+		{source: "split('a,,b', ',').size()", want: float64(3)},
+		{source: "join(tags, '|')", want: "a|b"},
+		{source: "join(['x', 1, true])", want: "x1true"},
+		{source: "isBlank(blank)", want: true},
+		{source: "isBlank(missing)", want: true},
+		{source: "isNumber(amount)", want: true},
+		{source: "isNumber(code)", want: false},
+		{source: "coalesce(missing, blank, code)", want: "AC-1001"},
+		{source: "coalesce(missing)", want: nil},
+		{source: "int(amount)", want: float64(12)},
+		{source: "int('-2.7')", want: float64(-2)},
+		{source: "double(qty)", want: float64(3)},
+		{source: "abs(-2)", want: float64(2)},
+		{source: "floor(amount)", want: float64(12)},
+		{source: "ceil(amount)", want: float64(13)},
+		{source: "round(amount)", want: float64(13)},
+		{source: "round(2.345, 2)", want: float64(2.35)},
+		{source: "min(3, qty, 5)", want: float64(3)},
+		{source: "max([1, 9, 4])", want: float64(9)},
+		{source: "string(1.5)", want: "1.5"},
+		{source: "string(true)", want: "true"},
+		{source: "bool(active)", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			p, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile(%q) returned error: %v", tt.source, err)
+			}
+			got, err := p.Eval(vars, DefaultLimits)
+			if err != nil {
+				t.Fatalf("Eval(%q) returned error: %v", tt.source, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Eval(%q) = %#v, want %#v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	vars := map[string]interface{}{
+		"code": "AC-1001",
+		"tags": []interface{}{"a", "b"},
+		"row":  map[string]interface{}{"first_name": "Ada"},
+	}
+	tests := []struct {
+		source  string
+		wantErr string
+	}{
+		{source: "1 / 0", wantErr: "division by zero"},
+		{source: "1 % 0", wantErr: "modulus by zero"},
+		{source: "code * 2", wantErr: "operator * requires numbers, got string and number"},
+		{source: "-code", wantErr: "operator - requires a number"},
+		{source: "!code", wantErr: "operator ! requires a boolean"},
+		{source: "code && true", wantErr: "operator && requires booleans"},
+		{source: "true && code", wantErr: "operator && requires booleans"},
+		{source: "code ? 1 : 2", wantErr: "the condition of ?: must be a boolean"},
+		{source: "code < 1", wantErr: "cannot compare string and number"},
+		{source: "tags < tags", wantErr: "cannot compare list and list"},
+		{source: "true + 1", wantErr: "operator + cannot be used with boolean and number"},
+		{source: "1 in code", wantErr: "operator in requires a list or map"},
+		{source: "tags[2]", wantErr: "list index 2 out of range"},
+		{source: "tags[0.5]", wantErr: "list index must be an integer"},
+		{source: "row[1]", wantErr: "map key must be a string"},
+		{source: "code[0]", wantErr: "cannot index string"},
+		{source: "code.first", wantErr: "cannot select field first on string"},
+		{source: "code.matches('[')", wantErr: "matches: invalid regex"},
+		{source: "substring(code, 5, 2)", wantErr: "substring: range [5, 2) out of bounds"},
+		{source: "substring(code, 1.5)", wantErr: "substring: expected an integer"},
+		{source: "size(1)", wantErr: "size: cannot get the size of number"},
+		{source: "join(code)", wantErr: "join: expected a list"},
+		{source: "split(tags, ',')", wantErr: "split: expected a string, got list"},
+		{source: "round(1.5, 16)", wantErr: "round: digits must be between 0 and 15"},
+		{source: "max([])", wantErr: "max: requires at least one value"},
+		{source: "min(1, code)", wantErr: "min: expected a number, got string"},
+		{source: "bool('maybe')", wantErr: "bool: cannot convert 'maybe' to a boolean"},
+		{source: "int(code)", wantErr: "int: expected a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			p, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile(%q) returned error: %v", tt.source, err)
+			}
+			_, err = p.Eval(vars, DefaultLimits)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Eval(%q) error = %v, want an error containing %q", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{value: nil, want: ""},
+		{value: "text", want: "text"},
+		{value: float64(1234.5), want: "1234.5"},
+		{value: float64(1e21), want: "1000000000000000000000"},
+		{value: true, want: "true"},
+		{value: []interface{}{"a", float64(2), nil}, want: "a, 2, "},
+	}
+	for _, tt := range tests {
+		if got := ToString(tt.value); got != tt.want {
+			t.Errorf("ToString(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package expr implements a small, CEL-style expression language used for expression validations and computed
+// columns. Expressions are compiled once and can then be evaluated many times against a set of variables, with limits
+// on the cost and time of each evaluation.
+//
+// Supported syntax:
+//
+//	literals     "text", 'text', 10, 2.5, true, false, null, [1, 2, 3]
+//	operators    ! - * / % + < <= > >= == != in && || ?:
+//	access       row["key"], row.key, list[0]
+//	functions    size(value), value.size(), value.startsWith("AC"), int(amount) > 5, ...
+//
+// Values are strings, numbers (float64), booleans, null, lists and maps. Strings are converted to numbers when they
+// are used with a number, so cell values (which are always strings) can be used in arithmetic and comparisons.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSourceLength is the maximum length of an expression
+const MaxSourceLength = 4096
+
+// Limits restrict the cost and time of a single evaluation
+type Limits struct {
+	// MaxCost is the maximum number of evaluation steps, where string operations cost extra based on their length
+	MaxCost int
+	// Timeout is the maximum time an evaluation can take
+	Timeout time.Duration
+}
+
+var DefaultLimits = Limits{
+	MaxCost: 10000,
+	Timeout: 50 * time.Millisecond,
+}
+
+var ErrCostLimitExceeded = errors.New("expression exceeded the cost limit")
+var ErrTimeLimitExceeded = errors.New("expression exceeded the time limit")
+
+type Program struct {
+	source      string
+	root        node
+	identifiers []string
+}
+
+// Compile parses the expression into a Program that can be evaluated
+func Compile(source string) (*Program, error) {
+	if len(source) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+	if !utf8.ValidString(source) {
+		return nil, errors.New("expression is not valid UTF-8")
+	}
+	if len(source) > MaxSourceLength {
+		return nil, fmt.Errorf("expression cannot be longer than %d characters", MaxSourceLength)
+	}
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, identifiers: make(map[string]bool)}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, unexpected(t, "end of expression")
+	}
+	identifiers := lo.Keys(p.identifiers)
+	sort.Strings(identifiers)
+	return &Program{source: source, root: root, identifiers: identifiers}, nil
+}
+
+// Source returns the expression the program was compiled from
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Identifiers returns the names of the variables referenced by the expression
+func (p *Program) Identifiers() []string {
+	return p.identifiers
+}
+
+// Eval evaluates the program with the variables provided. Variables referenced by the expression that aren't provided
+// evaluate to null.
+func (p *Program) Eval(vars map[string]interface{}, limits Limits) (interface{}, error) {
+	s := &state{
+		vars:    vars,
+		maxCost: limits.MaxCost,
+	}
+	if limits.Timeout > 0 {
+		s.deadline = time.Now().Add(limits.Timeout)
+	}
+	return p.root.eval(s)
+}
+
+// EvalBool evaluates the program and requires the result to be a boolean
+func (p *Program) EvalBool(vars map[string]interface{}, limits Limits) (bool, error) {
+	result, err := p.Eval(vars, limits)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to a boolean, got %s", typeName(result))
+	}
+	return b, nil
+}
+
+type state struct {
+	vars     map[string]interface{}
+	steps    int
+	cost     int
+	maxCost  int
+	deadline time.Time
+}
+
+// step adds to the cost of the evaluation, checking the limits
+func (s *state) step(cost int) error {
+	s.steps++
+	s.cost += cost
+	if s.maxCost > 0 && s.cost > s.maxCost {
+		return ErrCostLimitExceeded
+	}
+	// Checking the time is relatively expensive, so only do this periodically
+	if !s.deadline.IsZero() && s.steps%32 == 0 && time.Now().After(s.deadline) {
+		return ErrTimeLimitExceeded
+	}
+	return nil
+}
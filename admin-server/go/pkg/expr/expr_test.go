@@ -0,0 +1,121 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name            string
+		source          string
+		wantIdentifiers []string
+		wantErr         string
+	}{
+		{name: "comparison", source: "amount > 5", wantIdentifiers: []string{"amount"}},
+		{name: "identifiers are sorted and unique", source: "b + a + b", wantIdentifiers: []string{"a", "b"}},
+		{name: "function names aren't identifiers", source: "size(name) > 0 && name.startsWith('A')", wantIdentifiers: []string{"name"}},
+		{name: "literals only", source: "[1, 'two', true, null]", wantIdentifiers: []string{}},
+		{name: "field access", source: "row.first_name == row['last_name']", wantIdentifiers: []string{"row"}},
+		{name: "ternary", source: "a ? b : c", wantIdentifiers: []string{"a", "b", "c"}},
+		{name: "in", source: "country in ['US', 'CA']", wantIdentifiers: []string{"country"}},
+		{name: "nesting at the limit", source: strings.Repeat("(", maxDepth-1) + "1" + strings.Repeat(")", maxDepth-1), wantIdentifiers: []string{}},
+		{name: "empty", source: "", wantErr: "expression is empty"},
+		{name: "invalid UTF-8", source: "a == '\xff'", wantErr: "not valid UTF-8"},
+		{name: "too long", source: strings.Repeat("1+", MaxSourceLength/2) + "1", wantErr: "cannot be longer than"},
+		{name: "nested too deeply", source: strings.Repeat("(", maxDepth+1) + "1" + strings.Repeat(")", maxDepth+1), wantErr: "nested too deeply"},
+		{name: "unary nested too deeply", source: strings.Repeat("!", maxDepth+1) + "true", wantErr: "nested too deeply"},
+		{name: "unknown function", source: "reverse(name)", wantErr: "unknown function reverse"},
+		{name: "wrong number of arguments", source: "startsWith(name)", wantErr: "wrong number of arguments to startsWith"},
+		{name: "unterminated string", source: "name == 'abc", wantErr: "unterminated string"},
+		{name: "unexpected character", source: "a = 1", wantErr: "unexpected character '='"},
+		{name: "invalid number", source: "1.2.3 > 0", wantErr: "invalid number 1.2.3"},
+		{name: "missing operand", source: "a >", wantErr: "unexpected end of expression"},
+		{name: "trailing tokens", source: "a b", wantErr: "expected end of expression"},
+		{name: "missing colon", source: "a ? b", wantErr: "expected ':'"},
+		{name: "unclosed list", source: "[1, 2", wantErr: "expected ']'"},
+		{name: "in as a value", source: "in == 1", wantErr: "unexpected 'in'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.source)
+			if len(tt.wantErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Compile(%q) error = %v, want an error containing %q", tt.source, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Compile(%q) returned error: %v", tt.source, err)
+			}
+			if p.Source() != tt.source {
+				t.Errorf("Source() = %q, want %q", p.Source(), tt.source)
+			}
+			if !reflect.DeepEqual(p.Identifiers(), tt.wantIdentifiers) {
+				t.Errorf("Identifiers() = %q, want %q", p.Identifiers(), tt.wantIdentifiers)
+			}
+		})
+	}
+}
+
+func TestEvalLimits(t *testing.T) {
+	long := strings.Repeat("a", MaxStringLength/2+1)
+	manyElements := "[" + strings.Repeat("1, ", 200) + "1].size()"
+	tests := []struct {
+		name    string
+		source  string
+		vars    map[string]interface{}
+		limits  Limits
+		wantErr error
+		wantMsg string
+	}{
+		{name: "cost", source: "split(s, '').size()", vars: map[string]interface{}{"s": strings.Repeat("a", DefaultLimits.MaxCost+1)}, limits: DefaultLimits, wantErr: ErrCostLimitExceeded},
+		{name: "cost of a large list", source: "1 in l", vars: map[string]interface{}{"l": make([]interface{}, DefaultLimits.MaxCost+1)}, limits: DefaultLimits, wantErr: ErrCostLimitExceeded},
+		{name: "cost of concatenating lists", source: "(l + l).size()", vars: map[string]interface{}{"l": make([]interface{}, DefaultLimits.MaxCost/2+1)}, limits: DefaultLimits, wantErr: ErrCostLimitExceeded},
+		{name: "cost of a small limit", source: manyElements, limits: Limits{MaxCost: 100}, wantErr: ErrCostLimitExceeded},
+		{name: "time", source: manyElements, limits: Limits{Timeout: time.Nanosecond}, wantErr: ErrTimeLimitExceeded},
+		{name: "string concatenation length", source: "s + s", vars: map[string]interface{}{"s": long}, limits: DefaultLimits, wantMsg: "string result is too long"},
+		{name: "replace length", source: "replace(s, 'a', 'aa')", vars: map[string]interface{}{"s": long}, limits: DefaultLimits, wantMsg: "string result is too long"},
+		{name: "function result length", source: "join(split(s, ''), '-')", vars: map[string]interface{}{"s": long}, limits: Limits{}, wantMsg: "join: string result is too long"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile(%q) returned error: %v", tt.source, err)
+			}
+			_, err = p.Eval(tt.vars, tt.limits)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Eval(%q) error = %v, want %v", tt.source, err, tt.wantErr)
+			}
+			if len(tt.wantMsg) != 0 && (err == nil || !strings.Contains(err.Error(), tt.wantMsg)) {
+				t.Errorf("Eval(%q) error = %v, want an error containing %q", tt.source, err, tt.wantMsg)
+			}
+		})
+	}
+
+	// The same expressions pass within the limits
+	p, err := Compile(manyElements)
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	if result, err := p.Eval(nil, DefaultLimits); err != nil || result != float64(201) {
+		t.Errorf("Eval = %v, %v, want 201", result, err)
+	}
+}
+
+func TestEvalBool(t *testing.T) {
+	p, err := Compile("amount")
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	if b, err := p.EvalBool(map[string]interface{}{"amount": true}, DefaultLimits); err != nil || !b {
+		t.Errorf("EvalBool = %v, %v, want true", b, err)
+	}
+	if _, err = p.EvalBool(map[string]interface{}{"amount": "true"}, DefaultLimits); err == nil || !strings.Contains(err.Error(), "must evaluate to a boolean, got string") {
+		t.Errorf("EvalBool of a string error = %v, want an error", err)
+	}
+}
@@ -0,0 +1,349 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"tableflow/go/pkg/util"
+	"unicode/utf8"
+)
+
+type function struct {
+	minArgs int
+	maxArgs int // -1 for variadic
+	call    func(s *state, args []interface{}) (interface{}, error)
+}
+
+// functions can be called globally, i.e. size(value), or as a method on the first argument, i.e. value.size()
+var functions map[string]*function
+
+func init() {
+	functions = map[string]*function{
+		"size":       {1, 1, fnSize},
+		"startsWith": {2, 2, stringPredicate(strings.HasPrefix)},
+		"endsWith":   {2, 2, stringPredicate(strings.HasSuffix)},
+		"contains":   {2, 2, fnContains},
+		"matches":    {2, 2, fnMatches},
+		"lower":      {1, 1, stringTransform(strings.ToLower)},
+		"upper":      {1, 1, stringTransform(strings.ToUpper)},
+		"trim":       {1, 1, stringTransform(strings.TrimSpace)},
+		"replace":    {3, 3, fnReplace},
+		"substring":  {2, 3, fnSubstring},
+		"split":      {2, 2, fnSplit},
+		"join":       {1, 2, fnJoin},
+		"isBlank":    {1, 1, fnIsBlank},
+		"isNumber":   {1, 1, fnIsNumber},
+		"coalesce":   {1, -1, fnCoalesce},
+		"int":        {1, 1, numberTransform(math.Trunc)},
+		"double":     {1, 1, numberTransform(func(f float64) float64 { return f })},
+		"abs":        {1, 1, numberTransform(math.Abs)},
+		"floor":      {1, 1, numberTransform(math.Floor)},
+		"ceil":       {1, 1, numberTransform(math.Ceil)},
+		"round":      {1, 2, fnRound},
+		"min":        {1, -1, numberReduce(math.Min)},
+		"max":        {1, -1, numberReduce(math.Max)},
+		"string":     {1, 1, fnString},
+		"bool":       {1, 1, fnBool},
+	}
+}
+
+func asString(v interface{}) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case nil, float64, bool:
+		return ToString(x), nil
+	}
+	return "", fmt.Errorf("expected a string, got %s", typeName(v))
+}
+
+func asNumber(v interface{}) (float64, error) {
+	f, ok := toNumber(v)
+	if !ok {
+		return 0, fmt.Errorf("expected a number, got %s", typeName(v))
+	}
+	return f, nil
+}
+
+func asInt(v interface{}) (int, error) {
+	f, err := asNumber(v)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("expected an integer, got %v", f)
+	}
+	return int(f), nil
+}
+
+func stringPredicate(predicate func(string, string) bool) func(*state, []interface{}) (interface{}, error) {
+	return func(s *state, args []interface{}) (interface{}, error) {
+		a, err := asString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := asString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return predicate(a, b), s.step(len(a) / 64)
+	}
+}
+
+func stringTransform(transform func(string) string) func(*state, []interface{}) (interface{}, error) {
+	return func(s *state, args []interface{}) (interface{}, error) {
+		str, err := asString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return transform(str), s.step(len(str) / 64)
+	}
+}
+
+func numberTransform(transform func(float64) float64) func(*state, []interface{}) (interface{}, error) {
+	return func(_ *state, args []interface{}) (interface{}, error) {
+		f, err := asNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return transform(f), nil
+	}
+}
+
+func numberReduce(reduce func(float64, float64) float64) func(*state, []interface{}) (interface{}, error) {
+	return func(s *state, args []interface{}) (interface{}, error) {
+		// Allow a single list argument, i.e. max([a, b, c])
+		if list, ok := args[0].([]interface{}); ok && len(args) == 1 {
+			args = list
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("requires at least one value")
+		}
+		if err := s.step(len(args)); err != nil {
+			return nil, err
+		}
+		result, err := asNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range args[1:] {
+			f, err := asNumber(arg)
+			if err != nil {
+				return nil, err
+			}
+			result = reduce(result, f)
+		}
+		return result, nil
+	}
+}
+
+func fnSize(_ *state, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(utf8.RuneCountInString(v)), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	}
+	return nil, fmt.Errorf("cannot get the size of %s", typeName(args[0]))
+}
+
+func fnContains(s *state, args []interface{}) (interface{}, error) {
+	if _, ok := args[0].([]interface{}); ok {
+		return contains(s, args[0], args[1])
+	}
+	return stringPredicate(strings.Contains)(s, args)
+}
+
+const maxCachedRegexes = 256
+
+var regexCache sync.Map
+var regexCacheSize int
+var regexCacheLock sync.Mutex
+
+func compileRegex(s *state, pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	if err := s.step(len(pattern)); err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %v", err)
+	}
+	regexCacheLock.Lock()
+	defer regexCacheLock.Unlock()
+	if regexCacheSize < maxCachedRegexes {
+		regexCache.Store(pattern, re)
+		regexCacheSize++
+	}
+	return re, nil
+}
+
+func fnMatches(s *state, args []interface{}) (interface{}, error) {
+	str, err := asString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := asString(args[1])
+	if err != nil {
+		return nil, err
+	}
+	re, err := compileRegex(s, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(str), s.step(len(str) / 16)
+}
+
+func fnReplace(s *state, args []interface{}) (interface{}, error) {
+	strs := make([]string, 0, 3)
+	for _, arg := range args {
+		str, err := asString(arg)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, str)
+	}
+	if len(strs[1]) != 0 && len(strs[2]) > len(strs[1]) &&
+		len(strs[0])+strings.Count(strs[0], strs[1])*(len(strs[2])-len(strs[1])) > MaxStringLength {
+		return nil, fmt.Errorf("string result is too long")
+	}
+	return strings.ReplaceAll(strs[0], strs[1], strs[2]), s.step(len(strs[0]) / 64)
+}
+
+func fnSubstring(_ *state, args []interface{}) (interface{}, error) {
+	str, err := asString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(str)
+	start, err := asInt(args[1])
+	if err != nil {
+		return nil, err
+	}
+	end := len(runes)
+	if len(args) == 3 {
+		if end, err = asInt(args[2]); err != nil {
+			return nil, err
+		}
+	}
+	if start < 0 || end > len(runes) || start > end {
+		return nil, fmt.Errorf("range [%d, %d) out of bounds for a string of length %d", start, end, len(runes))
+	}
+	return string(runes[start:end]), nil
+}
+
+func fnSplit(s *state, args []interface{}) (interface{}, error) {
+	str, err := asString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	sep, err := asString(args[1])
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(str, sep)
+	if err = s.step(len(parts)); err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		list = append(list, part)
+	}
+	return list, nil
+}
+
+func fnJoin(s *state, args []interface{}) (interface{}, error) {
+	list, ok := args[0].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %s", typeName(args[0]))
+	}
+	sep := ""
+	if len(args) == 2 {
+		var err error
+		if sep, err = asString(args[1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.step(len(list)); err != nil {
+		return nil, err
+	}
+	parts := make([]string, 0, len(list))
+	for _, v := range list {
+		str, err := asString(v)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, str)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func fnIsBlank(_ *state, args []interface{}) (interface{}, error) {
+	str, err := asString(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return util.IsBlankUnicode(str), nil
+}
+
+func fnIsNumber(_ *state, args []interface{}) (interface{}, error) {
+	_, ok := toNumber(args[0])
+	return ok, nil
+}
+
+func fnCoalesce(_ *state, args []interface{}) (interface{}, error) {
+	for _, arg := range args {
+		if str, ok := arg.(string); ok && util.IsBlankUnicode(str) {
+			continue
+		}
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+func fnRound(_ *state, args []interface{}) (interface{}, error) {
+	f, err := asNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	digits := 0
+	if len(args) == 2 {
+		if digits, err = asInt(args[1]); err != nil {
+			return nil, err
+		}
+		if digits < 0 || digits > 15 {
+			return nil, fmt.Errorf("digits must be between 0 and 15")
+		}
+	}
+	pow := math.Pow(10, float64(digits))
+	return math.Round(f*pow) / pow, nil
+}
+
+func fnString(_ *state, args []interface{}) (interface{}, error) {
+	return asString(args[0])
+}
+
+func fnBool(_ *state, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(v)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert '%s' to a boolean", v)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to a boolean", typeName(args[0]))
+}
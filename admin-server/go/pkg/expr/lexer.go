@@ -0,0 +1,125 @@
+package expr
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+var twoCharOperators = []string{"&&", "||", "==", "!=", "<=", ">="}
+
+const singleCharOperators = "!<>+-*/%().,[]?:"
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				i++
+				if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+					i++
+				}
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			text := string(runes[start:i])
+			num, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %s at position %d", text, start)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, num: num, pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			str, next, err := readString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			tokens = append(tokens, token{kind: tokenString, text: str, pos: start})
+		default:
+			if i+1 < len(runes) && lo.Contains(twoCharOperators, string(runes[i:i+2])) {
+				tokens = append(tokens, token{kind: tokenOperator, text: string(runes[i : i+2]), pos: i})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune(singleCharOperators, r) {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: i})
+			i++
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+// readString reads a quoted string literal starting at runes[start], returning the unescaped value and the position
+// after the closing quote
+func readString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		r := runes[i]
+		if r == quote {
+			return sb.String(), i + 1, nil
+		}
+		if r != '\\' {
+			sb.WriteRune(r)
+			continue
+		}
+		i++
+		if i >= len(runes) {
+			break
+		}
+		switch runes[i] {
+		case 'n':
+			sb.WriteRune('\n')
+		case 't':
+			sb.WriteRune('\t')
+		case 'r':
+			sb.WriteRune('\r')
+		case '\\', '"', '\'':
+			sb.WriteRune(runes[i])
+		default:
+			// Keep unknown escapes as-is so regex patterns such as "\d" can be written without double escaping
+			sb.WriteRune('\\')
+			sb.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
@@ -0,0 +1,327 @@
+package expr
+
+import (
+	"fmt"
+)
+
+// maxDepth limits the nesting of expressions so deeply nested input can't exhaust the stack
+const maxDepth = 64
+
+type node interface {
+	eval(s *state) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type identNode struct {
+	name string
+}
+
+type listNode struct {
+	elements []node
+}
+
+type unaryNode struct {
+	op string
+	x  node
+}
+
+type binaryNode struct {
+	op    string
+	left  node
+	right node
+}
+
+type ternaryNode struct {
+	cond      node
+	then      node
+	otherwise node
+}
+
+type indexNode struct {
+	x     node
+	index node
+}
+
+type selectNode struct {
+	x     node
+	field string
+}
+
+type callNode struct {
+	name string
+	fn   *function
+	args []node
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	depth       int
+	identifiers map[string]bool
+}
+
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3, "in": 3,
+	"+": 4, "-": 4,
+	"*": 5, "/": 5, "%": 5,
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(op string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.text == op
+}
+
+func (p *parser) expect(op string) error {
+	t := p.next()
+	if t.kind != tokenOperator || t.text != op {
+		return unexpected(t, fmt.Sprintf("'%s'", op))
+	}
+	return nil
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression, expected %s", expected)
+	}
+	return fmt.Errorf("unexpected '%s' at position %d, expected %s", t.text, t.pos, expected)
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return fmt.Errorf("expression is nested too deeply (max %d)", maxDepth)
+	}
+	return nil
+}
+
+// parseExpression parses a full expression, including the ternary conditional operator
+func (p *parser) parseExpression() (node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	cond, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("?") {
+		return cond, nil
+	}
+	p.next()
+	then, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ternaryNode{cond: cond, then: then, otherwise: otherwise}, nil
+}
+
+func (p *parser) binaryOperator() (string, int, bool) {
+	t := p.peek()
+	if t.kind == tokenOperator || (t.kind == tokenIdent && t.text == "in") {
+		precedence, ok := binaryPrecedence[t.text]
+		return t.text, precedence, ok
+	}
+	return "", 0, false
+}
+
+func (p *parser) parseBinary(minPrecedence int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, precedence, ok := p.binaryOperator()
+		if !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!") || p.isOperator("-") {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+		op := p.next().text
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, x: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOperator("."):
+			p.next()
+			t := p.next()
+			if t.kind != tokenIdent {
+				return nil, unexpected(t, "a field or function name")
+			}
+			if !p.isOperator("(") {
+				x = &selectNode{x: x, field: t.text}
+				continue
+			}
+			// Method call, the target is passed as the first argument
+			args, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			if x, err = newCall(t, append([]node{x}, args...)); err != nil {
+				return nil, err
+			}
+		case p.isOperator("["):
+			p.next()
+			index, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &indexNode{x: x, index: index}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parseArguments() ([]node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []node
+	if p.isOperator(")") {
+		p.next()
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.isOperator(",") {
+			p.next()
+			continue
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return &literalNode{value: t.num}, nil
+	case tokenString:
+		return &literalNode{value: t.text}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "in":
+			return nil, unexpected(t, "a value")
+		}
+		if p.isOperator("(") {
+			args, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			return newCall(t, args)
+		}
+		p.identifiers[t.text] = true
+		return &identNode{name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			x, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "[":
+			list := &listNode{}
+			if p.isOperator("]") {
+				p.next()
+				return list, nil
+			}
+			for {
+				element, err := p.parseExpression()
+				if err != nil {
+					return nil, err
+				}
+				list.elements = append(list.elements, element)
+				if p.isOperator(",") {
+					p.next()
+					continue
+				}
+				if err = p.expect("]"); err != nil {
+					return nil, err
+				}
+				return list, nil
+			}
+		}
+	}
+	return nil, unexpected(t, "a value")
+}
+
+func newCall(t token, args []node) (node, error) {
+	fn, ok := functions[t.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at position %d", t.text, t.pos)
+	}
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, fmt.Errorf("wrong number of arguments to %s at position %d", t.text, t.pos)
+	}
+	return &callNode{name: t.text, fn: fn, args: args}, nil
+}
@@ -0,0 +1,135 @@
+package file
+
+import (
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+	"math"
+	"strings"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/scylla"
+	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/types"
+	"tableflow/go/pkg/util"
+)
+
+// HeaderRowDetectionSampleSize is the number of rows at the start of an upload considered as the header row, which is
+// the number of rows shown when selecting the header row
+const HeaderRowDetectionSampleSize = 25
+
+// headerRowMinConfidence is the confidence a row must be detected as the header row with for it to be proposed
+const headerRowMinConfidence = 0.5
+
+// The weights of the scores of a row when detecting the header row. The template score is only used if there are
+// template columns to match the cells to, otherwise the other weights are scaled up to sum to 1.
+const (
+	headerRowFillWeight     = 0.25
+	headerRowTextWeight     = 0.2
+	headerRowUniqueWeight   = 0.15
+	headerRowTemplateWeight = 0.4
+)
+
+// DetectUploadHeaderRow proposes the header row of an upload, which is preselected when the user selects the header
+// row. The rows must already be stored. The template columns of the upload (or the importer) and the synonym dictionary
+// of the workspace are used to recognize the header, unless the upload is schemaless.
+func DetectUploadHeaderRow(upload *model.Upload) {
+	var templateColumns []*model.TemplateColumn
+	if upload.Template.Valid && !upload.Schemaless {
+		template, err := types.ConvertRawTemplate(upload.Template, false, nil, false)
+		if err != nil {
+			tf.Log.Warnw("Could not convert upload template to detect the header row", "upload_id", upload.ID, "error", err)
+			return
+		}
+		templateColumns = types.ConvertTemplateToModel(template, upload.WorkspaceID).TemplateColumns
+	} else if !upload.Schemaless {
+		template, err := db.GetTemplateByImporter(upload.ImporterID.String())
+		if err != nil {
+			tf.Log.Warnw("Could not retrieve template to detect the header row", "upload_id", upload.ID, "error", err)
+			return
+		}
+		templateColumns = template.TemplateColumns
+	}
+
+	var synonyms *SynonymDictionary
+	if len(templateColumns) != 0 {
+		synonyms = LoadSynonymDictionary(upload.WorkspaceID.String())
+	}
+
+	// One more row is retrieved than can be the header row, as a header must have a row of data after it
+	rows := scylla.PaginateUploadRows(upload.ID.String(), 0, HeaderRowDetectionSampleSize+1)
+	index, confidence, ok := DetectHeaderRow(rows, templateColumns, synonyms)
+	if !ok || confidence < headerRowMinConfidence {
+		return
+	}
+	upload.MatchedHeaderRowIndex = null.IntFrom(int64(index))
+	upload.MatchedHeaderRowConfidence = null.FloatFrom(confidence)
+}
+
+// DetectHeaderRow returns the index of the row most likely to be the header row and the confidence of the match, which
+// is the weighted score of the row. Rows are scored by the fraction of the columns they fill, the fraction of their
+// cells that are text rather than numbers, the fraction of their cells that are unique, and the fraction of their cells
+// that match the names, suggested mappings or synonyms of the template columns. The earliest row wins a tie, as headers
+// come before the data. The last row can't be the header row. Returns false if there are no rows to choose from.
+func DetectHeaderRow(rows []map[int]string, templateColumns []*model.TemplateColumn, synonyms *SynonymDictionary) (int, float64, bool) {
+	if len(rows) < 2 {
+		return 0, 0, false
+	}
+	templateColumns = lo.Filter(templateColumns, func(tc *model.TemplateColumn, _ int) bool {
+		return !tc.IsComputed()
+	})
+	numColumns := 0
+	for _, row := range rows {
+		for columnIndex := range row {
+			if columnIndex+1 > numColumns {
+				numColumns = columnIndex + 1
+			}
+		}
+	}
+
+	bestIndex, bestScore := -1, 0.0
+	for i, row := range rows[:len(rows)-1] {
+		score := headerRowScore(row, numColumns, templateColumns, synonyms)
+		if bestIndex == -1 || score > bestScore {
+			bestIndex, bestScore = i, score
+		}
+	}
+	return bestIndex, bestScore, true
+}
+
+// headerRowScore returns the weighted score of a row as the header row, between 0 and 1
+func headerRowScore(row map[int]string, numColumns int, templateColumns []*model.TemplateColumn, synonyms *SynonymDictionary) float64 {
+	cells := make([]string, 0, len(row))
+	for _, cell := range row {
+		if !util.IsBlankUnicode(cell) {
+			cells = append(cells, strings.ToLower(strings.TrimSpace(cell)))
+		}
+	}
+	if len(cells) == 0 || numColumns == 0 {
+		return 0
+	}
+	fillScore := float64(len(cells)) / float64(numColumns)
+	textScore := float64(lo.CountBy(cells, func(cell string) bool {
+		_, _, err := util.StringToNumberOrNil(cell)
+		return err != nil
+	})) / float64(len(cells))
+	uniqueScore := float64(len(lo.Uniq(cells))) / float64(len(cells))
+
+	if len(templateColumns) == 0 {
+		otherWeights := headerRowFillWeight + headerRowTextWeight + headerRowUniqueWeight
+		return (headerRowFillWeight*fillScore + headerRowTextWeight*textScore + headerRowUniqueWeight*uniqueScore) / otherWeights
+	}
+
+	// The file may have fewer columns than the template or more columns than the template, so the number of matches is
+	// compared to the smaller of the two
+	numMatches := lo.CountBy(templateColumns, func(tc *model.TemplateColumn) bool {
+		return lo.ContainsBy(cells, func(cell string) bool {
+			return nameMatchScore(cell, tc, synonyms, "", 0) > 0
+		})
+	})
+	templateScore := math.Min(1, float64(numMatches)/float64(lo.Min([]int{len(cells), len(templateColumns)})))
+
+	return headerRowFillWeight*fillScore +
+		headerRowTextWeight*textScore +
+		headerRowUniqueWeight*uniqueScore +
+		headerRowTemplateWeight*templateScore
+}
@@ -0,0 +1,130 @@
+package file
+
+import (
+	"tableflow/go/pkg/model"
+	"testing"
+)
+
+func TestDetectHeaderRow(t *testing.T) {
+	synonyms := NewSynonymDictionary(nil)
+	templateColumns := []*model.TemplateColumn{
+		{Name: "First Name", Key: "first_name"},
+		{Name: "Email", Key: "email"},
+		{Name: "Age", Key: "age"},
+	}
+	tests := []struct {
+		name            string
+		rows            []map[int]string
+		templateColumns []*model.TemplateColumn
+		wantIndex       int
+		wantOK          bool
+	}{
+		{
+			name: "first row",
+			rows: []map[int]string{
+				{0: "First Name", 1: "Email", 2: "Age"},
+				{0: "Ada", 1: "ada@example.com", 2: "36"},
+				{0: "Alan", 1: "alan@example.com", 2: "41"},
+			},
+			templateColumns: templateColumns,
+			wantIndex:       0,
+			wantOK:          true,
+		},
+		{
+			name: "after a title and a blank row",
+			rows: []map[int]string{
+				{0: "Customer export"},
+				{},
+				{0: "First Name", 1: "Email", 2: "Age"},
+				{0: "Ada", 1: "ada@example.com", 2: "36"},
+			},
+			templateColumns: templateColumns,
+			wantIndex:       2,
+			wantOK:          true,
+		},
+		{
+			name: "headers matched by the synonyms",
+			rows: []map[int]string{
+				{0: "Kundenliste 2023"},
+				{0: "Vorname", 1: "E-Mail-Adresse", 2: "Alter"},
+				{0: "Ada", 1: "ada@example.com", 2: "36"},
+			},
+			templateColumns: templateColumns,
+			wantIndex:       1,
+			wantOK:          true,
+		},
+		{
+			name: "without template columns",
+			rows: []map[int]string{
+				{0: "Report"},
+				{0: "id", 1: "amount", 2: "currency"},
+				{0: "1", 1: "10.5", 2: "USD"},
+				{0: "2", 1: "12", 2: "USD"},
+			},
+			wantIndex: 1,
+			wantOK:    true,
+		},
+		{
+			name: "earliest row wins a tie",
+			rows: []map[int]string{
+				{0: "a", 1: "b"},
+				{0: "c", 1: "d"},
+				{0: "e", 1: "f"},
+			},
+			wantIndex: 0,
+			wantOK:    true,
+		},
+		{
+			name: "last row can't be the header row",
+			rows: []map[int]string{
+				{0: "1", 1: "2"},
+				{0: "First Name", 1: "Email"},
+			},
+			templateColumns: templateColumns,
+			wantIndex:       0,
+			wantOK:          true,
+		},
+		{
+			name:            "single row",
+			rows:            []map[int]string{{0: "First Name", 1: "Email"}},
+			templateColumns: templateColumns,
+			wantOK:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, confidence, ok := DetectHeaderRow(tt.rows, tt.templateColumns, synonyms)
+			if ok != tt.wantOK {
+				t.Fatalf("DetectHeaderRow ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if index != tt.wantIndex {
+				t.Errorf("DetectHeaderRow index = %d, want %d", index, tt.wantIndex)
+			}
+			if confidence < 0 || confidence > 1 {
+				t.Errorf("DetectHeaderRow confidence = %v, want a value between 0 and 1", confidence)
+			}
+		})
+	}
+}
+
+func TestDetectHeaderRowConfidence(t *testing.T) {
+	templateColumns := []*model.TemplateColumn{{Name: "First Name", Key: "first_name"}, {Name: "Email", Key: "email"}}
+	rows := []map[int]string{
+		{0: "First Name", 1: "Email"},
+		{0: "Ada", 1: "ada@example.com"},
+	}
+	_, confidence, _ := DetectHeaderRow(rows, templateColumns, nil)
+	if confidence < headerRowMinConfidence {
+		t.Errorf("confidence of a header row matching every template column = %v, want at least %v", confidence, headerRowMinConfidence)
+	}
+	unrelated := []map[int]string{
+		{0: "1", 1: "1"},
+		{0: "2", 1: "2"},
+	}
+	if _, confidence, _ = DetectHeaderRow(unrelated, templateColumns, nil); confidence >= headerRowMinConfidence {
+		t.Errorf("confidence of rows of numbers = %v, want less than %v", confidence, headerRowMinConfidence)
+	}
+}
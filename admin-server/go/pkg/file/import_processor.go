@@ -5,10 +5,14 @@ import (
 	"github.com/guregu/null"
 	"github.com/samber/lo"
 	"sync"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/evaluator"
 	"tableflow/go/pkg/model"
 	"tableflow/go/pkg/model/jsonb"
 	"tableflow/go/pkg/scylla"
 	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/types"
+	"tableflow/go/pkg/util"
 	"time"
 )
 
@@ -18,11 +22,109 @@ type ImportProcessResult struct {
 	NumProcessedValues int
 	NumErrorRows       int
 	NumValidRows       int
+	NumWarningRows     int
+	NumInfoRows        int
 }
 
 type templateColumnKeyValidation struct {
-	Key         string
-	Validations []model.Validation
+	Key           string
+	CellValue     func(uploadRow *uploadRowCells) string // Reads the cell from the upload row, which can be split or merged
+	Transforms    model.TemplateColumnTransforms
+	DefaultValue  func() string         // Set on blank cells, or nil if the template column has no default
+	Computed      *model.ComputedColumn // Set if the cell is computed from the other cells in the row instead of being read
+	ListDelimiter string                // The delimiter the cell is split into elements with, or blank if it isn't a list
+	Validations   []model.Validation
+}
+
+// uploadRowCells are the cells of an upload row by upload column index, along with the parts of the split cells. Each
+// split is performed once per row, rather than once for each template column a part is mapped to.
+type uploadRowCells struct {
+	Cells      map[int]string
+	splitParts map[int][]string // split index -> parts
+}
+
+func newUploadRowCells(cells map[int]string) *uploadRowCells {
+	return &uploadRowCells{Cells: cells, splitParts: make(map[int][]string)}
+}
+
+// splitCell returns the parts of the cell at the upload column index, splitting the cell the first time
+func (r *uploadRowCells) splitCell(splitIndex int, split *model.UploadColumnSplit, index int) []string {
+	parts, ok := r.splitParts[splitIndex]
+	if !ok {
+		parts = split.Split(r.Cells[index])
+		r.splitParts[splitIndex] = parts
+	}
+	return parts
+}
+
+// values returns the cell of the upload row before and after the transforms and default value are applied, the default
+// value is treated as the cell in the file as it isn't a change to a value the user provided
+func (k templateColumnKeyValidation) values(uploadRow *uploadRowCells) (string, string) {
+	rawCellValue := k.CellValue(uploadRow)
+	cellValue := k.Transforms.Apply(rawCellValue)
+	if k.DefaultValue != nil && util.IsBlankUnicode(cellValue) {
+		cellValue = k.DefaultValue()
+		rawCellValue = cellValue
+	}
+	return rawCellValue, cellValue
+}
+
+// cellValues is a cell of an upload row before (Raw) and after (Value) the transforms and default value are applied
+type cellValues struct {
+	Raw   string
+	Value string
+}
+
+// pageCellValues returns the cells of each upload row of a page by column key, before and after the transforms and
+// default values are applied. The values are computed once, as default values can be generated (i.e. a uuid), so the
+// batch validations are prefetched with the same values as the cells are evaluated with.
+func pageCellValues(columnKeys []templateColumnKeyValidation, uploadRows []map[int]string) []map[string]cellValues {
+	pageValues := make([]map[string]cellValues, len(uploadRows))
+	for i, uploadRow := range uploadRows {
+		rowCells := newUploadRowCells(uploadRow)
+		pageValues[i] = make(map[string]cellValues, len(columnKeys))
+		for _, key := range columnKeys {
+			if key.Computed != nil {
+				continue
+			}
+			rawCellValue, cellValue := key.values(rowCells)
+			pageValues[i][key.Key] = cellValues{Raw: rawCellValue, Value: cellValue}
+		}
+	}
+	return pageValues
+}
+
+// importRowFailures holds the IDs of the validations which did not pass on a row by cell key. Only validations with
+// the error severity block the import from being submitted, the warn and info severities are stored separately. The
+// details of the failures (i.e. the suggested values) are stored with them.
+type importRowFailures struct {
+	Errors     map[string][]uint
+	Warnings   map[string][]uint
+	Details    scylla.ImportRowErrorDetails
+	HasWarning bool
+	HasInfo    bool
+}
+
+func newImportRowFailures() *importRowFailures {
+	return &importRowFailures{
+		Errors:   make(map[string][]uint),
+		Warnings: make(map[string][]uint),
+		Details:  make(scylla.ImportRowErrorDetails),
+	}
+}
+
+func (f *importRowFailures) add(key string, v model.Validation, cell, listDelimiter string, failedElements []int) {
+	f.Details.Add(key, v.ID, scylla.NewImportRowErrorDetail(v, cell, listDelimiter, failedElements))
+	switch v.Severity {
+	case model.ValidationSeverityWarn:
+		f.Warnings[key] = append(f.Warnings[key], v.ID)
+		f.HasWarning = true
+	case model.ValidationSeverityInfo:
+		f.Warnings[key] = append(f.Warnings[key], v.ID)
+		f.HasInfo = true
+	default:
+		f.Errors[key] = append(f.Errors[key], v.ID)
+	}
 }
 
 func ImportData(upload *model.Upload, template *model.Template) {
@@ -38,6 +140,8 @@ func ImportData(upload *model.Upload, template *model.Template) {
 		dataTypes[tc.Key] = string(tc.DataType)
 	}
 	imp.DataTypes = jsonb.FromMap(dataTypes)
+	imp.ColumnFormats = jsonb.FromMap(generateColumnFormats(template))
+	imp.ListDelimiters = jsonb.FromMap(generateListDelimiters(template))
 
 	err := tf.DB.Create(imp).Error
 	if err != nil {
@@ -60,6 +164,8 @@ func ImportData(upload *model.Upload, template *model.Template) {
 	imp.NumProcessedValues = null.IntFrom(int64(importResult.NumProcessedValues))
 	imp.NumErrorRows = null.IntFrom(int64(importResult.NumErrorRows))
 	imp.NumValidRows = null.IntFrom(int64(importResult.NumValidRows))
+	imp.NumWarningRows = null.IntFrom(int64(importResult.NumWarningRows))
+	imp.NumInfoRows = null.IntFrom(int64(importResult.NumInfoRows))
 
 	err = tf.DB.Save(imp).Error
 	if err != nil {
@@ -69,14 +175,24 @@ func ImportData(upload *model.Upload, template *model.Template) {
 }
 
 func processAndStoreImport(template *model.Template, upload *model.Upload, imp *model.Import) (ImportProcessResult, error) {
-	columnKeyMap := generateColumnKeyMap(template, upload)
-	numColumns := len(columnKeyMap)
+	columnKeyMap := generateColumnKeyMap(template, upload, imp)
+	computedKeys, err := generateComputedColumnKeys(template)
+	if err != nil {
+		return ImportProcessResult{}, err
+	}
+	for _, key := range computedKeys {
+		columnKeyMap[key.Key] = key
+	}
+	columnKeys := orderColumnKeys(template, columnKeyMap)
+	numColumns := len(columnKeys)
 	importID := imp.ID.String()
 
 	importRowIndex := 0
 	numProcessedValues := 0
 	numValidRows := 0
 	numErrorRows := 0
+	numWarningRows := 0
+	numInfoRows := 0
 
 	goroutines := 8
 	batchCounter := 0
@@ -93,56 +209,65 @@ func processAndStoreImport(template *model.Template, upload *model.Upload, imp *
 
 	paginationPageSize := 1000
 
-	// Start paginating through the upload rows after the header row
-	for offset := int(upload.HeaderRowIndex.Int64) + 1; ; offset += paginationPageSize {
+	// Start paginating through the upload rows after the header rows
+	for offset := upload.DataRowIndex(); ; offset += paginationPageSize {
 		if offset > int(upload.NumRows.Int64) {
 			in <- b
 			break
 		}
 		uploadRows := scylla.PaginateUploadRows(upload.ID.String(), offset, paginationPageSize)
+		pageValues := pageCellValues(columnKeys, uploadRows)
+		prefetchBatchValidations(columnKeys, pageValues)
 
 		// Iterate over the upload rows in pages returned from Scylla
 		for pageRowIndex := 0; pageRowIndex < len(uploadRows); pageRowIndex++ {
 			approxMutationSize := 0
 
-			// uploadRow example:
-			// {0: 'Mary', 1: 'Jenkins', 2: 'mary@example.com', 3: '02/22/2020', 4: ''}
-			uploadRow := uploadRows[pageRowIndex]
+			// rowValues example (the cells before and after the transforms and default values, by key):
+			// {'first_name': {'Mary', 'Mary'}, 'email': {' Mary@Example.com', 'mary@example.com'}}
+			rowValues := pageValues[pageRowIndex]
 
 			// importRowValues example:
 			// {'first_name': 'Mary', 'last_name': 'Jenkins', 'email': 'mary@example.com'}
 			importRowValues := make(map[string]string, numColumns)
 
-			// importRowErrors example (the numbers are the validation ID(s) which did not pass):
+			// importRowRawValues example (the values in the file of the cells changed by transforms or validations):
+			// {'email': ' Mary@Example.com'}
+			importRowRawValues := make(map[string]string)
+
+			// importRowFailures.Errors example (the numbers are the validation ID(s) which did not pass):
 			// {'first_name': {4281}, 'email': {4281, 4295}}
-			importRowErrors := make(map[string][]uint)
+			importRowFailures := newImportRowFailures()
 
-			// Iterate over columnKeyMap, the columns that have mappings set (also included any validations)
+			// Iterate over columnKeys, the columns that have mappings set (also included any validations) in the order of
+			// the template columns
 			// Rows ending in blank values may not exist in the uploadRow (i.e. excel), but we still want to set empty
 			// values for those cells as they are logically empty in the source file
 			//
-			// columnKeyMap example:
-			// {0: 'first_name', 1: 'last_name', 2: 'email'}
+			// columnKeys example:
+			// [{'first_name': 0}, {'last_name': 1}, {'email': 2}]
 
-			for uploadColumnIndex, key := range columnKeyMap {
+			for _, key := range columnKeys {
+				if key.Computed != nil {
+					// Computed cells are set below once all the cells they may reference are set
+					continue
+				}
 
-				// uploadColumnIndex = 0
+				// key = first_name + upload column index 0 + validations
 				// cellValue         = Mary
-				// key = first_name + validations
 				// importRowValue    = {'first_name': 'Mary'}
 
-				cellValue := uploadRow[uploadColumnIndex]
-
-				// Perform validations on the cell, if any
+				// The cell with the transforms applied to clean it up and the default value if it's blank, then perform
+				// validations on the cell, if any
+				rawCellValue, cellValue := rowValues[key.Key].Raw, rowValues[key.Key].Value
 				for _, v := range key.Validations {
-					passed, value := v.Evaluate(cellValue)
+					if v.IsRowValidation() {
+						// Row validations are performed once all the cells in the row are set
+						continue
+					}
+					passed, value, failedElements := v.EvaluateCell(cellValue, key.ListDelimiter)
 					if !passed {
-						// Add the validation ID to the slice at the key, or create a new entry if the key doesn't exist
-						if _, ok := importRowErrors[key.Key]; ok {
-							importRowErrors[key.Key] = append(importRowErrors[key.Key], v.ID)
-						} else {
-							importRowErrors[key.Key] = []uint{v.ID}
-						}
+						importRowFailures.add(key.Key, v, cellValue, key.ListDelimiter, failedElements)
 					} else {
 						cellValue = value
 					}
@@ -151,18 +276,34 @@ func processAndStoreImport(template *model.Template, upload *model.Upload, imp *
 				// Add the cell value and update progress
 				importRowValues[key.Key] = cellValue
 				approxMutationSize += len(cellValue)
+				if cellValue != rawCellValue {
+					importRowRawValues[key.Key] = rawCellValue
+					approxMutationSize += len(rawCellValue)
+				}
 				numProcessedValues++
 			}
 
+			// Compute the cells derived from the other cells in the row, then perform the validations on them, if any
+			numProcessedValues += evaluateComputedColumns(computedKeys, importRowValues, importRowFailures)
+
+			// Perform any validations that reference other cells in the row, now that all the cell values are set
+			evaluateRowValidations(columnKeys, importRowValues, importRowFailures)
+
 			batchCounter++
 			batchSize += approxMutationSize
 
-			if len(importRowErrors) == 0 {
+			if importRowFailures.HasWarning {
+				numWarningRows++
+			}
+			if importRowFailures.HasInfo {
+				numInfoRows++
+			}
+			if len(importRowFailures.Errors) == 0 {
 				numValidRows++
-				b.Query("insert into import_rows (import_id, row_index, values) values (?, ?, ?)", importID, importRowIndex, importRowValues)
+				b.Query("insert into import_rows (import_id, row_index, values, raw_values, warnings, error_details) values (?, ?, ?, ?, ?, ?)", importID, importRowIndex, importRowValues, importRowRawValues, importRowFailures.Warnings, importRowFailures.Details)
 			} else {
 				numErrorRows++
-				b.Query("insert into import_row_errors (import_id, row_index, values, errors) values (?, ?, ?, ?)", importID, importRowIndex, importRowValues, importRowErrors)
+				b.Query("insert into import_row_errors (import_id, row_index, values, raw_values, errors, warnings, error_details) values (?, ?, ?, ?, ?, ?, ?)", importID, importRowIndex, importRowValues, importRowRawValues, importRowFailures.Errors, importRowFailures.Warnings, importRowFailures.Details)
 			}
 
 			batchSizeApproachingLimit := batchSize > int(float64(maxMutationSize)*safetyMargin)
@@ -189,32 +330,260 @@ func processAndStoreImport(template *model.Template, upload *model.Upload, imp *
 		NumProcessedValues: numProcessedValues,
 		NumErrorRows:       numErrorRows,
 		NumValidRows:       numValidRows,
+		NumWarningRows:     numWarningRows,
+		NumInfoRows:        numInfoRows,
 	}, nil
 }
 
+// evaluateComputedColumns sets the computed cells of the row in the order of the computed column keys, so a formula can
+// reference a computed cell before it. If a formula can't be evaluated (i.e. a referenced cell isn't a number) the cell
+// is left blank. Returns the number of cells computed.
+func evaluateComputedColumns(computedKeys []templateColumnKeyValidation, importRowValues map[string]string, importRowFailures *importRowFailures) int {
+	for _, key := range computedKeys {
+		cellValue, err := key.Computed.Compute(importRowValues)
+		if err != nil {
+			cellValue = ""
+		}
+		for _, v := range key.Validations {
+			if v.IsRowValidation() {
+				continue
+			}
+			passed, value, failedElements := v.EvaluateCell(cellValue, key.ListDelimiter)
+			if !passed {
+				importRowFailures.add(key.Key, v, cellValue, key.ListDelimiter, failedElements)
+			} else {
+				cellValue = value
+			}
+		}
+		importRowValues[key.Key] = cellValue
+	}
+	return len(computedKeys)
+}
+
+// evaluateRowValidations runs the validations that reference other cells in the row (i.e. cross-column rules) against
+// the complete row values. The IDs of any validations that did not pass are added to the failures of the cell key the
+// validation is attached to. The keys are evaluated in order, as a validation that passes can normalize the value of
+// its cell, which the row validations of the keys after it reference.
+func evaluateRowValidations(columnKeys []templateColumnKeyValidation, importRowValues map[string]string, importRowFailures *importRowFailures) {
+	for _, key := range columnKeys {
+		for _, v := range key.Validations {
+			if !v.IsRowValidation() {
+				continue
+			}
+			passed, value, failedElements := v.EvaluateRowCell(importRowValues[key.Key], key.ListDelimiter, importRowValues)
+			if !passed {
+				importRowFailures.add(key.Key, v, importRowValues[key.Key], key.ListDelimiter, failedElements)
+			} else {
+				importRowValues[key.Key] = value
+			}
+		}
+	}
+}
+
+// prefetchBatchValidations evaluates the cells of a page of upload rows in batches for the validations that support it
+// (i.e. remote validations), so each cell doesn't need a separate request. The cells have the column transforms and
+// default values applied, and the validations before a batch validation are applied first, so the batch receives the
+// same values as when the cells are evaluated one at a time.
+func prefetchBatchValidations(columnKeys []templateColumnKeyValidation, pageValues []map[string]cellValues) {
+	for _, key := range columnKeys {
+		if key.Computed != nil {
+			continue
+		}
+		_, lastBatchIndex, found := lo.FindLastIndexOf(key.Validations, func(v model.Validation) bool {
+			return v.IsBatchValidation()
+		})
+		if !found {
+			continue
+		}
+		// The elements of list cells are evaluated separately, so they're prefetched separately
+		cells := lo.FlatMap(pageValues, func(rowValues map[string]cellValues, _ int) []string {
+			cellValue := rowValues[key.Key].Value
+			if len(key.ListDelimiter) != 0 {
+				return model.SplitListCell(cellValue, key.ListDelimiter)
+			}
+			return []string{cellValue}
+		})
+		for validationIndex, v := range key.Validations[:lastBatchIndex+1] {
+			if v.IsRowValidation() {
+				continue
+			}
+			if v.IsBatchValidation() {
+				v.Prefetch(cells)
+			}
+			if validationIndex == lastBatchIndex {
+				break
+			}
+			for i, cell := range cells {
+				if passed, value := v.Evaluate(cell); passed {
+					cells[i] = value
+				}
+			}
+		}
+	}
+}
+
+// GetImportTemplate retrieves the template used to process the import of an upload. This is the template set on the
+// upload if one exists (SDK-defined or generated from a schemaless import), otherwise the template of the importer.
+func GetImportTemplate(upload *model.Upload) (*model.Template, error) {
+	var template *model.Template
+	var err error
+	if upload.Template.Valid {
+		var importServiceTemplate *types.Template
+		if importServiceTemplate, err = types.ConvertRawTemplate(upload.Template, false, nil, false); err != nil {
+			return nil, err
+		}
+		template = types.ConvertTemplateToModel(importServiceTemplate, upload.WorkspaceID)
+	} else if template, err = db.GetTemplateByImporter(upload.ImporterID.String()); err != nil {
+		return nil, err
+	}
+	for _, tc := range template.TemplateColumns {
+		model.SortValidations(tc.Validations)
+	}
+	model.SetDateParsers(template.TemplateColumns)
+	return template, nil
+}
+
+// generateColumnFormats returns the formats the values of each column key were parsed with, which are needed to convert
+// the stored values to their data type
+func generateColumnFormats(template *model.Template) map[string]interface{} {
+	columnFormats := make(map[string]interface{})
+	for _, tc := range template.TemplateColumns {
+		for _, v := range tc.Validations {
+			// The number, integer and decimal evaluators
+			if numberEvaluator, ok := v.Evaluator.(interface{ NumberFormat() util.NumberFormat }); ok && evaluator.IsDataTypeEvaluator(v.Validate) {
+				columnFormats[tc.Key] = numberEvaluator.NumberFormat()
+			}
+		}
+	}
+	return columnFormats
+}
+
+// generateListDelimiters returns the delimiter of each list column key, which is needed to split the stored values into
+// their elements
+func generateListDelimiters(template *model.Template) map[string]interface{} {
+	listDelimiters := make(map[string]interface{})
+	for _, tc := range template.TemplateColumns {
+		if tc.IsList {
+			listDelimiters[tc.Key] = tc.ListDelimiter()
+		}
+	}
+	return listDelimiters
+}
+
 // generateColumnKeyMap
-// For the columns that a user set a mapping for, create a map of the upload column indexes to the template column key
+// For the columns that a user set a mapping for, create a map of the template column key to how the cell is read from
+// the upload row: from one upload column, a part of a split upload column, or several merged upload columns
+// Template columns with a default value are included even if they aren't mapped, so the default is set on every row
 // This is used to store the import data in Scylla by the template column key
-func generateColumnKeyMap(template *model.Template, upload *model.Upload) map[int]templateColumnKeyValidation {
+func generateColumnKeyMap(template *model.Template, upload *model.Upload, imp *model.Import) map[string]templateColumnKeyValidation {
+	importedAt := lo.Ternary(imp.CreatedAt.Valid, imp.CreatedAt.Time, time.Now())
 
 	// templateRowMap == template column ID -> template column key + validations
 	templateRowMap := make(map[string]templateColumnKeyValidation)
 	for _, tc := range template.TemplateColumns {
-		templateRowMap[tc.ID.String()] = templateColumnKeyValidation{
-			Key:         tc.Key,
-			Validations: lo.Map(tc.Validations, func(v *model.Validation, _ int) model.Validation { return *v }),
+		if tc.IsComputed() {
+			continue
 		}
+		key := templateColumnKeyValidation{
+			Key:           tc.Key,
+			Transforms:    tc.Transforms,
+			ListDelimiter: tc.ListDelimiter(),
+			Validations:   lo.Map(tc.CellValidations(), func(v *model.Validation, _ int) model.Validation { return *v }),
+		}
+		if defaultValue := tc.DefaultValue; defaultValue != nil {
+			key.DefaultValue = func() string {
+				return defaultValue.Generate(imp.Metadata, importedAt)
+			}
+		}
+		templateRowMap[tc.ID.String()] = key
 	}
 
-	// columnKeyMap == upload column index -> template column key + validations
-	columnKeyMap := make(map[int]templateColumnKeyValidation)
+	// columnKeyMap == template column key -> upload column(s) + validations
+	columnKeyMap := make(map[string]templateColumnKeyValidation)
+	uploadColumnIndexes := make(map[string]int, len(upload.UploadColumns))
 	for _, uc := range upload.UploadColumns {
+		uploadColumnIndexes[uc.ID.String()] = uc.Index
 		if !uc.TemplateColumnID.Valid {
 			continue
 		}
 		if key, ok := templateRowMap[uc.TemplateColumnID.String()]; ok {
-			columnKeyMap[uc.Index] = key
+			index := uc.Index
+			key.CellValue = func(uploadRow *uploadRowCells) string {
+				return uploadRow.Cells[index]
+			}
+			columnKeyMap[key.Key] = key
+		}
+	}
+
+	for splitIndex, split := range upload.ColumnMappings.Splits {
+		index, ok := uploadColumnIndexes[split.UploadColumnID]
+		if err := split.Compile(); !ok || err != nil {
+			tf.Log.Warnw("Skipping invalid column split", "upload_id", upload.ID, "upload_column_id", split.UploadColumnID, "error", err)
+			continue
+		}
+		for part, tcID := range split.TemplateColumnIDs {
+			key, ok := templateRowMap[tcID]
+			if !ok {
+				continue
+			}
+			splitIndex, split, part := splitIndex, split, part
+			key.CellValue = func(uploadRow *uploadRowCells) string {
+				return uploadRow.splitCell(splitIndex, split, index)[part]
+			}
+			columnKeyMap[key.Key] = key
+		}
+	}
+
+	for _, merge := range upload.ColumnMappings.Merges {
+		key, ok := templateRowMap[merge.TemplateColumnID]
+		if !ok {
+			continue
+		}
+		indexes := lo.FilterMap(merge.UploadColumnIDs, func(ucID string, _ int) (int, bool) {
+			index, ok := uploadColumnIndexes[ucID]
+			return index, ok
+		})
+		merge := merge
+		key.CellValue = func(uploadRow *uploadRowCells) string {
+			return merge.Merge(lo.Map(indexes, func(index int, _ int) string { return uploadRow.Cells[index] }))
+		}
+		columnKeyMap[key.Key] = key
+	}
+
+	// Unmapped template columns with a default value are constant columns
+	for _, key := range templateRowMap {
+		if _, mapped := columnKeyMap[key.Key]; mapped || key.DefaultValue == nil {
+			continue
+		}
+		key.CellValue = func(*uploadRowCells) string {
+			return ""
 		}
+		columnKeyMap[key.Key] = key
 	}
 	return columnKeyMap
 }
+
+// orderColumnKeys returns the keys of columnKeyMap in the order of the template columns, so the cells of every row are
+// evaluated in the same order rather than the random iteration order of the map
+func orderColumnKeys(template *model.Template, columnKeyMap map[string]templateColumnKeyValidation) []templateColumnKeyValidation {
+	return lo.FilterMap(template.TemplateColumns, func(tc *model.TemplateColumn, _ int) (templateColumnKeyValidation, bool) {
+		key, ok := columnKeyMap[tc.Key]
+		return key, ok
+	})
+}
+
+// generateComputedColumnKeys returns the computed template columns in the order they must be computed
+func generateComputedColumnKeys(template *model.Template) ([]templateColumnKeyValidation, error) {
+	computedColumns, err := model.ParseComputedColumns(template.TemplateColumns)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(computedColumns, func(cc model.ComputedColumn, _ int) templateColumnKeyValidation {
+		return templateColumnKeyValidation{
+			Key:           cc.TemplateColumn.Key,
+			Computed:      &cc,
+			ListDelimiter: cc.TemplateColumn.ListDelimiter(),
+			Validations:   lo.Map(cc.TemplateColumn.CellValidations(), func(v *model.Validation, _ int) model.Validation { return *v }),
+		}
+	}), nil
+}
@@ -0,0 +1,310 @@
+package file
+
+import (
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+	"reflect"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+	"time"
+)
+
+func mustParseValidation(t *testing.T, validate string, options interface{}, dataType model.TemplateColumnDataType) model.Validation {
+	t.Helper()
+	optionsJSON, err := jsonb.FromInterface(options)
+	if err != nil {
+		t.Fatalf("Invalid options %v: %v", options, err)
+	}
+	v, err := model.ParseValidation(0, "", validate, optionsJSON, "", string(model.ValidationSeverityError), dataType)
+	if err != nil {
+		t.Fatalf("ParseValidation(%s) returned error: %v", validate, err)
+	}
+	return *v
+}
+
+func TestOrderColumnKeys(t *testing.T) {
+	template := &model.Template{
+		TemplateColumns: []*model.TemplateColumn{{Key: "first_name"}, {Key: "last_name"}, {Key: "email"}, {Key: "full_name"}},
+	}
+	columnKeyMap := map[string]templateColumnKeyValidation{
+		"full_name":  {Key: "full_name"},
+		"email":      {Key: "email"},
+		"first_name": {Key: "first_name"},
+	}
+	keys := orderColumnKeys(template, columnKeyMap)
+	got := make([]string, 0, len(keys))
+	for _, key := range keys {
+		got = append(got, key.Key)
+	}
+	want := []string{"first_name", "email", "full_name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orderColumnKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluateRowValidations(t *testing.T) {
+	// The start date is normalized by its row validation before the end date is compared with it as a string
+	columnKeys := []templateColumnKeyValidation{
+		{
+			Key: "start_date",
+			Validations: []model.Validation{mustParseValidation(t, "conditional", map[string]interface{}{
+				"when":     map[string]interface{}{"key": "status", "value": "closed"},
+				"validate": "date",
+				"options":  map[string]interface{}{"output": "date"},
+			}, model.TemplateColumnDataTypeDate)},
+		},
+		{
+			Key: "end_date",
+			Validations: []model.Validation{
+				mustParseValidation(t, "expression", "value > start_date", model.TemplateColumnDataTypeString),
+			},
+		},
+		{Key: "status"},
+	}
+	importRowValues := map[string]string{"start_date": "March 15, 2024", "end_date": "2024-04-03", "status": "closed"}
+	importRowFailures := newImportRowFailures()
+	evaluateRowValidations(columnKeys, importRowValues, importRowFailures)
+	if len(importRowFailures.Errors) != 0 {
+		t.Errorf("evaluateRowValidations() errors = %v, want none", importRowFailures.Errors)
+	}
+	if importRowValues["start_date"] != "2024-03-15" {
+		t.Errorf("evaluateRowValidations() start_date = %q, want %q", importRowValues["start_date"], "2024-03-15")
+	}
+}
+
+func TestGenerateColumnKeyMapSplitsAndMerges(t *testing.T) {
+	firstName := &model.TemplateColumn{ID: model.NewID(), Key: "first_name"}
+	lastName := &model.TemplateColumn{ID: model.NewID(), Key: "last_name"}
+	address := &model.TemplateColumn{ID: model.NewID(), Key: "address"}
+	email := &model.TemplateColumn{ID: model.NewID(), Key: "email"}
+	template := &model.Template{TemplateColumns: []*model.TemplateColumn{firstName, lastName, address, email}}
+	fullNameColumn := &model.UploadColumn{ID: model.NewID(), Index: 0}
+	line1Column := &model.UploadColumn{ID: model.NewID(), Index: 1}
+	line2Column := &model.UploadColumn{ID: model.NewID(), Index: 2}
+	emailColumn := &model.UploadColumn{ID: model.NewID(), Index: 3, TemplateColumnID: email.ID}
+	upload := &model.Upload{
+		UploadColumns: []*model.UploadColumn{fullNameColumn, line1Column, line2Column, emailColumn},
+		ColumnMappings: model.UploadColumnMappings{
+			Splits: []*model.UploadColumnSplit{{
+				UploadColumnID:    fullNameColumn.ID.String(),
+				Delimiter:         " ",
+				TemplateColumnIDs: []string{firstName.ID.String(), lastName.ID.String()},
+			}},
+			Merges: []*model.UploadColumnMerge{{
+				UploadColumnIDs:  []string{line1Column.ID.String(), line2Column.ID.String()},
+				Separator:        ", ",
+				TemplateColumnID: address.ID.String(),
+			}},
+		},
+	}
+	columnKeyMap := generateColumnKeyMap(template, upload, &model.Import{})
+
+	row := newUploadRowCells(map[int]string{0: "Mary Ann Jenkins", 1: "1 Main St", 2: "Apt 2", 3: "mary@example.com"})
+	want := map[string]string{
+		"first_name": "Mary",
+		"last_name":  "Ann Jenkins",
+		"address":    "1 Main St, Apt 2",
+		"email":      "mary@example.com",
+	}
+	got := make(map[string]string, len(columnKeyMap))
+	for key, k := range columnKeyMap {
+		got[key] = k.CellValue(row)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CellValue() = %v, want %v", got, want)
+	}
+	// The cell is split once for both template columns
+	if len(row.splitParts) != 1 {
+		t.Errorf("splitParts = %v, want the parts of one split", row.splitParts)
+	}
+}
+
+func TestImportRowFailuresAdd(t *testing.T) {
+	validation := func(id uint, severity model.ValidationSeverity) model.Validation {
+		v := mustParseValidation(t, "not_blank", nil, model.TemplateColumnDataTypeString)
+		v.ID = id
+		v.Severity = severity
+		return v
+	}
+	tests := []struct {
+		name           string
+		validations    []model.Validation
+		wantErrors     map[string][]uint
+		wantWarnings   map[string][]uint
+		wantHasWarning bool
+		wantHasInfo    bool
+	}{
+		{
+			name:         "error",
+			validations:  []model.Validation{validation(1, model.ValidationSeverityError)},
+			wantErrors:   map[string][]uint{"email": {1}},
+			wantWarnings: map[string][]uint{},
+		},
+		{
+			name:           "warn",
+			validations:    []model.Validation{validation(2, model.ValidationSeverityWarn)},
+			wantErrors:     map[string][]uint{},
+			wantWarnings:   map[string][]uint{"email": {2}},
+			wantHasWarning: true,
+		},
+		{
+			name:         "info",
+			validations:  []model.Validation{validation(3, model.ValidationSeverityInfo)},
+			wantErrors:   map[string][]uint{},
+			wantWarnings: map[string][]uint{"email": {3}},
+			wantHasInfo:  true,
+		},
+		{
+			name: "all severities",
+			validations: []model.Validation{
+				validation(1, model.ValidationSeverityError),
+				validation(2, model.ValidationSeverityWarn),
+				validation(3, model.ValidationSeverityInfo),
+			},
+			wantErrors:     map[string][]uint{"email": {1}},
+			wantWarnings:   map[string][]uint{"email": {2, 3}},
+			wantHasWarning: true,
+			wantHasInfo:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newImportRowFailures()
+			for _, v := range tt.validations {
+				f.add("email", v, "", "", nil)
+			}
+			if !reflect.DeepEqual(f.Errors, tt.wantErrors) || !reflect.DeepEqual(f.Warnings, tt.wantWarnings) {
+				t.Errorf("add() errors = %v, warnings = %v, want %v, %v", f.Errors, f.Warnings, tt.wantErrors, tt.wantWarnings)
+			}
+			if f.HasWarning != tt.wantHasWarning || f.HasInfo != tt.wantHasInfo {
+				t.Errorf("add() HasWarning = %v, HasInfo = %v, want %v, %v", f.HasWarning, f.HasInfo, tt.wantHasWarning, tt.wantHasInfo)
+			}
+		})
+	}
+}
+
+func TestGenerateColumnKeyMapDefaults(t *testing.T) {
+	trim, err := model.ParseTemplateColumnTransform("trim", jsonb.NewNull())
+	if err != nil {
+		t.Fatalf("ParseTemplateColumnTransform() returned error: %v", err)
+	}
+	status := &model.TemplateColumn{
+		ID:           model.NewID(),
+		Key:          "status",
+		Transforms:   model.TemplateColumnTransforms{trim},
+		DefaultValue: &model.TemplateColumnDefault{Type: model.TemplateColumnDefaultTypeValue, Literal: "Active"},
+	}
+	tenant := &model.TemplateColumn{
+		ID:           model.NewID(),
+		Key:          "tenant_id",
+		DefaultValue: &model.TemplateColumnDefault{Type: model.TemplateColumnDefaultTypeMetadata, Key: "tenant.id"},
+	}
+	importedAt := &model.TemplateColumn{
+		ID:           model.NewID(),
+		Key:          "imported_at",
+		DefaultValue: &model.TemplateColumnDefault{Type: model.TemplateColumnDefaultTypeTimestamp},
+	}
+	notes := &model.TemplateColumn{ID: model.NewID(), Key: "notes"}
+	template := &model.Template{TemplateColumns: []*model.TemplateColumn{status, tenant, importedAt, notes}}
+	upload := &model.Upload{
+		UploadColumns: []*model.UploadColumn{{ID: model.NewID(), Index: 0, TemplateColumnID: status.ID}},
+	}
+	imp := &model.Import{
+		Metadata:  jsonb.FromMap(map[string]interface{}{"tenant": map[string]interface{}{"id": "t-1"}}),
+		CreatedAt: model.NullTime{Time: time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC), Valid: true},
+	}
+	columnKeyMap := generateColumnKeyMap(template, upload, imp)
+
+	// Unmapped template columns without a default are left out, the others are constant columns
+	if _, ok := columnKeyMap["notes"]; ok {
+		t.Errorf("generateColumnKeyMap() contains the unmapped column notes without a default")
+	}
+	tests := []struct {
+		key       string
+		cell      string
+		wantRaw   string
+		wantValue string
+	}{
+		{key: "status", cell: "Inactive", wantRaw: "Inactive", wantValue: "Inactive"},
+		{key: "status", cell: "", wantRaw: "Active", wantValue: "Active"},
+		{key: "status", cell: "   ", wantRaw: "Active", wantValue: "Active"},
+		{key: "tenant_id", cell: "ignored", wantRaw: "t-1", wantValue: "t-1"},
+		{key: "imported_at", wantRaw: "2024-03-15T09:30:00Z", wantValue: "2024-03-15T09:30:00Z"},
+	}
+	for _, tt := range tests {
+		key, ok := columnKeyMap[tt.key]
+		if !ok {
+			t.Errorf("generateColumnKeyMap() is missing the key %s", tt.key)
+			continue
+		}
+		raw, value := key.values(newUploadRowCells(map[int]string{0: tt.cell}))
+		if raw != tt.wantRaw || value != tt.wantValue {
+			t.Errorf("%s values(%q) = %q, %q, want %q, %q", tt.key, tt.cell, raw, value, tt.wantRaw, tt.wantValue)
+		}
+	}
+
+	keys := orderColumnKeys(template, columnKeyMap)
+	got := lo.Map(keys, func(k templateColumnKeyValidation, _ int) string { return k.Key })
+	if want := []string{"status", "tenant_id", "imported_at"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("orderColumnKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluateComputedColumns(t *testing.T) {
+	validation := mustParseValidation(t, "not_blank", nil, model.TemplateColumnDataTypeString)
+	validation.ID = 1
+	total := &model.TemplateColumn{Key: "total", Formula: null.StringFrom("qty * price")}
+	label := &model.TemplateColumn{
+		Key:         "label",
+		Formula:     null.StringFrom("name + ': ' + total"),
+		Validations: []*model.Validation{&validation},
+	}
+	summary := &model.TemplateColumn{
+		Key:         "summary",
+		Formula:     null.StringFrom("qty > 5 ? name : ''"),
+		Validations: []*model.Validation{&validation},
+	}
+	template := &model.Template{
+		TemplateColumns: []*model.TemplateColumn{{Key: "name"}, {Key: "qty"}, {Key: "price"}, label, summary, total},
+	}
+	computedKeys, err := generateComputedColumnKeys(template)
+	if err != nil {
+		t.Fatalf("generateComputedColumnKeys() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		row        map[string]string
+		want       map[string]string
+		wantErrors map[string][]uint
+	}{
+		{
+			name:       "computed in order",
+			row:        map[string]string{"name": "Widget", "qty": "3", "price": "2.5"},
+			want:       map[string]string{"name": "Widget", "qty": "3", "price": "2.5", "total": "7.5", "label": "Widget: 7.5", "summary": ""},
+			wantErrors: map[string][]uint{"summary": {1}},
+		},
+		{
+			// The formula fails on the non-numeric quantity, so the cell is blank and validated as blank
+			name:       "formula error",
+			row:        map[string]string{"name": "Widget", "qty": "three", "price": "2.5"},
+			want:       map[string]string{"name": "Widget", "qty": "three", "price": "2.5", "total": "", "label": "Widget: ", "summary": ""},
+			wantErrors: map[string][]uint{"summary": {1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importRowFailures := newImportRowFailures()
+			if n := evaluateComputedColumns(computedKeys, tt.row, importRowFailures); n != 3 {
+				t.Errorf("evaluateComputedColumns() = %d, want 3", n)
+			}
+			if !reflect.DeepEqual(tt.row, tt.want) {
+				t.Errorf("evaluateComputedColumns() row = %v, want %v", tt.row, tt.want)
+			}
+			if !reflect.DeepEqual(importRowFailures.Errors, tt.wantErrors) {
+				t.Errorf("evaluateComputedColumns() errors = %v, want %v", importRowFailures.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -1,18 +1,59 @@
 package file
 
 import (
-	"container/heap"
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+	"math"
 	"strings"
 	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/util"
 )
 
-// A MatchScore holds the template column ID and the similarity score
+const (
+	// nameMatchWeight and valueMatchWeight are the weights of the name and value scores in the score of a candidate when
+	// both are known. Candidates without a value score are scored by the name alone.
+	nameMatchWeight  = 0.7
+	valueMatchWeight = 0.3
+	// valueOnlyMatchWeight is the weight of the value score for candidates whose names don't match. It's low enough that
+	// any candidate with a matching name scores higher.
+	valueOnlyMatchWeight = 0.6
+	// valueOnlyMatchMinScore is the fraction of the sample data that must be valid for a candidate whose name doesn't
+	// match to be considered at all
+	valueOnlyMatchMinScore = 0.9
+	// nameSimilarityMinScore is the similarity above which the names of the columns are considered a match
+	nameSimilarityMinScore = 0.9
+	// synonymMatchScore is the name score of a header matched to a template column by the synonym dictionary, below an
+	// exact match as a header can be in several groups
+	synonymMatchScore = 0.95
+	// unconfirmedLearnedMatchScore is the name score of a learned mapping that hasn't been confirmed enough times to
+	// rank above any other match, below an exact match or a synonym
+	unconfirmedLearnedMatchScore = 0.9
+)
+
+// A MatchScore holds the template column ID and the score of the match, combined from the name and value scores.
+// Learned matches, confirmed enough times by users of the importer on previous uploads, rank above any other match.
 type MatchScore struct {
 	templateColumnID model.ID
-	score            float32
+	score            float64
+	learned          bool
+	nameScore        float64
+	valueScore       null.Float
 	index            int // The index is needed by the heap.Interface methods
 }
 
+// isValueOnly returns true if only the sample data of the upload column matched the template column, not the name
+func (m MatchScore) isValueOnly() bool {
+	return !m.learned && m.nameScore == 0
+}
+
+// isBetterThan returns true if the match ranks above the other match
+func (m MatchScore) isBetterThan(other MatchScore) bool {
+	if m.learned != other.learned {
+		return m.learned
+	}
+	return m.score > other.score
+}
+
 // A PriorityMatchQueue implements heap.Interface and holds MatchScore items
 type PriorityMatchQueue []*MatchScore
 
@@ -20,7 +61,7 @@ func (pq PriorityMatchQueue) Len() int { return len(pq) }
 
 func (pq PriorityMatchQueue) Less(i, j int) bool {
 	// We want Pop to give us the highest, not lowest, score, so we use greater than here
-	return pq[i].score > pq[j].score
+	return pq[i].isBetterThan(*pq[j])
 }
 
 func (pq PriorityMatchQueue) Swap(i, j int) {
@@ -45,37 +86,95 @@ func (pq *PriorityMatchQueue) Pop() interface{} {
 	return item
 }
 
-// update modifies the priority and value of a MatchScore in the queue
-func (pq *PriorityMatchQueue) update(item *MatchScore, score float32) {
-	item.score = score
-	heap.Fix(pq, item.index)
+// nameMatchScore returns how closely the upload column name matches the template column: 1 for a suggested mapping, a
+// synonym of the column or an exact match, a high score if the synonym dictionary matched them, the service score if
+// the column mapping service matched them, or the similarity of the names if it's high enough. Returns 0 if the names
+// don't match.
+func nameMatchScore(uploadColumnName string, tc *model.TemplateColumn, synonyms *SynonymDictionary, serviceMatch string, serviceScore float64) float64 {
+	templateColumnName := strings.ToLower(strings.TrimSpace(tc.Name))
+	if templateColumnName == "" {
+		return 0
+	}
+	if uploadColumnName == templateColumnName || lo.ContainsBy(tc.SuggestedMappings, func(suggestedMapping string) bool {
+		return uploadColumnName == strings.ToLower(suggestedMapping)
+	}) {
+		return 1
+	}
+	if len(tc.Synonyms) != 0 {
+		foldedName := util.FoldText(uploadColumnName)
+		if lo.ContainsBy(tc.Synonyms, func(synonym string) bool {
+			return foldedName == util.FoldText(synonym)
+		}) {
+			return 1
+		}
+	}
+	score := 0.0
+	if synonyms.Matches(uploadColumnName, tc) {
+		score = synonymMatchScore
+	}
+	if serviceMatch != "" && strings.ToLower(strings.TrimSpace(serviceMatch)) == templateColumnName {
+		score = math.Max(score, serviceScore)
+	}
+	if similarityScore := float64(util.StringSimilarity(uploadColumnName, templateColumnName)); similarityScore > nameSimilarityMinScore {
+		score = math.Max(score, similarityScore)
+	}
+	return score
 }
 
-// updateMatchScore adds or updates a match score in the priority queue
-func updateMatchScore(pq *PriorityMatchQueue, match *MatchScore) {
-	// Check if this templateColumnID is already in the queue
-	for _, m := range *pq {
-		if m.templateColumnID == match.templateColumnID {
-			if match.score > m.score {
-				pq.update(m, match.score)
-			}
-			return
-		}
+// valueMatchScore returns the fraction of the non-blank sample data that passes the data type and validations of the
+// template column. Only the validations with an error severity that evaluate a cell on its own are used. Returns false
+// if there's no sample data or the column has no such validations (i.e. a string column with no validations), as any
+// value would pass.
+func valueMatchScore(sampleData []string, tc *model.TemplateColumn) (float64, bool) {
+	cells := lo.Filter(sampleData, func(cell string, _ int) bool {
+		return !util.IsBlankUnicode(cell)
+	})
+	validations := lo.Filter(tc.CellValidations(), func(v *model.Validation, _ int) bool {
+		return v.Evaluator != nil &&
+			v.Severity == model.ValidationSeverityError &&
+			v.Validate != "not_blank" &&
+			!v.IsRowValidation() &&
+			!v.IsBatchValidation()
+	})
+	if len(cells) == 0 || len(validations) == 0 {
+		return 0, false
 	}
-	// If not, push it to the queue
-	heap.Push(pq, match)
+	listDelimiter := tc.ListDelimiter()
+	numValid := lo.CountBy(cells, func(cell string) bool {
+		return lo.EveryBy(validations, func(v *model.Validation) bool {
+			passed, _, _ := v.EvaluateCell(cell, listDelimiter)
+			return passed
+		})
+	})
+	return float64(numValid) / float64(len(cells)), true
 }
 
-// Helper function to add a match to the priority queue based on column names
-func addMatchToQueueByName(pq *PriorityMatchQueue, templateColumnName string, templateColumns []*model.TemplateColumn, score float32) {
-	// Find the template column ID by name
-	for _, tc := range templateColumns {
-		if strings.ToLower(strings.TrimSpace(tc.Name)) == strings.ToLower(templateColumnName) {
-			heap.Push(pq, &MatchScore{
-				templateColumnID: tc.ID,
-				score:            score,
-			})
-			break
-		}
+// scoreMatch combines the name and value scores of a template column for an upload column. The learned mapping is the
+// mapping learned for the upload column to the template column, if any. Learned matches confirmed enough times are
+// scored 1 regardless, the others are scored like a close name match. Returns nil if the template column isn't a
+// candidate.
+func scoreMatch(tc *model.TemplateColumn, learnedMapping *model.LearnedColumnMapping, nameScore, valueScore float64, hasValueScore bool) *MatchScore {
+	learned := learnedMapping != nil && learnedMapping.IsConfirmed()
+	if learnedMapping != nil && !learned {
+		nameScore = math.Max(nameScore, unconfirmedLearnedMatchScore)
+	}
+	match := &MatchScore{
+		templateColumnID: tc.ID,
+		learned:          learned,
+		nameScore:        nameScore,
+		valueScore:       null.NewFloat(valueScore, hasValueScore),
+	}
+	switch {
+	case learned:
+		match.score = 1
+	case nameScore > 0 && hasValueScore:
+		match.score = nameMatchWeight*nameScore + valueMatchWeight*valueScore
+	case nameScore > 0:
+		match.score = nameScore
+	case hasValueScore && valueScore >= valueOnlyMatchMinScore:
+		match.score = valueOnlyMatchWeight * valueScore
+	default:
+		return nil
 	}
+	return match
 }
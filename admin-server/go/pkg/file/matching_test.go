@@ -0,0 +1,130 @@
+package file
+
+import (
+	"math"
+	"tableflow/go/pkg/model"
+	"testing"
+)
+
+func TestNameMatchScore(t *testing.T) {
+	synonyms := NewSynonymDictionary(nil)
+	tc := &model.TemplateColumn{
+		Name:              "First Name",
+		Key:               "first_name",
+		SuggestedMappings: []string{"FName"},
+		Synonyms:          []string{"Prénom usuel"},
+	}
+	tests := []struct {
+		name         string
+		uploadColumn string
+		serviceMatch string
+		serviceScore float64
+		want         float64
+	}{
+		{name: "exact", uploadColumn: "first name", want: 1},
+		{name: "suggested mapping", uploadColumn: "fname", want: 1},
+		{name: "column synonym", uploadColumn: "prenom_usuel", want: 1},
+		{name: "synonym dictionary", uploadColumn: "vorname", want: synonymMatchScore},
+		{name: "similar", uploadColumn: "first names", want: 1 - 1.0/11},
+		{name: "not similar enough", uploadColumn: "first", want: 0},
+		{name: "service match", uploadColumn: "given", serviceMatch: "First Name", serviceScore: 0.8, want: 0.8},
+		{name: "service match of another column", uploadColumn: "given", serviceMatch: "Last Name", serviceScore: 0.8, want: 0},
+		{name: "synonym above the service score", uploadColumn: "vorname", serviceMatch: "first name", serviceScore: 0.8, want: synonymMatchScore},
+		{name: "unrelated", uploadColumn: "email", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nameMatchScore(tt.uploadColumn, tc, synonyms, tt.serviceMatch, tt.serviceScore)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("nameMatchScore(%q) = %v, want %v", tt.uploadColumn, got, tt.want)
+			}
+		})
+	}
+
+	if got := nameMatchScore("vorname", tc, nil, "", 0); got != 0 {
+		t.Errorf("nameMatchScore without a synonym dictionary = %v, want 0", got)
+	}
+	if got := nameMatchScore("", &model.TemplateColumn{Key: "first_name"}, synonyms, "", 0); got != 0 {
+		t.Errorf("nameMatchScore of a template column without a name = %v, want 0", got)
+	}
+}
+
+func TestScoreMatch(t *testing.T) {
+	tc := &model.TemplateColumn{ID: model.NewID(), Name: "Email", Key: "email"}
+	tests := []struct {
+		name          string
+		nameScore     float64
+		valueScore    float64
+		hasValueScore bool
+		wantNil       bool
+		wantScore     float64
+	}{
+		{name: "name only", nameScore: 0.95, wantScore: 0.95},
+		{name: "name and value", nameScore: 1, valueScore: 0.5, hasValueScore: true, wantScore: nameMatchWeight + valueMatchWeight*0.5},
+		{name: "value only", valueScore: 1, hasValueScore: true, wantScore: valueOnlyMatchWeight},
+		{name: "value only below the minimum", valueScore: valueOnlyMatchMinScore - 0.01, hasValueScore: true, wantNil: true},
+		{name: "no match", wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := scoreMatch(tc, nil, tt.nameScore, tt.valueScore, tt.hasValueScore)
+			if tt.wantNil {
+				if match != nil {
+					t.Errorf("scoreMatch = %+v, want nil", match)
+				}
+				return
+			}
+			if match == nil {
+				t.Fatalf("scoreMatch = nil, want a score of %v", tt.wantScore)
+			}
+			if math.Abs(match.score-tt.wantScore) > 1e-6 || match.templateColumnID != tc.ID {
+				t.Errorf("scoreMatch = %+v, want a score of %v", match, tt.wantScore)
+			}
+		})
+	}
+
+	// Any candidate with a matching name ranks above a candidate matched by its values alone
+	nameMatch := scoreMatch(tc, nil, nameSimilarityMinScore, 0, true)
+	valueMatch := scoreMatch(tc, nil, 0, 1, true)
+	if !nameMatch.isBetterThan(*valueMatch) {
+		t.Errorf("name match %+v doesn't rank above value match %+v", nameMatch, valueMatch)
+	}
+}
+
+func TestScoreMatchLearned(t *testing.T) {
+	tc := &model.TemplateColumn{ID: model.NewID(), Name: "Email", Key: "email"}
+	confirmed := &model.LearnedColumnMapping{TemplateColumnKey: "email", TimesConfirmed: model.LearnedColumnMappingMinConfirmations}
+	unconfirmed := &model.LearnedColumnMapping{TemplateColumnKey: "email", TimesConfirmed: 1}
+	tests := []struct {
+		name           string
+		learnedMapping *model.LearnedColumnMapping
+		nameScore      float64
+		wantLearned    bool
+		wantScore      float64
+	}{
+		{name: "confirmed", learnedMapping: confirmed, wantLearned: true, wantScore: 1},
+		{name: "confirmed with a name match", learnedMapping: confirmed, nameScore: 0.95, wantLearned: true, wantScore: 1},
+		{name: "unconfirmed", learnedMapping: unconfirmed, wantScore: unconfirmedLearnedMatchScore},
+		{name: "unconfirmed below an exact match", learnedMapping: unconfirmed, nameScore: 1, wantScore: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := scoreMatch(tc, tt.learnedMapping, tt.nameScore, 0, false)
+			if match == nil {
+				t.Fatal("scoreMatch = nil, want a match")
+			}
+			if match.learned != tt.wantLearned || math.Abs(match.score-tt.wantScore) > 1e-6 {
+				t.Errorf("scoreMatch = %+v, want learned %v and a score of %v", match, tt.wantLearned, tt.wantScore)
+			}
+		})
+	}
+
+	// A confirmed learned match ranks above an exact name match of another column, an unconfirmed one doesn't
+	exactMatch := scoreMatch(&model.TemplateColumn{ID: model.NewID(), Name: "Work Email"}, nil, 1, 0, false)
+	if !scoreMatch(tc, confirmed, 0, 0, false).isBetterThan(*exactMatch) {
+		t.Error("confirmed learned match doesn't rank above an exact name match")
+	}
+	if scoreMatch(tc, unconfirmed, 0, 0, false).isBetterThan(*exactMatch) {
+		t.Error("unconfirmed learned match ranks above an exact name match")
+	}
+}
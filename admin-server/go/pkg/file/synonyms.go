@@ -0,0 +1,92 @@
+package file
+
+import (
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/util"
+)
+
+// builtInSynonyms is the starter dictionary of headers commonly used for the same field in English, German, Spanish and
+// French. The headers are folded before they're compared, so the accents, case and word separators don't matter. A
+// header can be in several groups, i.e. "nombre" is used for both the first name and the full name. Headers that are
+// also common words for other fields in one of the languages (i.e. "land", "cell", "nom" and "estado") are left out,
+// as they would match unrelated columns.
+var builtInSynonyms = [][]string{
+	{"first name", "given name", "forename", "vorname", "rufname", "nombre", "nombre de pila", "prénom"},
+	{"last name", "surname", "family name", "nachname", "familienname", "apellido", "apellidos", "nom de famille"},
+	{"name", "full name", "vollständiger name", "nombre", "nombre completo", "nom complet"},
+	{"email", "e-mail", "email address", "e-mail address", "mail", "e-mail-adresse", "emailadresse", "correo", "correo electrónico", "dirección de correo electrónico", "courriel", "adresse e-mail", "adresse électronique"},
+	{"phone", "phone number", "telephone", "telephone number", "tel", "telefon", "telefonnummer", "rufnummer", "teléfono", "número de teléfono", "téléphone", "numéro de téléphone"},
+	{"mobile", "mobile phone", "mobile number", "cell phone", "handynummer", "mobilnummer", "móvil", "celular", "teléfono móvil", "téléphone portable"},
+	{"address", "street address", "street", "adresse", "anschrift", "straße", "dirección", "domicilio", "calle", "rue"},
+	{"city", "town", "stadt", "ort", "wohnort", "ciudad", "localidad", "ville", "commune"},
+	{"postal code", "zip", "zip code", "postcode", "plz", "postleitzahl", "código postal", "code postal"},
+	{"state", "province", "region", "bundesland", "provincia", "région"},
+	{"country", "país", "pays"},
+	{"company", "company name", "organization", "organisation", "firma", "unternehmen", "empresa", "compañía", "entreprise", "société"},
+}
+
+// A SynonymDictionary holds groups of headers that name the same column, indexed by their folded form. It combines the
+// built-in dictionary with the header synonyms managed on a workspace.
+type SynonymDictionary struct {
+	groups []map[string]bool
+	index  map[string][]int
+}
+
+// NewSynonymDictionary builds a dictionary from the built-in groups and the header synonyms of a workspace, each of
+// which is a group of its term and synonyms
+func NewSynonymDictionary(headerSynonyms []*model.HeaderSynonym) *SynonymDictionary {
+	d := &SynonymDictionary{index: make(map[string][]int)}
+	for _, group := range builtInSynonyms {
+		d.addGroup(group)
+	}
+	for _, hs := range headerSynonyms {
+		d.addGroup(append([]string{hs.Term}, hs.Synonyms...))
+	}
+	return d
+}
+
+// LoadSynonymDictionary builds the dictionary for a workspace. The built-in groups are used alone if the header
+// synonyms of the workspace can't be retrieved, as the matching can still be done without them.
+func LoadSynonymDictionary(workspaceID string) *SynonymDictionary {
+	headerSynonyms, err := db.GetHeaderSynonyms(workspaceID)
+	if err != nil {
+		tf.Log.Warnw("Could not retrieve header synonyms", "workspace_id", workspaceID, "error", err)
+	}
+	return NewSynonymDictionary(headerSynonyms)
+}
+
+func (d *SynonymDictionary) addGroup(headers []string) {
+	group := make(map[string]bool)
+	for _, header := range headers {
+		if folded := util.FoldText(header); len(folded) != 0 {
+			group[folded] = true
+		}
+	}
+	if len(group) < 2 {
+		return
+	}
+	d.groups = append(d.groups, group)
+	for folded := range group {
+		d.index[folded] = append(d.index[folded], len(d.groups)-1)
+	}
+}
+
+// Matches returns true if the upload column header is in a group with the name or key of the template column
+func (d *SynonymDictionary) Matches(header string, tc *model.TemplateColumn) bool {
+	if d == nil {
+		return false
+	}
+	folded := util.FoldText(header)
+	if len(folded) == 0 {
+		return false
+	}
+	name, key := util.FoldText(tc.Name), util.FoldText(tc.Key)
+	for _, i := range d.index[folded] {
+		if d.groups[i][name] || d.groups[i][key] {
+			return true
+		}
+	}
+	return false
+}
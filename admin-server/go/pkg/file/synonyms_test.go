@@ -0,0 +1,52 @@
+package file
+
+import (
+	"tableflow/go/pkg/model"
+	"testing"
+)
+
+func TestSynonymDictionaryMatches(t *testing.T) {
+	d := NewSynonymDictionary([]*model.HeaderSynonym{
+		{Term: "SKU", Synonyms: []string{"Artikelnummer", "référence"}},
+		// A group with a single header after folding isn't added
+		{Term: "Notes", Synonyms: []string{"notes", "NOTES"}},
+	})
+	firstName := &model.TemplateColumn{Name: "First Name", Key: "first_name"}
+	fullName := &model.TemplateColumn{Name: "Full Name", Key: "name"}
+	sku := &model.TemplateColumn{Name: "Product code", Key: "sku"}
+	notes := &model.TemplateColumn{Name: "Comments", Key: "comments"}
+	country := &model.TemplateColumn{Name: "Country", Key: "country"}
+	mobile := &model.TemplateColumn{Name: "Mobile", Key: "mobile"}
+	tests := []struct {
+		name   string
+		header string
+		tc     *model.TemplateColumn
+		want   bool
+	}{
+		{name: "built-in synonym of the name", header: "Vorname", tc: firstName, want: true},
+		{name: "accents and case", header: "PRENOM", tc: firstName, want: true},
+		{name: "synonym of the key", header: "Artikelnummer", tc: sku, want: true},
+		{name: "workspace synonym with accents", header: "Reference", tc: sku, want: true},
+		{name: "header in several groups", header: "nombre", tc: firstName, want: true},
+		{name: "header in several groups matches each", header: "nombre", tc: fullName, want: true},
+		{name: "different group", header: "Nachname", tc: firstName, want: false},
+		{name: "not in the dictionary", header: "Kommentar", tc: notes, want: false},
+		{name: "ambiguous word isn't a synonym", header: "Land", tc: country, want: false},
+		{name: "ambiguous word isn't a synonym of the phone", header: "Cell", tc: mobile, want: false},
+		{name: "unambiguous phrase", header: "Cell Phone", tc: mobile, want: true},
+		{name: "single header group", header: "notes", tc: &model.TemplateColumn{Name: "NOTES", Key: "notes"}, want: false},
+		{name: "blank header", header: " ", tc: firstName, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Matches(tt.header, tt.tc); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.header, tt.tc.Name, got, tt.want)
+			}
+		})
+	}
+
+	var nilDictionary *SynonymDictionary
+	if nilDictionary.Matches("Vorname", firstName) {
+		t.Error("Matches on a nil dictionary returned true")
+	}
+}
@@ -0,0 +1,177 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/types"
+	"tableflow/go/pkg/util"
+)
+
+// TemplateGenerationSampleSize is the number of rows after the header row of a sample file used to generate a template
+const TemplateGenerationSampleSize = 1000
+
+const (
+	// listMaxDistinctValues is the most distinct values a string column can have for a list validation to be proposed
+	listMaxDistinctValues = 10
+	// listMinRepetition is how many times each distinct value must appear on average for a list validation to be
+	// proposed, so columns with few rows aren't treated as lists
+	listMinRepetition = 3
+)
+
+// GenerateTemplate proposes a template from a sample file (CSV or XLSX) with a header row. For each column, the name is
+// taken from the header and the key derived from it, the data type is inferred from the values, and validations are
+// proposed: not_blank if every row has a value and list if the column has few distinct values. The variants of the
+// header (i.e. "first_name" and "firstname" for "First Name") are added as suggested mappings. The template isn't
+// saved, so it can be reviewed first.
+func GenerateTemplate(file *os.File, fileType string) (*types.Template, error) {
+	it, err := util.OpenDataFileIterator(file, fileType)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	header, err := it.GetRow()
+	if err == io.EOF || len(header) == 0 {
+		return nil, errors.New("The file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the header row of the file: %s", err.Error())
+	}
+	rows := make([][]string, 0, TemplateGenerationSampleSize)
+	for len(rows) < TemplateGenerationSampleSize {
+		row, err := it.GetRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Could not read row %d of the file: %s", len(rows)+2, err.Error())
+		}
+		if lo.EveryBy(row, util.IsBlankUnicode) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	template := &types.Template{TemplateColumns: make([]*types.TemplateColumn, 0, len(header))}
+	seenKeys := make(map[string]bool)
+	seenSuggestedMappings := make(map[string]bool)
+	for i, name := range header {
+		cells := lo.Map(rows, func(row []string, _ int) string {
+			if i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		})
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			if lo.EveryBy(cells, util.IsBlankUnicode) {
+				// Skip empty columns, i.e. from a trailing delimiter on each row
+				continue
+			}
+			name = fmt.Sprintf("Column %d", i+1)
+		}
+		key := generateTemplateColumnKey(name, i, seenKeys)
+		seenKeys[key] = true
+		seenSuggestedMappings[strings.ToLower(name)] = true
+
+		tc := &types.TemplateColumn{
+			Name:              name,
+			Key:               key,
+			DataType:          string(model.TemplateColumnDataTypeString),
+			Validations:       make([]*types.Validation, 0),
+			SuggestedMappings: make([]string, 0),
+		}
+		inferredType, _, inferred := InferType(cells)
+		if inferred {
+			dataType, validate, err := model.ParseInferredType(inferredType)
+			if err == nil {
+				tc.DataType = string(dataType)
+				if len(validate) != 0 {
+					tc.Validations = append(tc.Validations, &types.Validation{Validate: validate, Options: jsonb.NewNull()})
+				}
+			}
+		}
+		if len(cells) != 0 && !lo.ContainsBy(cells, util.IsBlankUnicode) {
+			tc.Validations = append(tc.Validations, &types.Validation{Validate: "not_blank", Options: jsonb.NewNull()})
+		}
+		if inferredType == string(model.TemplateColumnDataTypeString) {
+			if values, ok := listValues(cells); ok {
+				options, err := jsonb.FromInterface(values)
+				if err != nil {
+					return nil, err
+				}
+				tc.Validations = append(tc.Validations, &types.Validation{Validate: "list", Options: options})
+			}
+		}
+		template.TemplateColumns = append(template.TemplateColumns, tc)
+	}
+
+	// Suggested mappings are added once all the names are known, as they must be unique across the columns
+	for _, tc := range template.TemplateColumns {
+		for _, variant := range headerVariants(tc.Name) {
+			if !seenSuggestedMappings[variant] {
+				seenSuggestedMappings[variant] = true
+				tc.SuggestedMappings = append(tc.SuggestedMappings, variant)
+			}
+		}
+	}
+	return template, nil
+}
+
+// generateTemplateColumnKey derives a unique key from the column name, falling back to the position of the column if
+// the name has no characters that can be used in a key
+func generateTemplateColumnKey(name string, index int, seenKeys map[string]bool) string {
+	key := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return -1
+	}, util.SanitizeKey(name))
+	if len(strings.Trim(key, "_")) == 0 {
+		key = fmt.Sprintf("column_%d", index+1)
+	}
+	uniqueKey := key
+	for n := 2; seenKeys[uniqueKey]; n++ {
+		uniqueKey = fmt.Sprintf("%s_%d", key, n)
+	}
+	return uniqueKey
+}
+
+// listValues returns the sorted distinct values of the column if there are few of them compared to the number of
+// cells, ignoring case as the list validation does
+func listValues(cells []string) ([]string, bool) {
+	cells = lo.Filter(cells, func(cell string, _ int) bool {
+		return !util.IsBlankUnicode(cell)
+	})
+	values := lo.UniqBy(cells, strings.ToLower)
+	if len(values) == 0 || len(values) > listMaxDistinctValues || len(cells) < len(values)*listMinRepetition {
+		return nil, false
+	}
+	sort.Strings(values)
+	return values, true
+}
+
+// headerVariants returns the lowercase spellings of a header a file may use for the same column, i.e. "first name",
+// "first_name", "first-name" and "firstname" for "First Name", excluding the header itself
+func headerVariants(header string) []string {
+	words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '.'
+	})
+	if len(words) < 2 {
+		return nil
+	}
+	variants := []string{
+		strings.Join(words, " "),
+		strings.Join(words, "_"),
+		strings.Join(words, "-"),
+		strings.Join(words, ""),
+	}
+	return lo.Without(lo.Uniq(variants), strings.ToLower(header))
+}
@@ -0,0 +1,136 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTemplate(t *testing.T) {
+	csv := strings.Join([]string{
+		"First Name,Email,Amount,Status,Joined,,",
+		"Mary,mary@example.com,10.5,active,2024-01-31,,",
+		"John,john@example.com,20,active,2024-02-15,,",
+		"Ana,,30,inactive,2024-03-01,,",
+		",,,,,,",
+		"Li,li@example.com,40,active,2024-03-04,,",
+		"Sam,sam@example.com,50,inactive,2024-03-05,,",
+		"Kim,kim@example.com,60,active,2024-03-06,,",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "sample.csv")
+	if err := os.WriteFile(path, []byte(csv), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	template, err := GenerateTemplate(f, "text/csv")
+	if err != nil {
+		t.Fatalf("GenerateTemplate returned error: %v", err)
+	}
+	type column struct {
+		key               string
+		dataType          string
+		validations       []string
+		suggestedMappings []string
+	}
+	want := []column{
+		{key: "first_name", dataType: "string", validations: []string{"not_blank"}, suggestedMappings: []string{"first_name", "first-name", "firstname"}},
+		{key: "email", dataType: "string", validations: []string{"email"}, suggestedMappings: []string{}},
+		{key: "amount", dataType: "number", validations: []string{"not_blank"}, suggestedMappings: []string{}},
+		{key: "status", dataType: "string", validations: []string{"not_blank", "list"}, suggestedMappings: []string{}},
+		{key: "joined", dataType: "date", validations: []string{"not_blank"}, suggestedMappings: []string{}},
+	}
+	got := make([]column, 0, len(template.TemplateColumns))
+	for _, tc := range template.TemplateColumns {
+		validations := make([]string, 0, len(tc.Validations))
+		for _, v := range tc.Validations {
+			validations = append(validations, v.Validate)
+		}
+		got = append(got, column{key: tc.Key, dataType: tc.DataType, validations: validations, suggestedMappings: tc.SuggestedMappings})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateTemplate() columns = %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerateTemplateColumnKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		index    int
+		seenKeys map[string]bool
+		want     string
+	}{
+		{name: "words", header: "First Name", want: "first_name"},
+		{name: "punctuation", header: "E-mail (work)", want: "email_work"},
+		{name: "no usable characters", header: "名前", index: 2, want: "column_3"},
+		{name: "duplicate", header: "Email", seenKeys: map[string]bool{"email": true}, want: "email_2"},
+		{name: "several duplicates", header: "Email", seenKeys: map[string]bool{"email": true, "email_2": true}, want: "email_3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seenKeys := tt.seenKeys
+			if seenKeys == nil {
+				seenKeys = map[string]bool{}
+			}
+			if got := generateTemplateColumnKey(tt.header, tt.index, seenKeys); got != tt.want {
+				t.Errorf("generateTemplateColumnKey(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		cells  []string
+		want   []string
+		wantOK bool
+	}{
+		{name: "repeated values", cells: []string{"b", "a", "B", "a", "b", "a"}, want: []string{"a", "b"}, wantOK: true},
+		{name: "too few repetitions", cells: []string{"a", "b", "a", "b"}, wantOK: false},
+		{name: "blank cells are ignored", cells: []string{"a", "", "a", " ", "a"}, want: []string{"a"}, wantOK: true},
+		{name: "all blank", cells: []string{"", ""}, wantOK: false},
+		{
+			name:   "too many distinct values",
+			cells:  strings.Split(strings.Repeat("a,b,c,d,e,f,g,h,i,j,k,", 3), ","),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := listValues(tt.cells)
+			if ok != tt.wantOK {
+				t.Fatalf("listValues(%q) ok = %v, want %v", tt.cells, ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listValues(%q) = %q, want %q", tt.cells, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderVariants(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "First Name", want: []string{"first_name", "first-name", "firstname"}},
+		{header: "first_name", want: []string{"first name", "first-name", "firstname"}},
+		{header: "e.mail", want: []string{"e mail", "e_mail", "e-mail", "email"}},
+		{header: "Email", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := headerVariants(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("headerVariants(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package file
+
+import (
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+	"tableflow/go/pkg/evaluator"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/scylla"
+	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/util"
+)
+
+// TypeInferenceSampleSize is the number of rows after the header row profiled to infer the data types of the columns
+const TypeInferenceSampleSize = 1000
+
+// typeInferenceMinConfidence is the fraction of the non-blank cells in a column that must be valid for a type to be
+// inferred, so a few bad values don't prevent the type from being proposed
+const typeInferenceMinConfidence = 0.9
+
+// inferredTypes are the types that can be inferred, in the order they're checked. Types that are more specific come
+// first, i.e. a column of 0 and 1 is inferred as a number rather than a boolean, and a column of years as a number
+// rather than a date.
+var inferredTypes = []string{
+	model.InferredTypeEmail,
+	string(model.TemplateColumnDataTypeNumber),
+	string(model.TemplateColumnDataTypeBoolean),
+	string(model.TemplateColumnDataTypeDate),
+}
+
+// InferUploadColumnDataTypes profiles the rows of a schemaless upload after the header rows to propose a data type for
+// each upload column, which the user can accept when setting the column mapping. The rows must already be stored.
+func InferUploadColumnDataTypes(upload *model.Upload) {
+	if !upload.Schemaless || !upload.HeaderRowIndex.Valid {
+		return
+	}
+	uploadRows := scylla.PaginateUploadRows(upload.ID.String(), upload.DataRowIndex(), TypeInferenceSampleSize)
+	for _, uc := range upload.UploadColumns {
+		cells := lo.Map(uploadRows, func(row map[int]string, _ int) string {
+			return row[uc.Index]
+		})
+		inferredType, confidence, ok := InferType(cells)
+		if !ok {
+			continue
+		}
+		uc.InferredDataType = null.StringFrom(inferredType)
+		uc.InferredDataTypeConfidence = null.FloatFrom(confidence)
+	}
+}
+
+// InferType returns the type most of the non-blank cells are valid for and the fraction of the non-blank cells that are
+// valid. Columns are inferred as strings if no other type is valid for enough of the cells. Returns false if all the
+// cells are blank, as nothing can be inferred.
+func InferType(cells []string) (string, float64, bool) {
+	cells = lo.Filter(cells, func(cell string, _ int) bool {
+		return !util.IsBlankUnicode(cell)
+	})
+	if len(cells) == 0 {
+		return "", 0, false
+	}
+	for _, inferredType := range inferredTypes {
+		e, err := evaluator.Parse(inferredType, jsonb.NewNull())
+		if err != nil {
+			tf.Log.Errorw("Could not create evaluator to infer type", "inferred_type", inferredType, "error", err)
+			continue
+		}
+		numValid := lo.CountBy(cells, func(cell string) bool {
+			passed, _, _ := e.Evaluate(cell)
+			return passed
+		})
+		confidence := float64(numValid) / float64(len(cells))
+		if confidence >= typeInferenceMinConfidence {
+			return inferredType, confidence, true
+		}
+	}
+	return string(model.TemplateColumnDataTypeString), 1, true
+}
@@ -0,0 +1,58 @@
+package file
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInferType(t *testing.T) {
+	tests := []struct {
+		name           string
+		cells          []string
+		wantType       string
+		wantConfidence float64
+		wantOK         bool
+	}{
+		{name: "numbers", cells: []string{"1", "2.5", "-3", "1,000"}, wantType: "number", wantConfidence: 1, wantOK: true},
+		{name: "zeros and ones are numbers", cells: []string{"0", "1", "1", "0"}, wantType: "number", wantConfidence: 1, wantOK: true},
+		{name: "booleans", cells: []string{"true", "FALSE", "t", "f"}, wantType: "boolean", wantConfidence: 1, wantOK: true},
+		{name: "dates", cells: []string{"2024-01-31", "02/15/2024", "March 3, 2024"}, wantType: "date", wantConfidence: 1, wantOK: true},
+		{name: "years are numbers", cells: []string{"2021", "2022", "2023"}, wantType: "number", wantConfidence: 1, wantOK: true},
+		{name: "emails", cells: []string{"mary@example.com", "john@example.org"}, wantType: "email", wantConfidence: 1, wantOK: true},
+		{name: "strings", cells: []string{"Mary", "John", "42"}, wantType: "string", wantConfidence: 1, wantOK: true},
+		{name: "blank cells are ignored", cells: []string{"1", "", " ", "2"}, wantType: "number", wantConfidence: 1, wantOK: true},
+		{
+			name:           "a few invalid cells",
+			cells:          []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "n/a"},
+			wantType:       "number",
+			wantConfidence: 10.0 / 11.0,
+			wantOK:         true,
+		},
+		{
+			name:           "too many invalid cells",
+			cells:          []string{"1", "2", "3", "4", "5", "6", "7", "8", "n/a", "unknown"},
+			wantType:       "string",
+			wantConfidence: 1,
+			wantOK:         true,
+		},
+		{name: "all blank", cells: []string{"", " "}, wantOK: false},
+		{name: "no cells", cells: nil, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inferredType, confidence, ok := InferType(tt.cells)
+			if ok != tt.wantOK {
+				t.Fatalf("InferType(%q) ok = %v, want %v", tt.cells, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if inferredType != tt.wantType {
+				t.Errorf("InferType(%q) type = %q, want %q", tt.cells, inferredType, tt.wantType)
+			}
+			if math.Abs(confidence-tt.wantConfidence) > 1e-9 {
+				t.Errorf("InferType(%q) confidence = %v, want %v", tt.cells, confidence, tt.wantConfidence)
+			}
+		})
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"github.com/gocql/gocql"
 	"github.com/guregu/null"
+	"github.com/samber/lo"
 	"github.com/tus/tusd/pkg/handler"
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +35,12 @@ var maxColumnLimit = int(math.Min(500, math.MaxInt16))
 var maxRowLimit = 1000 * 1000 * 10       // TODO: Store and configure this on the workspace? But keep a max limit to prevent runaways?
 const UploadColumnSampleDataSize = 1 + 3 // 1 header row + 3 sample rows
 
+// MaxHeaderRows is the most rows a header can span
+const MaxHeaderRows = 5
+
+// HeaderNameSeparator separates the cells of the header rows in the names of the columns
+const HeaderNameSeparator = " "
+
 func UploadCompleteHandler(event handler.HookEvent,
 	uploadAdditionalStorageHandler func(*model.Upload, *os.File) error,
 	uploadLimitCheck func(*model.Upload, *os.File) (int, error),
@@ -65,7 +73,7 @@ func UploadCompleteHandler(event handler.HookEvent,
 	// If a template is provided from the SDK, use that instead of the template on the importer
 	uploadError := "" // TODO: Refactor this so the upload object is created higher up and other fatal errors can exist
 	allowedValidateTypes := getAllowedValidateTypes(importer.WorkspaceID.String())
-	uploadTemplate, err := generateUploadTemplate(event.HTTPRequest.Header.Get("X-Import-Template"), allowedValidateTypes)
+	uploadTemplate, err := generateUploadTemplate(event.HTTPRequest.Header.Get("X-Import-Template"), importer.WorkspaceID.String(), allowedValidateTypes)
 	if err != nil {
 		tf.Log.Warnw("Could not generate upload template", "error", err, "tus_id", event.Upload.ID, "importer_id", importerID)
 		uploadError = fmt.Sprintf("Invalid template: %s", err.Error())
@@ -151,6 +159,7 @@ func UploadCompleteHandler(event handler.HookEvent,
 	// If SkipHeaderRowSelection is turned on, set the upload column header index and return the upload columns with the upload
 	if skipHeaderRowSelection {
 		upload.HeaderRowIndex = null.IntFrom(0)
+		upload.HeaderRowEndIndex = null.IntFrom(0)
 
 		// Parse the column headers and sample data directly from the file
 		// TODO: Consider moving this to get the data directly from Scylla to avoid having two methods to do it
@@ -160,6 +169,9 @@ func UploadCompleteHandler(event handler.HookEvent,
 			removeUploadFileFromDisk(file, fileName, upload.ID.String())
 			return
 		}
+	} else if uploadChunkHandler == nil {
+		// Propose the header row, unless the chunks are sent to a service to find it
+		DetectUploadHeaderRow(upload)
 	}
 
 	fileSize, err := util.GetFileSize(file)
@@ -364,7 +376,7 @@ func getImportMetadata(importMetadataEncodedStr string) (jsonb.JSONB, error) {
 	return importMetadata, nil
 }
 
-func generateUploadTemplate(uploadTemplateEncodedStr string, allowedValidateTypes map[string]bool) (jsonb.JSONB, error) {
+func generateUploadTemplate(uploadTemplateEncodedStr, workspaceID string, allowedValidateTypes map[string]bool) (jsonb.JSONB, error) {
 	if len(uploadTemplateEncodedStr) == 0 {
 		return jsonb.JSONB{}, nil
 	}
@@ -384,6 +396,25 @@ func generateUploadTemplate(uploadTemplateEncodedStr string, allowedValidateType
 	if err != nil {
 		return jsonb.JSONB{}, fmt.Errorf("could not convert upload template: %v", err.Error())
 	}
+	// Lookup validations can only use the lookup tables of the workspace, and http validations can only be added to the
+	// templates of importers so an SDK-defined template can't make the server send requests to any URL
+	for _, tc := range template.TemplateColumns {
+		for _, v := range tc.Validations {
+			if v.Validate == "http" {
+				return jsonb.JSONB{}, fmt.Errorf("the http validate type on the column %s can't be used in an SDK-defined template", tc.Key)
+			}
+			if v.Validate != "lookup" {
+				continue
+			}
+			validation, err := model.ParseValidation(v.ValidationID, tc.ID.String(), v.Validate, v.Options, v.Message, v.Severity, model.TemplateColumnDataType(tc.DataType))
+			if err != nil {
+				return jsonb.JSONB{}, err
+			}
+			if err = db.ValidateLookupTableReference(workspaceID, validation); err != nil {
+				return jsonb.JSONB{}, err
+			}
+		}
+	}
 
 	// Now convert the validated and updated template object back to JSON to be stored on the upload
 	jsonBytes, err := json.Marshal(template)
@@ -419,30 +450,28 @@ func processUploadColumnsFromFile(upload *model.Upload, file *os.File) error {
 	return CreateUploadColumns(upload, rows)
 }
 
-// CreateUploadColumns Create UploadColumns on the Upload and set the SampleData from the remaining rows
+// CreateUploadColumns Create UploadColumns on the Upload and set the SampleData from the remaining rows. The rows start
+// at the header row, and if the header spans several rows the names are composed from the cells of each header row.
+// The data types of the columns are inferred on schemaless uploads.
 func CreateUploadColumns(upload *model.Upload, rows [][]string) error {
-	headerRowProcessed := false
-
-	for rowIndex, row := range rows {
-		if !headerRowProcessed {
-			headerRowProcessed = true
-			for columnIndex, v := range row {
-				if columnIndex >= maxColumnLimit {
-					tf.Log.Warnw("Max column limit reached for row", "column_index", columnIndex, "row_index", rowIndex, "upload_id", upload.ID)
-					break
-				}
-				upload.UploadColumns = append(upload.UploadColumns, &model.UploadColumn{
-					UploadID:   upload.ID,
-					Name:       v,
-					Index:      columnIndex,
-					SampleData: make([]string, 0),
-				})
-			}
-			continue
+	numHeaderRows := lo.Min([]int{lo.Max([]int{upload.NumHeaderRows(), 1}), len(rows)})
+
+	for columnIndex, name := range ComposeHeaderNames(rows[:numHeaderRows]) {
+		if columnIndex >= maxColumnLimit {
+			tf.Log.Warnw("Max column limit reached for the header", "column_index", columnIndex, "upload_id", upload.ID)
+			break
 		}
+		upload.UploadColumns = append(upload.UploadColumns, &model.UploadColumn{
+			UploadID:   upload.ID,
+			Name:       name,
+			Index:      columnIndex,
+			SampleData: make([]string, 0),
+		})
+	}
+	for rowIndex, row := range rows[numHeaderRows:] {
 		for columnIndex, v := range row {
 			if columnIndex >= len(upload.UploadColumns) {
-				tf.Log.Warnw("Index out of range for row", "column_index", columnIndex, "row_index", rowIndex, "upload_id", upload.ID)
+				tf.Log.Warnw("Index out of range for row", "column_index", columnIndex, "row_index", rowIndex+numHeaderRows, "upload_id", upload.ID)
 				break
 			}
 			upload.UploadColumns[columnIndex].SampleData = append(upload.UploadColumns[columnIndex].SampleData, v)
@@ -452,6 +481,7 @@ func CreateUploadColumns(upload *model.Upload, rows [][]string) error {
 		tf.Log.Warnw("No upload columns found in file", "upload_id", upload.ID)
 		return errors.New("no upload columns found in file")
 	}
+	InferUploadColumnDataTypes(upload)
 	err := tf.DB.Create(upload.UploadColumns).Error
 	if err != nil {
 		tf.Log.Errorw("Could not create upload columns in database", "error", err, "upload_id", upload.ID)
@@ -461,6 +491,46 @@ func CreateUploadColumns(upload *model.Upload, rows [][]string) error {
 	return nil
 }
 
+// ComposeHeaderNames returns the names of the columns from the header rows. With several header rows, the names are
+// composed from the cells stacked in each column, top to bottom (i.e. "Q1 Revenue"). A cell merged across columns is
+// only stored in its first column, so blank cells in the header rows above the last are filled from the cell to their
+// left, unless the cells above them start a new group.
+func ComposeHeaderNames(headerRows [][]string) []string {
+	if len(headerRows) == 1 {
+		return headerRows[0]
+	}
+	numColumns := 0
+	for _, row := range headerRows {
+		numColumns = lo.Max([]int{numColumns, len(row)})
+	}
+	parts := make([][]string, numColumns)
+	for r, row := range headerRows {
+		isLastRow := r == len(headerRows)-1
+		// The names composed from the rows above, which tell where the groups start
+		groups := lo.Map(parts, func(p []string, _ int) string {
+			return strings.Join(p, "\x00")
+		})
+		cell := ""
+		for c := 0; c < numColumns; c++ {
+			leftCell := cell
+			cell = ""
+			if c < len(row) {
+				cell = strings.TrimSpace(row[c])
+			}
+			if len(cell) == 0 && !isLastRow && c > 0 && groups[c] == groups[c-1] {
+				cell = leftCell
+			}
+			// Cells merged across rows are only added once
+			if len(cell) != 0 && (len(parts[c]) == 0 || parts[c][len(parts[c])-1] != cell) {
+				parts[c] = append(parts[c], cell)
+			}
+		}
+	}
+	return lo.Map(parts, func(p []string, _ int) string {
+		return strings.Join(p, HeaderNameSeparator)
+	})
+}
+
 func saveUploadError(upload *model.Upload, errorStr string) {
 	upload.Error = null.StringFrom(errorStr)
 	if err := tf.DB.Save(upload).Error; err != nil {
@@ -482,89 +552,81 @@ func removeUploadFileFromDisk(file *os.File, fileName, uploadID string) {
 	}
 }
 
-// AddColumnMappingSuggestions determines the best match between upload and template columns
-func AddColumnMappingSuggestions(upload *types.Upload, templateColumns []*model.TemplateColumn, getColumnMatches func(*types.Upload, []*model.TemplateColumn) map[string]string) {
+// AddColumnMappingSuggestions determines the best match between upload and template columns. Template columns are
+// scored by how closely their names match the upload column header and by how much of the sample data of the upload
+// column passes their data type and validations, so columns with generic headers (i.e. "Field 3") can still be matched
+// by their values. The column mappings learned from previous uploads to the importer (by the normalized upload column
+// names) are the strongest signal once they're confirmed enough times. Headers in other languages are matched by the
+// synonyms of the template columns and the synonym dictionary. The candidates are added to each upload column with
+// their confidence.
+func AddColumnMappingSuggestions(upload *types.Upload, templateColumns []*model.TemplateColumn, learnedMappings map[string]*model.LearnedColumnMapping, synonyms *SynonymDictionary, getColumnMatches func(*types.Upload, []*model.TemplateColumn) map[string]string) {
 	// TODO: We should persist these on the upload to avoid having to regenerate them in different scenarios.
 
+	// Computed columns are derived from the other columns, so they can't be mapped
+	templateColumns = lo.Filter(templateColumns, func(tc *model.TemplateColumn, _ int) bool {
+		return !tc.IsComputed()
+	})
+
 	// Call the column mapping service function to get the initial matches, if any
 	serviceMatches := make(map[string]string)
 	if getColumnMatches != nil {
 		serviceMatches = getColumnMatches(upload, templateColumns)
 	}
 
-	potentialBestMatches := make([]MatchScore, len(upload.UploadColumns))
+	candidates := make([][]*MatchScore, len(upload.UploadColumns))
 
-	// First pass: Iterate over the upload columns and store the potential best matches
+	// First pass: Score the template columns for each upload column, ordered by the best match first
 	for i, uc := range upload.UploadColumns {
 		uploadColumnName := strings.ToLower(strings.TrimSpace(uc.Name))
-		if uploadColumnName == "" {
-			continue
-		}
+		learnedMapping := learnedMappings[model.NormalizeUploadColumnName(uc.Name)]
 
 		// Initialize the priority queue for this upload column
 		pq := make(PriorityMatchQueue, 0)
 		heap.Init(&pq)
 
-		// If a match exists from the service, add to the priority queue
-		if matchedTemplateName, ok := serviceMatches[uploadColumnName]; ok {
-			addMatchToQueueByName(&pq, matchedTemplateName, templateColumns, 0.95)
-		}
-
-		// Add other potential matches to the priority queue
 		for _, tc := range templateColumns {
-			templateColumnName := strings.ToLower(strings.TrimSpace(tc.Name))
-			if templateColumnName == "" {
-				continue
-			}
-
-			// Check suggested mappings for a match
-			suggestedMappingFound := false
-			for _, suggestedMapping := range tc.SuggestedMappings {
-				if uploadColumnName == strings.ToLower(suggestedMapping) {
-					updateMatchScore(&pq, &MatchScore{
-						templateColumnID: tc.ID,
-						score:            1.0,
-					})
-					suggestedMappingFound = true
-					break
-				}
-			}
-			if suggestedMappingFound {
-				continue // Suggested mapping found, carry on
+			nameScore := 0.0
+			if uploadColumnName != "" {
+				nameScore = nameMatchScore(uploadColumnName, tc, synonyms, serviceMatches[uploadColumnName], 0.95)
 			}
-
-			// Check for an exact match
-			if uploadColumnName == templateColumnName {
-				updateMatchScore(&pq, &MatchScore{
-					templateColumnID: tc.ID,
-					score:            1.0,
-				})
-				continue // Exact match found, carry on
-			}
-
-			// Calculate similarity score if no suggested or exact match was found
-			similarityScore := util.StringSimilarity(uploadColumnName, templateColumnName)
-			// Only add high confidence scores
-			if similarityScore > 0.9 {
-				updateMatchScore(&pq, &MatchScore{
-					templateColumnID: tc.ID,
-					score:            similarityScore,
-				})
+			valueScore, hasValueScore := valueMatchScore(uc.SampleData, tc)
+			tcLearnedMapping := lo.Ternary(learnedMapping != nil && learnedMapping.TemplateColumnKey == tc.Key, learnedMapping, nil)
+			if match := scoreMatch(tc, tcLearnedMapping, nameScore, valueScore, hasValueScore); match != nil {
+				heap.Push(&pq, match)
 			}
 		}
 
-		// After evaluating all matches, the best match is the one at the top of the priority queue
-		if pq.Len() > 0 {
-			bestMatch := heap.Pop(&pq).(*MatchScore)
-			potentialBestMatches[i] = *bestMatch
+		for pq.Len() > 0 {
+			match := heap.Pop(&pq).(*MatchScore)
+			candidates[i] = append(candidates[i], match)
+			uc.MatchCandidates = append(uc.MatchCandidates, &types.ColumnMatchCandidate{
+				TemplateColumnID: match.templateColumnID,
+				Confidence:       match.score,
+				Learned:          match.learned,
+				NameScore:        match.nameScore,
+				ValueScore:       match.valueScore,
+			})
 		}
 	}
 
-	// Second pass: Finalize the matches, ensuring each template column matches to only one upload column
+	// Second pass: Finalize the matches, ensuring each template column matches to only one upload column. The upload
+	// columns with the best matches are finalized first, so a match on the values alone can't take a template column
+	// from an upload column whose name matches it, nor a name match one from a learned match.
+	order := lo.Range(len(upload.UploadColumns))
+	sort.SliceStable(order, func(a, b int) bool {
+		return isBetterBestMatch(candidates[order[a]], candidates[order[b]])
+	})
 	matchedTemplateColumnIDs := make(map[string]bool)
-	for i, bestMatch := range potentialBestMatches {
-		if !bestMatch.templateColumnID.Valid || matchedTemplateColumnIDs[bestMatch.templateColumnID.String()] {
-			continue // Skip if already matched or no match was found
+	for _, i := range order {
+		available := lo.Filter(candidates[i], func(m *MatchScore, _ int) bool {
+			return !matchedTemplateColumnIDs[m.templateColumnID.String()]
+		})
+		if len(available) == 0 {
+			continue // Skip if no match was found or all the matches are taken
+		}
+		bestMatch := available[0]
+		if bestMatch.isValueOnly() && len(available) > 1 && available[1].score == bestMatch.score {
+			continue // Skip if the values match several template columns equally, as any choice would be a guess
 		}
 
 		// Finalize the match
@@ -572,3 +634,11 @@ func AddColumnMappingSuggestions(upload *types.Upload, templateColumns []*model.
 		matchedTemplateColumnIDs[bestMatch.templateColumnID.String()] = true
 	}
 }
+
+// isBetterBestMatch returns true if the first of the matches ranks above the first of the other matches
+func isBetterBestMatch(matches, otherMatches []*MatchScore) bool {
+	if len(matches) == 0 || len(otherMatches) == 0 {
+		return len(matches) != 0
+	}
+	return matches[0].isBetterThan(*otherMatches[0])
+}
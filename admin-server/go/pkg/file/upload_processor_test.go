@@ -0,0 +1,67 @@
+package file
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComposeHeaderNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		headerRows [][]string
+		want       []string
+	}{
+		{
+			name:       "single row",
+			headerRows: [][]string{{"Name", " Email ", ""}},
+			want:       []string{"Name", " Email ", ""},
+		},
+		{
+			name:       "merged cells across columns",
+			headerRows: [][]string{{"2023", "", "2024", ""}, {"Q1", "Q2", "Q1", "Q2"}},
+			want:       []string{"2023 Q1", "2023 Q2", "2024 Q1", "2024 Q2"},
+		},
+		{
+			name:       "blank cell above the first column",
+			headerRows: [][]string{{"", "Revenue", ""}, {"Name", "Q1", "Q2"}},
+			want:       []string{"Name", "Revenue Q1", "Revenue Q2"},
+		},
+		{
+			name:       "merged cells across rows",
+			headerRows: [][]string{{"ID", "Sales", ""}, {"", "Q1", "Q2"}},
+			want:       []string{"ID", "Sales Q1", "Sales Q2"},
+		},
+		{
+			name:       "same cell in each row",
+			headerRows: [][]string{{"Name", "Total"}, {"Name", "Amount"}},
+			want:       []string{"Name", "Total Amount"},
+		},
+		{
+			name:       "three rows",
+			headerRows: [][]string{{"2023", "", ""}, {"H1", "", "H2"}, {"Jan", "Feb", "Jul"}},
+			want:       []string{"2023 H1 Jan", "2023 H1 Feb", "2023 H2 Jul"},
+		},
+		{
+			name:       "blank cells aren't filled across groups",
+			headerRows: [][]string{{"A", "B"}, {"x", ""}, {"1", "2"}},
+			want:       []string{"A x 1", "B 2"},
+		},
+		{
+			name:       "rows of different lengths",
+			headerRows: [][]string{{"Contact"}, {"Name", "Email"}},
+			want:       []string{"Contact Name", "Contact Email"},
+		},
+		{
+			name:       "whitespace is trimmed",
+			headerRows: [][]string{{" Billing ", ""}, {" City", "Zip "}},
+			want:       []string{"Billing City", "Billing Zip"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComposeHeaderNames(tt.headerRows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ComposeHeaderNames(%q) = %q, want %q", tt.headerRows, got, tt.want)
+			}
+		})
+	}
+}
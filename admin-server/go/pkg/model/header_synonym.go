@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// HeaderSynonym is a group of headers that name the same column, in any language, managed on a workspace. An upload
+// column whose header is in the group is matched to the template columns whose name or key is in the group. The
+// groups extend the built-in dictionary of common fields.
+type HeaderSynonym struct {
+	ID            ID             `json:"id" swaggertype:"string" example:"0c1a3f5e-2a4b-4e6f-9b8d-7c6e5f4d3b2a"`
+	WorkspaceID   ID             `json:"workspace_id" swaggertype:"string" example:"b2079476-261a-41fe-8019-46eb51c537f7"`
+	Term          string         `json:"term" example:"first name"`
+	Synonyms      pq.StringArray `json:"synonyms" gorm:"type:text[]" swaggertype:"array,string" example:"vorname,nombre,prénom"`
+	CreatedBy     ID             `json:"-"`
+	CreatedByUser *User          `json:"created_by,omitempty" gorm:"foreignKey:ID;references:CreatedBy"`
+	CreatedAt     NullTime       `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	UpdatedBy     ID             `json:"-"`
+	UpdatedByUser *User          `json:"updated_by,omitempty" gorm:"foreignKey:ID;references:UpdatedBy"`
+	UpdatedAt     NullTime       `json:"updated_at" swaggertype:"integer" example:"1682366228"`
+	DeletedBy     ID             `json:"-"`
+	DeletedByUser *User          `json:"-" gorm:"foreignKey:ID;references:DeletedBy"`
+	DeletedAt     gorm.DeletedAt `json:"-"`
+}
+
+func (s *HeaderSynonym) BeforeCreate(_ *gorm.DB) (err error) {
+	if !s.ID.Valid {
+		s.ID = NewID()
+	}
+	if s.Synonyms == nil {
+		s.Synonyms = pq.StringArray{}
+	}
+	return
+}
+
+// ParseHeaderSynonymTerm trims the term of a header synonym group, which can't be blank
+func ParseHeaderSynonymTerm(term string) (string, error) {
+	term = strings.TrimSpace(term)
+	if len(util.FoldText(term)) == 0 {
+		return "", errors.New("The term cannot be blank")
+	}
+	return term, nil
+}
+
+// ParseSynonyms trims the synonyms of a header synonym group or a template column, which are compared after folding
+// accents, case and word separators, so they can't be blank or the same once folded
+func ParseSynonyms(synonyms []string) ([]string, error) {
+	parsed := make([]string, 0, len(synonyms))
+	seen := make(map[string]string)
+	for _, synonym := range synonyms {
+		synonym = strings.TrimSpace(synonym)
+		folded := util.FoldText(synonym)
+		if len(folded) == 0 {
+			return nil, errors.New("The synonyms cannot contain blank values")
+		}
+		if other, ok := seen[folded]; ok {
+			return nil, fmt.Errorf("The synonyms '%s' and '%s' are the same", other, synonym)
+		}
+		seen[folded] = synonym
+		parsed = append(parsed, synonym)
+	}
+	return parsed, nil
+}
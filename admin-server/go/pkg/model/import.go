@@ -16,10 +16,14 @@ type Import struct {
 	NumProcessedValues null.Int       `json:"num_processed_values" swaggertype:"integer" example:"128"`
 	Metadata           jsonb.JSONB    `json:"metadata"`
 	DataTypes          jsonb.JSONB    `json:"data_types"`
+	ColumnFormats      jsonb.JSONB    `json:"column_formats"`
+	ListDelimiters     jsonb.JSONB    `json:"list_delimiters"`
 	IsStored           bool           `json:"is_stored" example:"false"`
 	IsComplete         bool           `json:"is_complete" example:"false"`
 	NumErrorRows       null.Int       `json:"num_error_rows" swaggertype:"integer" example:"32"`
 	NumValidRows       null.Int       `json:"num_valid_rows" swaggertype:"integer" example:"224"`
+	NumWarningRows     null.Int       `json:"num_warning_rows" swaggertype:"integer" example:"12"`
+	NumInfoRows        null.Int       `json:"num_info_rows" swaggertype:"integer" example:"4"`
 	CreatedAt          NullTime       `json:"created_at" swaggertype:"integer" example:"1682366228"`
 	UpdatedAt          NullTime       `json:"updated_at" swaggertype:"integer" example:"1682366228"`
 	DeletedAt          gorm.DeletedAt `json:"-"`
@@ -38,6 +42,28 @@ func (i *Import) BeforeCreate(_ *gorm.DB) (err error) {
 	return
 }
 
+// HasErrors returns true if any rows have a failed validation with the error severity, which blocks the import from
+// being submitted. Warnings and info don't count as errors.
 func (i *Import) HasErrors() bool {
 	return i.NumErrorRows.Int64 != 0
 }
+
+// HasWarnings returns true if any rows have a failed validation with the warn or info severity
+func (i *Import) HasWarnings() bool {
+	return i.NumWarningRows.Int64 != 0 || i.NumInfoRows.Int64 != 0
+}
+
+// GetListDelimiters returns the delimiter of each list column key, which the stored values are split into elements with
+func (i *Import) GetListDelimiters() map[string]string {
+	listDelimiters := make(map[string]string)
+	delimitersRaw, ok := i.ListDelimiters.AsMap()
+	if !ok {
+		return listDelimiters
+	}
+	for k, v := range delimitersRaw {
+		if delimiter, ok := v.(string); ok && len(delimiter) != 0 {
+			listDelimiters[k] = delimiter
+		}
+	}
+	return listDelimiters
+}
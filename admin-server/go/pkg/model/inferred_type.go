@@ -0,0 +1,15 @@
+package model
+
+// InferredTypeEmail is inferred for columns of email addresses, which are strings with the email validation
+const InferredTypeEmail = "email"
+
+// ParseInferredType returns the data type of a type inferred from the values of an upload column, along with the
+// validate type to add to the column if the inferred type isn't a data type (i.e. email). Any data type is accepted so
+// the user can set a different type than the one inferred.
+func ParseInferredType(inferredType string) (TemplateColumnDataType, string, error) {
+	if inferredType == InferredTypeEmail {
+		return TemplateColumnDataTypeString, InferredTypeEmail, nil
+	}
+	dataType, err := ParseTemplateColumnDataType(inferredType)
+	return dataType, "", err
+}
@@ -0,0 +1,35 @@
+package model
+
+import "testing"
+
+func TestParseInferredType(t *testing.T) {
+	tests := []struct {
+		inferredType string
+		wantDataType TemplateColumnDataType
+		wantValidate string
+		wantErr      bool
+	}{
+		{inferredType: "email", wantDataType: TemplateColumnDataTypeString, wantValidate: "email"},
+		{inferredType: "string", wantDataType: TemplateColumnDataTypeString},
+		{inferredType: "number", wantDataType: TemplateColumnDataTypeNumber},
+		{inferredType: "integer", wantDataType: TemplateColumnDataTypeInteger},
+		{inferredType: "boolean", wantDataType: TemplateColumnDataTypeBoolean},
+		{inferredType: "date", wantDataType: TemplateColumnDataTypeDate},
+		{inferredType: "datetime", wantDataType: TemplateColumnDataTypeDatetime},
+		{inferredType: " Decimal ", wantDataType: TemplateColumnDataTypeDecimal},
+		{inferredType: "phone", wantErr: true},
+		{inferredType: "", wantDataType: TemplateColumnDataTypeString},
+		{inferredType: "EMAIL", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.inferredType, func(t *testing.T) {
+			dataType, validate, err := ParseInferredType(tt.inferredType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInferredType(%q) error = %v, wantErr %v", tt.inferredType, err, tt.wantErr)
+			}
+			if dataType != tt.wantDataType || validate != tt.wantValidate {
+				t.Errorf("ParseInferredType(%q) = %q, %q, want %q, %q", tt.inferredType, dataType, validate, tt.wantDataType, tt.wantValidate)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"strings"
+)
+
+// LearnedColumnMapping is a column mapping confirmed by a user of an importer, from the header of an upload column to
+// the key of a template column. It's suggested the next time a file with the same header is uploaded to the importer.
+// The key is stored rather than the template column ID, so mappings also apply to SDK-defined templates.
+type LearnedColumnMapping struct {
+	ID                ID       `json:"id" swaggertype:"string" example:"7b0e1d1c-2f5d-4a8f-8a51-4a7c2f8a6b3e"`
+	ImporterID        ID       `json:"importer_id" swaggertype:"string" example:"6de452a2-bd1f-4cb3-b29b-0f8a2e3d9353"`
+	UploadColumnName  string   `json:"upload_column_name" example:"e-mail address"`
+	TemplateColumnKey string   `json:"template_column_key" example:"email"`
+	TimesConfirmed    int      `json:"times_confirmed" example:"3"`
+	CreatedAt         NullTime `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	UpdatedAt         NullTime `json:"updated_at" swaggertype:"integer" example:"1682366228"`
+}
+
+// LearnedColumnMappingMinConfirmations is the number of times a mapping must be confirmed before it ranks above any
+// other match, so a single upload can't override the matches by name for everyone using the importer
+const LearnedColumnMappingMinConfirmations = 3
+
+// IsConfirmed returns true if the mapping was confirmed enough times to rank above any other match
+func (m *LearnedColumnMapping) IsConfirmed() bool {
+	return m.TimesConfirmed >= LearnedColumnMappingMinConfirmations
+}
+
+// NormalizeUploadColumnName normalizes the header of an upload column for matching, so differences in case and
+// whitespace are ignored
+func NormalizeUploadColumnName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"tableflow/go/pkg/model/jsonb"
+)
+
+// LookupTable is a workspace-managed table uploaded as a CSV, used by the lookup validation to check cells against the
+// values of one of its columns
+type LookupTable struct {
+	ID            ID             `json:"id" swaggertype:"string" example:"3b0a3b1e-5a57-4d5f-b3c5-cd1b0a0dc0a3"`
+	WorkspaceID   ID             `json:"workspace_id" swaggertype:"string" example:"b2079476-261a-41fe-8019-46eb51c537f7"`
+	Name          string         `json:"name" example:"Products"`
+	Columns       pq.StringArray `json:"columns" gorm:"type:text[]" swaggertype:"array,string" example:"sku,name"`
+	NumRows       int            `json:"num_rows" example:"2500"`
+	Rows          jsonb.JSONB    `json:"-"`
+	CreatedBy     ID             `json:"-"`
+	CreatedByUser *User          `json:"created_by,omitempty" gorm:"foreignKey:ID;references:CreatedBy"`
+	CreatedAt     NullTime       `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	UpdatedBy     ID             `json:"-"`
+	UpdatedByUser *User          `json:"updated_by,omitempty" gorm:"foreignKey:ID;references:UpdatedBy"`
+	UpdatedAt     NullTime       `json:"updated_at" swaggertype:"integer" example:"1682366228"`
+	DeletedBy     ID             `json:"-"`
+	DeletedByUser *User          `json:"-" gorm:"foreignKey:ID;references:DeletedBy"`
+	DeletedAt     gorm.DeletedAt `json:"-"`
+}
+
+func (t *LookupTable) BeforeCreate(_ *gorm.DB) (err error) {
+	if !t.ID.Valid {
+		t.ID = NewID()
+	}
+	if t.Columns == nil {
+		t.Columns = pq.StringArray{}
+	}
+	return
+}
@@ -4,26 +4,38 @@ import (
 	"fmt"
 	"github.com/guregu/null"
 	"github.com/lib/pq"
+	"github.com/samber/lo"
 	"gorm.io/gorm"
 	"regexp"
 	"strings"
+	"tableflow/go/pkg/evaluator"
 )
 
 type TemplateColumnDataType string
 
 const (
-	TemplateColumnDataTypeString  TemplateColumnDataType = "string"
-	TemplateColumnDataTypeNumber  TemplateColumnDataType = "number"
-	TemplateColumnDataTypeBoolean TemplateColumnDataType = "boolean"
-	TemplateColumnDataTypeDate    TemplateColumnDataType = "date"
+	TemplateColumnDataTypeString   TemplateColumnDataType = "string"
+	TemplateColumnDataTypeNumber   TemplateColumnDataType = "number"
+	TemplateColumnDataTypeBoolean  TemplateColumnDataType = "boolean"
+	TemplateColumnDataTypeDate     TemplateColumnDataType = "date"
+	TemplateColumnDataTypeInteger  TemplateColumnDataType = "integer"
+	TemplateColumnDataTypeDecimal  TemplateColumnDataType = "decimal"
+	TemplateColumnDataTypeDatetime TemplateColumnDataType = "datetime"
+	TemplateColumnDataTypeEnum     TemplateColumnDataType = "enum"
+	TemplateColumnDataTypeJSON     TemplateColumnDataType = "json"
 )
 
 var validDataTypes = map[string]TemplateColumnDataType{
-	"":                                    TemplateColumnDataTypeString,
-	string(TemplateColumnDataTypeString):  TemplateColumnDataTypeString,
-	string(TemplateColumnDataTypeNumber):  TemplateColumnDataTypeNumber,
-	string(TemplateColumnDataTypeBoolean): TemplateColumnDataTypeBoolean,
-	string(TemplateColumnDataTypeDate):    TemplateColumnDataTypeDate,
+	"":                                     TemplateColumnDataTypeString,
+	string(TemplateColumnDataTypeString):   TemplateColumnDataTypeString,
+	string(TemplateColumnDataTypeNumber):   TemplateColumnDataTypeNumber,
+	string(TemplateColumnDataTypeBoolean):  TemplateColumnDataTypeBoolean,
+	string(TemplateColumnDataTypeDate):     TemplateColumnDataTypeDate,
+	string(TemplateColumnDataTypeInteger):  TemplateColumnDataTypeInteger,
+	string(TemplateColumnDataTypeDecimal):  TemplateColumnDataTypeDecimal,
+	string(TemplateColumnDataTypeDatetime): TemplateColumnDataTypeDatetime,
+	string(TemplateColumnDataTypeEnum):     TemplateColumnDataTypeEnum,
+	string(TemplateColumnDataTypeJSON):     TemplateColumnDataTypeJSON,
 }
 
 func ParseTemplateColumnDataType(dataType string) (TemplateColumnDataType, error) {
@@ -36,24 +48,30 @@ func ParseTemplateColumnDataType(dataType string) (TemplateColumnDataType, error
 }
 
 type TemplateColumn struct {
-	ID                ID                     `json:"id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
-	TemplateID        ID                     `json:"template_id" swaggertype:"string" example:"f0797968-becc-422a-b135-19de1d8c5d46"`
-	Name              string                 `json:"name" example:"Email"`
-	Key               string                 `json:"key" example:"email"`
-	Required          bool                   `json:"required" example:"false"`
-	DataType          TemplateColumnDataType `json:"data_type" swaggertype:"string" example:"string"`
-	Description       null.String            `json:"description" swaggertype:"string" example:"An email address"`
-	SuggestedMappings pq.StringArray         `json:"suggested_mappings" gorm:"type:text[]" swaggertype:"array,string" example:"first_name"`
-	Index             null.Int               `json:"index" swaggertype:"integer" example:"0"`
-	CreatedBy         ID                     `json:"-"`
-	CreatedByUser     *User                  `json:"created_by,omitempty" gorm:"foreignKey:ID;references:CreatedBy"`
-	CreatedAt         NullTime               `json:"created_at" swaggertype:"integer" example:"1682366228"`
-	UpdatedBy         ID                     `json:"-"`
-	UpdatedByUser     *User                  `json:"updated_by,omitempty" gorm:"foreignKey:ID;references:UpdatedBy"`
-	UpdatedAt         NullTime               `json:"updated_at" swaggertype:"integer" example:"1682366228"`
-	DeletedBy         ID                     `json:"-"`
-	DeletedByUser     *User                  `json:"-" gorm:"foreignKey:ID;references:DeletedBy"`
-	DeletedAt         gorm.DeletedAt         `json:"-"`
+	ID                ID                       `json:"id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+	TemplateID        ID                       `json:"template_id" swaggertype:"string" example:"f0797968-becc-422a-b135-19de1d8c5d46"`
+	Name              string                   `json:"name" example:"Email"`
+	Key               string                   `json:"key" example:"email"`
+	Required          bool                     `json:"required" example:"false"`
+	DataType          TemplateColumnDataType   `json:"data_type" swaggertype:"string" example:"string"`
+	Description       null.String              `json:"description" swaggertype:"string" example:"An email address"`
+	SuggestedMappings pq.StringArray           `json:"suggested_mappings" gorm:"type:text[]" swaggertype:"array,string" example:"first_name"`
+	Synonyms          pq.StringArray           `json:"synonyms" gorm:"type:text[]" swaggertype:"array,string" example:"vorname,nombre"`
+	Index             null.Int                 `json:"index" swaggertype:"integer" example:"0"`
+	Transforms        TemplateColumnTransforms `json:"transforms" gorm:"type:jsonb"`
+	DefaultValue      *TemplateColumnDefault   `json:"default_value" gorm:"type:jsonb"`
+	Formula           null.String              `json:"formula" swaggertype:"string" example:"first_name + ' ' + last_name"`
+	IsList            bool                     `json:"is_list" example:"false"`
+	Delimiter         string                   `json:"delimiter" example:";"`
+	CreatedBy         ID                       `json:"-"`
+	CreatedByUser     *User                    `json:"created_by,omitempty" gorm:"foreignKey:ID;references:CreatedBy"`
+	CreatedAt         NullTime                 `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	UpdatedBy         ID                       `json:"-"`
+	UpdatedByUser     *User                    `json:"updated_by,omitempty" gorm:"foreignKey:ID;references:UpdatedBy"`
+	UpdatedAt         NullTime                 `json:"updated_at" swaggertype:"integer" example:"1682366228"`
+	DeletedBy         ID                       `json:"-"`
+	DeletedByUser     *User                    `json:"-" gorm:"foreignKey:ID;references:DeletedBy"`
+	DeletedAt         gorm.DeletedAt           `json:"-"`
 
 	Validations []*Validation `json:"validations"`
 }
@@ -65,6 +83,12 @@ func (tc *TemplateColumn) BeforeCreate(_ *gorm.DB) (err error) {
 	if tc.SuggestedMappings == nil {
 		tc.SuggestedMappings = pq.StringArray{}
 	}
+	if tc.Synonyms == nil {
+		tc.Synonyms = pq.StringArray{}
+	}
+	if tc.Transforms == nil {
+		tc.Transforms = TemplateColumnTransforms{}
+	}
 	return
 }
 
@@ -77,3 +101,56 @@ func IsValidTemplateColumnKey(key string) bool {
 	match, _ := regexp.MatchString(pattern, key)
 	return match
 }
+
+// CellValidations returns the validations to perform on each cell of the column, ordered with the data type validations
+// first. Required columns imply a not_blank validation, which is added unless the column already has one.
+func (tc *TemplateColumn) CellValidations() []*Validation {
+	validations := make([]*Validation, 0, len(tc.Validations)+1)
+	validations = append(validations, tc.Validations...)
+	SortValidations(validations)
+	hasNotBlank := lo.ContainsBy(validations, func(v *Validation) bool {
+		return v.Validate == "not_blank" && v.Severity == ValidationSeverityError
+	})
+	if tc.Required && !hasNotBlank {
+		validations = append(validations, NewRequiredValidation(tc.ID))
+	}
+	return validations
+}
+
+// ValidateReferencedKeys checks that the keys referenced by the row validations of the template columns (i.e. the
+// cross-column rules) exist on the template, i.e. after a column key is changed or a column is deleted
+func ValidateReferencedKeys(templateColumns []*TemplateColumn) error {
+	keys := lo.SliceToMap(templateColumns, func(tc *TemplateColumn) (string, bool) {
+		return tc.Key, true
+	})
+	for _, tc := range templateColumns {
+		for _, v := range tc.Validations {
+			for _, key := range v.ReferencedKeys() {
+				if !keys[key] {
+					return fmt.Errorf("The %s validation on the column %s references the key %s, which does not exist", v.Validate, tc.Key, key)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetDateParsers passes the date and datetime validations of the template columns to the row validations that compare
+// dates, so the dates are parsed with the formats configured on their columns
+func SetDateParsers(templateColumns []*TemplateColumn) {
+	parsers := make(map[string]evaluator.DateParser)
+	for _, tc := range templateColumns {
+		for _, v := range tc.Validations {
+			if parser, ok := v.Evaluator.(evaluator.DateParser); ok && evaluator.IsDataTypeEvaluator(v.Validate) {
+				parsers[tc.Key] = parser
+			}
+		}
+	}
+	for _, tc := range templateColumns {
+		for _, v := range tc.Validations {
+			if dateComparer, ok := v.Evaluator.(evaluator.DateComparer); ok {
+				dateComparer.SetDateParsers(tc.Key, parsers)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/util"
+	"time"
+)
+
+const (
+	TemplateColumnDefaultTypeValue     = "value"
+	TemplateColumnDefaultTypeMetadata  = "metadata"
+	TemplateColumnDefaultTypeUUID      = "uuid"
+	TemplateColumnDefaultTypeTimestamp = "timestamp"
+)
+
+// TemplateColumnDefault is set on the blank cells of a template column, and on every row if the column isn't mapped.
+// Defaults are set before the validations, so they are validated like any other value.
+//
+//	{"type": "value", "value": "Active"}       A literal value
+//	{"type": "metadata", "key": "tenant.id"}   A value from the import metadata, nested keys are separated by dots
+//	{"type": "uuid"}                           A new UUID for each row
+//	{"type": "timestamp"}                      The time the import was processed, formatted as RFC3339
+type TemplateColumnDefault struct {
+	Type    string `json:"type" example:"value"`
+	Literal string `json:"value,omitempty" example:"Active"`
+	Key     string `json:"key,omitempty" example:"tenant.id"`
+}
+
+func ParseTemplateColumnDefault(defaultType, value, key string) (*TemplateColumnDefault, error) {
+	d := &TemplateColumnDefault{
+		Type: strings.TrimSpace(strings.ToLower(defaultType)),
+	}
+	switch d.Type {
+	case TemplateColumnDefaultTypeValue:
+		if util.IsBlankUnicode(value) {
+			return nil, errors.New("The default value cannot be blank")
+		}
+		d.Literal = value
+	case TemplateColumnDefaultTypeMetadata:
+		d.Key = strings.TrimSpace(key)
+		if lo.Contains(strings.Split(d.Key, "."), "") {
+			return nil, errors.New("The default metadata key is invalid")
+		}
+	case TemplateColumnDefaultTypeUUID, TemplateColumnDefaultTypeTimestamp:
+	default:
+		return nil, fmt.Errorf("The default type %v is invalid, it must be %s, %s, %s or %s", defaultType,
+			TemplateColumnDefaultTypeValue, TemplateColumnDefaultTypeMetadata, TemplateColumnDefaultTypeUUID, TemplateColumnDefaultTypeTimestamp)
+	}
+	return d, nil
+}
+
+// ParseTemplateColumnDefaultFromMap parses a default from a JSON object, i.e. from an SDK-defined template
+func ParseTemplateColumnDefaultFromMap(raw interface{}) (*TemplateColumnDefault, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	defaultMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("The default must be an object with a type")
+	}
+	defaultType, _ := defaultMap["type"].(string)
+	value, _ := defaultMap["value"].(string)
+	key, _ := defaultMap["key"].(string)
+	return ParseTemplateColumnDefault(defaultType, value, key)
+}
+
+// Generate returns the default for a cell of an import with the provided metadata
+func (d *TemplateColumnDefault) Generate(metadata jsonb.JSONB, importedAt time.Time) string {
+	switch d.Type {
+	case TemplateColumnDefaultTypeValue:
+		return d.Literal
+	case TemplateColumnDefaultTypeMetadata:
+		return metadataValue(metadata, d.Key)
+	case TemplateColumnDefaultTypeUUID:
+		return NewID().String()
+	case TemplateColumnDefaultTypeTimestamp:
+		return importedAt.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+// metadataValue returns the value of a key in the import metadata as a string, or blank if the key doesn't exist
+func metadataValue(metadata jsonb.JSONB, key string) string {
+	data, ok := metadata.AsMap()
+	if !ok {
+		return ""
+	}
+	var value interface{} = data
+	for _, part := range strings.Split(key, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if value, ok = m[part]; !ok {
+			return ""
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		jsonBytes, _ := json.Marshal(v)
+		return string(jsonBytes)
+	}
+}
+
+func (d TemplateColumnDefault) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *TemplateColumnDefault) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return errors.New("type assertion failed for template column default")
+	}
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"reflect"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+	"time"
+)
+
+func TestParseTemplateColumnDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultType string
+		value       string
+		key         string
+		want        *TemplateColumnDefault
+		wantErr     bool
+	}{
+		{name: "value", defaultType: "value", value: "Active", want: &TemplateColumnDefault{Type: "value", Literal: "Active"}},
+		{name: "type case-insensitive", defaultType: " VALUE ", value: "Active", want: &TemplateColumnDefault{Type: "value", Literal: "Active"}},
+		{name: "blank value", defaultType: "value", value: " ", wantErr: true},
+		{name: "metadata", defaultType: "metadata", key: " tenant.id ", want: &TemplateColumnDefault{Type: "metadata", Key: "tenant.id"}},
+		{name: "blank metadata key", defaultType: "metadata", key: "", wantErr: true},
+		{name: "empty nested metadata key", defaultType: "metadata", key: "tenant..id", wantErr: true},
+		{name: "uuid", defaultType: "uuid", value: "ignored", want: &TemplateColumnDefault{Type: "uuid"}},
+		{name: "timestamp", defaultType: "timestamp", want: &TemplateColumnDefault{Type: "timestamp"}},
+		{name: "invalid type", defaultType: "random", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTemplateColumnDefault(tt.defaultType, tt.value, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTemplateColumnDefault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTemplateColumnDefault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTemplateColumnDefaultFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    *TemplateColumnDefault
+		wantErr bool
+	}{
+		{name: "no default", raw: nil, want: nil},
+		{name: "value", raw: map[string]interface{}{"type": "value", "value": "Active"}, want: &TemplateColumnDefault{Type: "value", Literal: "Active"}},
+		{name: "metadata", raw: map[string]interface{}{"type": "metadata", "key": "tenant.id"}, want: &TemplateColumnDefault{Type: "metadata", Key: "tenant.id"}},
+		{name: "missing type", raw: map[string]interface{}{"value": "Active"}, wantErr: true},
+		{name: "not an object", raw: "Active", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTemplateColumnDefaultFromMap(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTemplateColumnDefaultFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTemplateColumnDefaultFromMap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateColumnDefaultGenerate(t *testing.T) {
+	metadata := jsonb.FromMap(map[string]interface{}{
+		"source": "sdk",
+		"tenant": map[string]interface{}{"id": float64(42), "active": true, "tags": []interface{}{"a", "b"}, "region": nil},
+	})
+	importedAt := time.Date(2024, 3, 15, 9, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	tests := []struct {
+		name     string
+		d        TemplateColumnDefault
+		metadata jsonb.JSONB
+		want     string
+	}{
+		{name: "value", d: TemplateColumnDefault{Type: "value", Literal: "Active"}, metadata: metadata, want: "Active"},
+		{name: "metadata string", d: TemplateColumnDefault{Type: "metadata", Key: "source"}, metadata: metadata, want: "sdk"},
+		{name: "nested metadata number", d: TemplateColumnDefault{Type: "metadata", Key: "tenant.id"}, metadata: metadata, want: "42"},
+		{name: "metadata boolean", d: TemplateColumnDefault{Type: "metadata", Key: "tenant.active"}, metadata: metadata, want: "true"},
+		{name: "metadata array as JSON", d: TemplateColumnDefault{Type: "metadata", Key: "tenant.tags"}, metadata: metadata, want: `["a","b"]`},
+		{name: "metadata null", d: TemplateColumnDefault{Type: "metadata", Key: "tenant.region"}, metadata: metadata, want: ""},
+		{name: "missing metadata key", d: TemplateColumnDefault{Type: "metadata", Key: "tenant.name"}, metadata: metadata, want: ""},
+		{name: "key nested in a value", d: TemplateColumnDefault{Type: "metadata", Key: "source.id"}, metadata: metadata, want: ""},
+		{name: "no metadata", d: TemplateColumnDefault{Type: "metadata", Key: "source"}, metadata: jsonb.NewNull(), want: ""},
+		{name: "timestamp in UTC", d: TemplateColumnDefault{Type: "timestamp"}, metadata: metadata, want: "2024-03-15T14:30:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Generate(tt.metadata, importedAt); got != tt.want {
+				t.Errorf("Generate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	uuidDefault := TemplateColumnDefault{Type: "uuid"}
+	first, second := uuidDefault.Generate(metadata, importedAt), uuidDefault.Generate(metadata, importedAt)
+	if !ParseID(first).Valid || first == second {
+		t.Errorf("Generate() = %q, %q, want a new UUID each time", first, second)
+	}
+}
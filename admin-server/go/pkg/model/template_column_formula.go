@@ -0,0 +1,107 @@
+package model
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"tableflow/go/pkg/expr"
+)
+
+// formulaReservedIdentifiers are the variables available to formulas that aren't template column keys
+var formulaReservedIdentifiers = []string{"row"}
+
+// ComputedColumn is a template column with a formula, which is computed from the other cells in the row instead of
+// being mapped from the file. Formulas are expressions, i.e. first_name + " " + last_name or qty * price.
+type ComputedColumn struct {
+	TemplateColumn *TemplateColumn
+	Program        *expr.Program
+}
+
+// IsComputed returns true if the template column is computed from a formula
+func (tc *TemplateColumn) IsComputed() bool {
+	return tc.Formula.Valid && len(tc.Formula.String) != 0
+}
+
+// ReferencedKeys returns the template column keys used by the formula
+func (cc ComputedColumn) ReferencedKeys() []string {
+	return lo.Without(cc.Program.Identifiers(), formulaReservedIdentifiers...)
+}
+
+// Compute evaluates the formula with the values of the row. Referenced columns that aren't mapped are treated as blank.
+func (cc ComputedColumn) Compute(row map[string]string) (string, error) {
+	rowVars := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		rowVars[k] = v
+	}
+	vars := map[string]interface{}{
+		"row": rowVars,
+	}
+	for _, key := range cc.ReferencedKeys() {
+		vars[key] = row[key]
+	}
+	value, err := cc.Program.Eval(vars, expr.DefaultLimits)
+	if err != nil {
+		return "", err
+	}
+	return expr.ToString(value), nil
+}
+
+// ParseComputedColumns compiles the formulas of the computed template columns, returning them in the order they must
+// be computed so formulas can reference other computed columns. The keys referenced must exist on the template and
+// can't reference the column itself, directly or through other computed columns.
+func ParseComputedColumns(templateColumns []*TemplateColumn) ([]ComputedColumn, error) {
+	keys := lo.SliceToMap(templateColumns, func(tc *TemplateColumn) (string, bool) {
+		return tc.Key, true
+	})
+	computedByKey := make(map[string]ComputedColumn)
+	for _, tc := range templateColumns {
+		if !tc.IsComputed() {
+			continue
+		}
+		program, err := expr.Compile(tc.Formula.String)
+		if err != nil {
+			return nil, fmt.Errorf("The formula on the column %s is invalid: %s", tc.Key, err.Error())
+		}
+		cc := ComputedColumn{TemplateColumn: tc, Program: program}
+		for _, key := range cc.ReferencedKeys() {
+			if !keys[key] {
+				return nil, fmt.Errorf("The formula on the column %s references the key %s, which does not exist", tc.Key, key)
+			}
+		}
+		computedByKey[tc.Key] = cc
+	}
+
+	// Order the computed columns so each is computed after the computed columns it references
+	ordered := make([]ComputedColumn, 0, len(computedByKey))
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var visit func(cc ComputedColumn) error
+	visit = func(cc ComputedColumn) error {
+		key := cc.TemplateColumn.Key
+		if visited[key] {
+			return nil
+		}
+		if visiting[key] {
+			return fmt.Errorf("The formula on the column %s has a circular reference to itself", key)
+		}
+		visiting[key] = true
+		for _, referencedKey := range cc.ReferencedKeys() {
+			if referenced, ok := computedByKey[referencedKey]; ok {
+				if err := visit(referenced); err != nil {
+					return err
+				}
+			}
+		}
+		visiting[key] = false
+		visited[key] = true
+		ordered = append(ordered, cc)
+		return nil
+	}
+	for _, tc := range templateColumns {
+		if cc, ok := computedByKey[tc.Key]; ok {
+			if err := visit(cc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"github.com/guregu/null"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func formulaColumn(key, formula string) *TemplateColumn {
+	return &TemplateColumn{Key: key, Formula: null.StringFrom(formula)}
+}
+
+func TestParseComputedColumns(t *testing.T) {
+	tests := []struct {
+		name            string
+		templateColumns []*TemplateColumn
+		wantKeys        []string
+		wantErr         string
+	}{
+		{
+			name:            "no computed columns",
+			templateColumns: []*TemplateColumn{{Key: "first_name"}, {Key: "last_name", Formula: null.StringFrom("")}},
+			wantKeys:        []string{},
+		},
+		{
+			name: "template order",
+			templateColumns: []*TemplateColumn{
+				{Key: "first_name"}, {Key: "last_name"}, {Key: "qty"}, {Key: "price"},
+				formulaColumn("full_name", "first_name + ' ' + last_name"),
+				formulaColumn("total", "qty * price"),
+			},
+			wantKeys: []string{"full_name", "total"},
+		},
+		{
+			name: "computed after the computed columns it references",
+			templateColumns: []*TemplateColumn{
+				{Key: "qty"}, {Key: "price"},
+				formulaColumn("total_with_tax", "total * 1.2"),
+				formulaColumn("label", "string(total_with_tax) + ' (' + string(total) + ')'"),
+				formulaColumn("total", "qty * price"),
+			},
+			wantKeys: []string{"total", "total_with_tax", "label"},
+		},
+		{
+			name:            "row variable",
+			templateColumns: []*TemplateColumn{{Key: "name"}, formulaColumn("has_name", "row.name != ''")},
+			wantKeys:        []string{"has_name"},
+		},
+		{
+			name:            "invalid formula",
+			templateColumns: []*TemplateColumn{{Key: "qty"}, formulaColumn("total", "qty *")},
+			wantErr:         "The formula on the column total is invalid",
+		},
+		{
+			name:            "unknown key",
+			templateColumns: []*TemplateColumn{{Key: "qty"}, formulaColumn("total", "qty * price")},
+			wantErr:         "references the key price, which does not exist",
+		},
+		{
+			name:            "references itself",
+			templateColumns: []*TemplateColumn{formulaColumn("total", "total + 1")},
+			wantErr:         "The formula on the column total has a circular reference to itself",
+		},
+		{
+			name: "circular reference through another column",
+			templateColumns: []*TemplateColumn{
+				formulaColumn("a", "b + 1"),
+				formulaColumn("b", "c + 1"),
+				formulaColumn("c", "a + 1"),
+			},
+			wantErr: "has a circular reference to itself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			computedColumns, err := ParseComputedColumns(tt.templateColumns)
+			if len(tt.wantErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseComputedColumns() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseComputedColumns() returned error: %v", err)
+			}
+			keys := make([]string, 0, len(computedColumns))
+			for _, cc := range computedColumns {
+				keys = append(keys, cc.TemplateColumn.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("ParseComputedColumns() keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestComputedColumnCompute(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		row     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "concatenation", formula: "first_name + ' ' + last_name", row: map[string]string{"first_name": "Mary", "last_name": "Jenkins"}, want: "Mary Jenkins"},
+		{name: "numeric cells", formula: "qty * price", row: map[string]string{"qty": "3", "price": "2.5"}, want: "7.5"},
+		{name: "row variable", formula: "row['first_name']", row: map[string]string{"first_name": "Mary"}, want: "Mary"},
+		{name: "missing key is blank", formula: "first_name + last_name", row: map[string]string{"first_name": "Mary"}, want: "Mary"},
+		{name: "boolean", formula: "qty > 2", row: map[string]string{"qty": "3"}, want: "true"},
+		{name: "non-numeric cell", formula: "qty * price", row: map[string]string{"qty": "three", "price": "2"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := formulaColumn("computed", tt.formula)
+			columns := []*TemplateColumn{{Key: "first_name"}, {Key: "last_name"}, {Key: "qty"}, {Key: "price"}, tc}
+			computedColumns, err := ParseComputedColumns(columns)
+			if err != nil {
+				t.Fatalf("ParseComputedColumns() returned error: %v", err)
+			}
+			got, err := computedColumns[0].Compute(tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Compute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// DefaultListDelimiter separates the elements of list columns if a delimiter isn't set
+const DefaultListDelimiter = ","
+
+// ListDelimiter returns the delimiter the cells of the column are split into elements with, or blank if the column
+// isn't a list
+func (tc *TemplateColumn) ListDelimiter() string {
+	if !tc.IsList {
+		return ""
+	}
+	if len(tc.Delimiter) == 0 {
+		return DefaultListDelimiter
+	}
+	return tc.Delimiter
+}
+
+// ValidateListColumn checks the list options of a template column. JSON columns can't be lists, as JSON arrays already
+// hold multiple values.
+func ValidateListColumn(isList bool, delimiter string, dataType TemplateColumnDataType) error {
+	if !isList {
+		if len(delimiter) != 0 {
+			return fmt.Errorf("The delimiter can only be set on list columns")
+		}
+		return nil
+	}
+	if dataType == TemplateColumnDataTypeJSON {
+		return fmt.Errorf("Columns with the %s data type can't be lists", dataType)
+	}
+	return nil
+}
+
+// SplitListCell splits the cell of a list column into its elements, which are trimmed, and blank elements are removed
+func SplitListCell(cell, delimiter string) []string {
+	elements := make([]string, 0)
+	if util.IsBlankUnicode(cell) {
+		return elements
+	}
+	for _, element := range strings.Split(cell, delimiter) {
+		element = strings.TrimSpace(element)
+		if len(element) != 0 {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// JoinListCell joins the elements of a list column cell so they can be split again with the delimiter. A space is
+// added after delimiters that aren't whitespace for readability, i.e. "red, blue, green".
+func JoinListCell(elements []string, delimiter string) string {
+	separator := delimiter
+	if len(strings.TrimSpace(delimiter)) == len(delimiter) {
+		separator += " "
+	}
+	return strings.Join(elements, separator)
+}
+
+// EvaluateList evaluates each element of a list column cell, returning the cell with the normalized elements and the
+// indexes of the elements that didn't pass. Cells without any elements are evaluated as a blank cell, so a required
+// list column must contain at least one element.
+func (v Validation) EvaluateList(cell, delimiter string) (bool, string, []int) {
+	return evaluateListElements(cell, delimiter, v.Evaluate)
+}
+
+// EvaluateRowList evaluates each element of a list column cell along with the other values in the row
+func (v Validation) EvaluateRowList(cell, delimiter string, row map[string]string) (bool, string, []int) {
+	return evaluateListElements(cell, delimiter, func(element string) (bool, string) {
+		return v.EvaluateRow(element, row)
+	})
+}
+
+// EvaluateCell evaluates the cell, or each element of the cell if a list delimiter is set. The indexes of the elements
+// that didn't pass are returned for list cells.
+func (v Validation) EvaluateCell(cell, listDelimiter string) (bool, string, []int) {
+	if len(listDelimiter) == 0 {
+		passed, value := v.Evaluate(cell)
+		return passed, value, nil
+	}
+	return v.EvaluateList(cell, listDelimiter)
+}
+
+// EvaluateRowCell evaluates the cell along with the other values in the row, or each element of the cell if a list
+// delimiter is set
+func (v Validation) EvaluateRowCell(cell, listDelimiter string, row map[string]string) (bool, string, []int) {
+	if len(listDelimiter) == 0 {
+		passed, value := v.EvaluateRow(cell, row)
+		return passed, value, nil
+	}
+	return v.EvaluateRowList(cell, listDelimiter, row)
+}
+
+func evaluateListElements(cell, delimiter string, evaluate func(string) (bool, string)) (bool, string, []int) {
+	elements := SplitListCell(cell, delimiter)
+	if len(elements) == 0 {
+		passed, value := evaluate("")
+		return passed, value, nil
+	}
+	var failedElements []int
+	for i, element := range elements {
+		passed, value := evaluate(element)
+		if !passed {
+			failedElements = append(failedElements, i)
+			continue
+		}
+		elements[i] = value
+	}
+	return len(failedElements) == 0, JoinListCell(elements, delimiter), failedElements
+}
+
+// ListElementsMessage adds the elements of a list column cell that didn't pass to the message of the validation, i.e.
+// "The cell must be a number (element 2: 'abc')"
+func ListElementsMessage(message string, elements []string, failedElements []int) string {
+	if len(failedElements) == 0 {
+		return message
+	}
+	described := make([]string, 0, len(failedElements))
+	for _, i := range failedElements {
+		described = append(described, fmt.Sprintf("%d: '%s'", i+1, elements[i]))
+	}
+	plural := ""
+	if len(failedElements) > 1 {
+		plural = "s"
+	}
+	return fmt.Sprintf("%s (element%s %s)", message, plural, strings.Join(described, ", "))
+}
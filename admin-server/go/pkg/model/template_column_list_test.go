@@ -0,0 +1,151 @@
+package model
+
+import (
+	"reflect"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+func TestTemplateColumnListDelimiter(t *testing.T) {
+	tests := []struct {
+		name string
+		tc   TemplateColumn
+		want string
+	}{
+		{name: "not a list", tc: TemplateColumn{Delimiter: ";"}, want: ""},
+		{name: "default delimiter", tc: TemplateColumn{IsList: true}, want: DefaultListDelimiter},
+		{name: "delimiter", tc: TemplateColumn{IsList: true, Delimiter: ";"}, want: ";"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tc.ListDelimiter(); got != tt.want {
+				t.Errorf("ListDelimiter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateListColumn(t *testing.T) {
+	tests := []struct {
+		name      string
+		isList    bool
+		delimiter string
+		dataType  TemplateColumnDataType
+		wantErr   bool
+	}{
+		{name: "not a list", dataType: TemplateColumnDataTypeString},
+		{name: "list", isList: true, delimiter: ";", dataType: TemplateColumnDataTypeNumber},
+		{name: "delimiter without a list", delimiter: ";", dataType: TemplateColumnDataTypeString, wantErr: true},
+		{name: "JSON list", isList: true, dataType: TemplateColumnDataTypeJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateListColumn(tt.isList, tt.delimiter, tt.dataType); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateListColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitListCell(t *testing.T) {
+	tests := []struct {
+		name      string
+		cell      string
+		delimiter string
+		want      []string
+	}{
+		{name: "trimmed", cell: "red, blue ,green", delimiter: ",", want: []string{"red", "blue", "green"}},
+		{name: "blank elements removed", cell: "red,, ,blue,", delimiter: ",", want: []string{"red", "blue"}},
+		{name: "multi-character delimiter", cell: "a || b||c", delimiter: "||", want: []string{"a", "b", "c"}},
+		{name: "single element", cell: " red ", delimiter: ";", want: []string{"red"}},
+		{name: "blank", cell: "  ", delimiter: ",", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitListCell(tt.cell, tt.delimiter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitListCell(%q, %q) = %q, want %q", tt.cell, tt.delimiter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinListCell(t *testing.T) {
+	tests := []struct {
+		elements  []string
+		delimiter string
+		want      string
+	}{
+		{elements: []string{"red", "blue", "green"}, delimiter: ",", want: "red, blue, green"},
+		{elements: []string{"a", "b"}, delimiter: "|", want: "a| b"},
+		{elements: []string{"a", "b"}, delimiter: "\n", want: "a\nb"},
+		{elements: []string{"a", "b"}, delimiter: " / ", want: "a / b"},
+		{elements: []string{"a"}, delimiter: ",", want: "a"},
+		{elements: []string{}, delimiter: ",", want: ""},
+	}
+	for _, tt := range tests {
+		if got := JoinListCell(tt.elements, tt.delimiter); got != tt.want {
+			t.Errorf("JoinListCell(%q, %q) = %q, want %q", tt.elements, tt.delimiter, got, tt.want)
+		}
+	}
+}
+
+func TestValidationEvaluateCellList(t *testing.T) {
+	options, err := jsonb.FromInterface([]interface{}{"Red", "Blue", "Green"})
+	if err != nil {
+		t.Fatalf("jsonb.FromInterface() returned error: %v", err)
+	}
+	listValidation, err := ParseValidation(1, "", "list", options, "", "", TemplateColumnDataTypeString)
+	if err != nil {
+		t.Fatalf("ParseValidation() returned error: %v", err)
+	}
+	notBlank, err := ParseValidation(2, "", "not_blank", jsonb.NewNull(), "", "", TemplateColumnDataTypeString)
+	if err != nil {
+		t.Fatalf("ParseValidation() returned error: %v", err)
+	}
+	tests := []struct {
+		name               string
+		v                  *Validation
+		cell               string
+		delimiter          string
+		wantPass           bool
+		wantValue          string
+		wantFailedElements []int
+	}{
+		{name: "not a list", v: listValidation, cell: "red", wantPass: true, wantValue: "Red"},
+		{name: "not a list with the delimiter", v: listValidation, cell: "red, blue", wantPass: false, wantValue: "red, blue"},
+		{name: "other delimiters are part of the element", v: listValidation, cell: "red,blue ; green", delimiter: ",", wantPass: false, wantValue: "Red, blue ; green", wantFailedElements: []int{1}},
+		{name: "every element passes", v: listValidation, cell: "red;BLUE;;", delimiter: ";", wantPass: true, wantValue: "Red; Blue"},
+		{name: "failed elements", v: listValidation, cell: "red, pink, purple", delimiter: ",", wantPass: false, wantValue: "Red, pink, purple", wantFailedElements: []int{1, 2}},
+		{name: "empty list evaluated as blank", v: notBlank, cell: " , ", delimiter: ",", wantPass: false, wantValue: ""},
+		{name: "non-empty list", v: notBlank, cell: "a,", delimiter: ",", wantPass: true, wantValue: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, value, failedElements := tt.v.EvaluateCell(tt.cell, tt.delimiter)
+			if passed != tt.wantPass || value != tt.wantValue || !reflect.DeepEqual(failedElements, tt.wantFailedElements) {
+				t.Errorf("EvaluateCell(%q, %q) = %v, %q, %v, want %v, %q, %v", tt.cell, tt.delimiter, passed, value, failedElements,
+					tt.wantPass, tt.wantValue, tt.wantFailedElements)
+			}
+		})
+	}
+}
+
+func TestListElementsMessage(t *testing.T) {
+	elements := []string{"red", "pink", "purple"}
+	tests := []struct {
+		name           string
+		failedElements []int
+		want           string
+	}{
+		{name: "no failed elements", failedElements: nil, want: "The cell must be a color"},
+		{name: "one element", failedElements: []int{1}, want: "The cell must be a color (element 2: 'pink')"},
+		{name: "several elements", failedElements: []int{1, 2}, want: "The cell must be a color (elements 2: 'pink', 3: 'purple')"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ListElementsMessage("The cell must be a color", elements, tt.failedElements); got != tt.want {
+				t.Errorf("ListElementsMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
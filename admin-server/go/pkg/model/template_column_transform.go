@@ -0,0 +1,104 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/transform"
+)
+
+// TemplateColumnTransform changes the cells of a template column before the validations are performed
+type TemplateColumnTransform struct {
+	Transform string      `json:"transform" example:"trim"`
+	Options   jsonb.JSONB `json:"options" swaggertype:"string" example:"null"`
+
+	Transformer transform.Transformer `json:"-"`
+}
+
+// TemplateColumnTransforms are applied in order, and stored as a JSON array on the template column
+type TemplateColumnTransforms []*TemplateColumnTransform
+
+func ParseTemplateColumnTransform(transformStr string, options jsonb.JSONB) (*TemplateColumnTransform, error) {
+	t := &TemplateColumnTransform{
+		Transform: transformStr,
+		Options:   options,
+	}
+	var err error
+	if t.Transformer, err = transform.Parse(transformStr, options); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ParseTemplateColumnTransforms parses transforms from a JSON array of objects, i.e. from an SDK-defined template
+func ParseTemplateColumnTransforms(raw interface{}) (TemplateColumnTransforms, error) {
+	transforms := make(TemplateColumnTransforms, 0)
+	if raw == nil {
+		return transforms, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, errors.New("transforms must be an array of objects")
+	}
+	for _, item := range items {
+		transformMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("transforms must be an array of objects")
+		}
+		transformStr, _ := transformMap["transform"].(string)
+		options, err := jsonb.FromInterface(transformMap["options"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s transform options: %s", transformStr, err.Error())
+		}
+		t, err := ParseTemplateColumnTransform(transformStr, options)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, t)
+	}
+	return transforms, nil
+}
+
+// Apply transforms the cell with each transform in order
+func (ts TemplateColumnTransforms) Apply(cell string) string {
+	for _, t := range ts {
+		if t.Transformer != nil {
+			cell = t.Transformer.Transform(cell)
+		}
+	}
+	return cell
+}
+
+func (ts TemplateColumnTransforms) Value() (driver.Value, error) {
+	if ts == nil {
+		return "[]", nil
+	}
+	return json.Marshal(ts)
+}
+
+func (ts *TemplateColumnTransforms) Scan(value interface{}) error {
+	var raw interface{}
+	switch v := value.(type) {
+	case nil:
+		*ts = make(TemplateColumnTransforms, 0)
+		return nil
+	case []byte:
+		if err := json.Unmarshal(v, &raw); err != nil {
+			return err
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &raw); err != nil {
+			return err
+		}
+	default:
+		return errors.New("type assertion failed for template column transforms")
+	}
+	transforms, err := ParseTemplateColumnTransforms(raw)
+	if err != nil {
+		return err
+	}
+	*ts = transforms
+	return nil
+}
@@ -8,26 +8,29 @@ import (
 )
 
 type Upload struct {
-	ID                    ID             `json:"id" swaggertype:"string" example:"50ca61e1-f683-4b03-9ec4-4b3adb592bf1"`
-	TusID                 string         `json:"tus_id" example:"ee715c254ee61855b465ed61be930487"`
-	ImporterID            ID             `json:"importer_id" swaggertype:"string" example:"6de452a2-bd1f-4cb3-b29b-0f8a2e3d9353"`
-	WorkspaceID           ID             `json:"workspace_id" swaggertype:"string" example:"b2079476-261a-41fe-8019-46eb51c537f7"`
-	FileName              null.String    `json:"file_name" swaggertype:"string" example:"example.csv"`
-	FileType              null.String    `json:"file_type" swaggertype:"string" example:"text/csv"`
-	FileExtension         null.String    `json:"file_extension" swaggertype:"string" example:"csv"`
-	FileSize              null.Int       `json:"file_size" swaggertype:"integer" example:"1024"`
-	NumRows               null.Int       `json:"num_rows" swaggertype:"integer" example:"256"`
-	NumColumns            null.Int       `json:"num_columns" swaggertype:"integer" example:"8"`
-	Template              jsonb.JSONB    `json:"template" swaggertype:"string" example:"{}"` // Set if the user passes in a template to the SDK (which overrides the template on the importer) or if a schemaless import occurs
-	Schemaless            bool           `json:"schemaless" example:"false"`
-	Metadata              jsonb.JSONB    `json:"metadata" swaggertype:"string" example:"{\"user_id\": 1234}"`
-	IsStored              bool           `json:"is_stored" example:"false"`
-	HeaderRowIndex        null.Int       `json:"header_row_index" swaggertype:"integer" example:"0"`
-	MatchedHeaderRowIndex null.Int       `json:"matched_header_row_index" swaggertype:"integer" example:"0"`
-	SheetList             pq.StringArray `json:"sheet_list" gorm:"type:text[]" swaggertype:"array,string" example:"Sheet 1"`
-	Error                 null.String    `json:"-" swaggerignore:"true"`
-	CreatedAt             NullTime       `json:"created_at" swaggertype:"integer" example:"1682366228"`
-	UpdatedAt             NullTime       `json:"updated_at" swaggertype:"integer" example:"1682366228"`
+	ID                         ID                   `json:"id" swaggertype:"string" example:"50ca61e1-f683-4b03-9ec4-4b3adb592bf1"`
+	TusID                      string               `json:"tus_id" example:"ee715c254ee61855b465ed61be930487"`
+	ImporterID                 ID                   `json:"importer_id" swaggertype:"string" example:"6de452a2-bd1f-4cb3-b29b-0f8a2e3d9353"`
+	WorkspaceID                ID                   `json:"workspace_id" swaggertype:"string" example:"b2079476-261a-41fe-8019-46eb51c537f7"`
+	FileName                   null.String          `json:"file_name" swaggertype:"string" example:"example.csv"`
+	FileType                   null.String          `json:"file_type" swaggertype:"string" example:"text/csv"`
+	FileExtension              null.String          `json:"file_extension" swaggertype:"string" example:"csv"`
+	FileSize                   null.Int             `json:"file_size" swaggertype:"integer" example:"1024"`
+	NumRows                    null.Int             `json:"num_rows" swaggertype:"integer" example:"256"`
+	NumColumns                 null.Int             `json:"num_columns" swaggertype:"integer" example:"8"`
+	Template                   jsonb.JSONB          `json:"template" swaggertype:"string" example:"{}"` // Set if the user passes in a template to the SDK (which overrides the template on the importer) or if a schemaless import occurs
+	Schemaless                 bool                 `json:"schemaless" example:"false"`
+	Metadata                   jsonb.JSONB          `json:"metadata" swaggertype:"string" example:"{\"user_id\": 1234}"`
+	IsStored                   bool                 `json:"is_stored" example:"false"`
+	HeaderRowIndex             null.Int             `json:"header_row_index" swaggertype:"integer" example:"0"`
+	HeaderRowEndIndex          null.Int             `json:"header_row_end_index" swaggertype:"integer" example:"1"` // The last header row, if the header spans several rows
+	MatchedHeaderRowIndex      null.Int             `json:"matched_header_row_index" swaggertype:"integer" example:"0"`
+	MatchedHeaderRowConfidence null.Float           `json:"matched_header_row_confidence" swaggertype:"number" example:"0.92"`
+	SheetList                  pq.StringArray       `json:"sheet_list" gorm:"type:text[]" swaggertype:"array,string" example:"Sheet 1"`
+	ColumnMappings             UploadColumnMappings `json:"column_mappings" gorm:"type:jsonb"` // The splits and merges set with the column mapping
+	Error                      null.String          `json:"-" swaggerignore:"true"`
+	CreatedAt                  NullTime             `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	UpdatedAt                  NullTime             `json:"updated_at" swaggertype:"integer" example:"1682366228"`
 
 	Importer      *Importer       `json:"importer,omitempty" swaggerignore:"true" gorm:"foreignKey:ID;references:ImporterID"`
 	UploadColumns []*UploadColumn `json:"upload_columns"`
@@ -42,3 +45,24 @@ func (u *Upload) BeforeCreate(_ *gorm.DB) (err error) {
 	}
 	return
 }
+
+// NumHeaderRows returns the number of rows the header spans, starting at the header row index. The names of the upload
+// columns are composed from the cells of the header rows.
+func (u *Upload) NumHeaderRows() int {
+	if !u.HeaderRowIndex.Valid {
+		return 0
+	}
+	if !u.HeaderRowEndIndex.Valid || u.HeaderRowEndIndex.Int64 < u.HeaderRowIndex.Int64 {
+		return 1
+	}
+	return int(u.HeaderRowEndIndex.Int64-u.HeaderRowIndex.Int64) + 1
+}
+
+// DataRowIndex returns the index of the first row after the header rows, where the data starts. If the header row
+// isn't set, the first row is treated as the header row.
+func (u *Upload) DataRowIndex() int {
+	if !u.HeaderRowIndex.Valid {
+		return 1
+	}
+	return int(u.HeaderRowIndex.Int64) + u.NumHeaderRows()
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"github.com/guregu/null"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
@@ -12,6 +13,11 @@ type UploadColumn struct {
 	Index            int            `json:"index" example:"0"`
 	SampleData       pq.StringArray `json:"sample_data" gorm:"type:text[]" swaggertype:"array,string" example:"test@example.com"`
 	TemplateColumnID ID             `json:"template_column_id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+
+	// The data type inferred from the values of the column on schemaless uploads, and the fraction of the values valid
+	// for the data type
+	InferredDataType           null.String `json:"inferred_data_type" swaggertype:"string" example:"number"`
+	InferredDataTypeConfidence null.Float  `json:"inferred_data_type_confidence" swaggertype:"number" example:"0.98"`
 }
 
 func (uc *UploadColumn) BeforeCreate(_ *gorm.DB) (err error) {
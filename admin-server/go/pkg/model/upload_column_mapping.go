@@ -0,0 +1,173 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"regexp"
+	"strings"
+	"tableflow/go/pkg/util"
+)
+
+// UploadColumnMappings are the column mappings of an upload that aren't from one upload column to one template column,
+// which are set on the upload columns
+type UploadColumnMappings struct {
+	Splits []*UploadColumnSplit `json:"splits"`
+	Merges []*UploadColumnMerge `json:"merges"`
+}
+
+// UploadColumnSplit maps the parts of an upload column to several template columns, i.e. "Full Name" to first_name and
+// last_name. The cell is split by the delimiter or the regex, with the last template column receiving the remainder of
+// the cell. If the regex contains capture groups, each group is mapped to a template column instead.
+type UploadColumnSplit struct {
+	UploadColumnID    string   `json:"upload_column_id" example:"3c79e7fd-1018-4a27-8b86-9cee84221cd8"`
+	Delimiter         string   `json:"delimiter,omitempty" example:" "`
+	Regex             string   `json:"regex,omitempty" example:""`
+	TemplateColumnIDs []string `json:"template_column_ids" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+
+	regex *regexp.Regexp
+}
+
+// UploadColumnMerge joins the non-blank cells of several upload columns, in order, with the separator into one template
+// column, i.e. "Address Line 1" and "Address Line 2" to address
+type UploadColumnMerge struct {
+	UploadColumnIDs  []string `json:"upload_column_ids" example:"3c79e7fd-1018-4a27-8b86-9cee84221cd8"`
+	Separator        string   `json:"separator" example:", "`
+	TemplateColumnID string   `json:"template_column_id" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+}
+
+// IsEmpty returns true if there are no splits or merges
+func (m UploadColumnMappings) IsEmpty() bool {
+	return len(m.Splits) == 0 && len(m.Merges) == 0
+}
+
+// Equals returns true if the splits and merges are the same
+func (m UploadColumnMappings) Equals(other UploadColumnMappings) bool {
+	if m.IsEmpty() || other.IsEmpty() {
+		return m.IsEmpty() && other.IsEmpty()
+	}
+	normalize := func(m UploadColumnMappings) string {
+		m.Splits = lo.Ternary(m.Splits == nil, []*UploadColumnSplit{}, m.Splits)
+		m.Merges = lo.Ternary(m.Merges == nil, []*UploadColumnMerge{}, m.Merges)
+		jsonBytes, _ := json.Marshal(m)
+		return string(jsonBytes)
+	}
+	return normalize(m) == normalize(other)
+}
+
+// TemplateColumnIDs returns the template column IDs mapped by the splits and merges
+func (m UploadColumnMappings) TemplateColumnIDs() []string {
+	var ids []string
+	for _, s := range m.Splits {
+		ids = append(ids, s.TemplateColumnIDs...)
+	}
+	for _, mg := range m.Merges {
+		ids = append(ids, mg.TemplateColumnID)
+	}
+	return ids
+}
+
+// UploadColumnIDs returns the upload column IDs mapped by the splits and merges
+func (m UploadColumnMappings) UploadColumnIDs() []string {
+	var ids []string
+	for _, s := range m.Splits {
+		ids = append(ids, s.UploadColumnID)
+	}
+	for _, mg := range m.Merges {
+		ids = append(ids, mg.UploadColumnIDs...)
+	}
+	return ids
+}
+
+// Validate checks the splits and merges are complete, and compiles the split regexes
+func (m UploadColumnMappings) Validate() error {
+	for _, s := range m.Splits {
+		if err := s.Compile(); err != nil {
+			return err
+		}
+	}
+	for _, mg := range m.Merges {
+		if len(mg.UploadColumnIDs) < 2 {
+			return errors.New("A merge must contain at least two source columns")
+		}
+		if len(mg.TemplateColumnID) == 0 || lo.Contains(mg.UploadColumnIDs, "") {
+			return errors.New("A merge cannot contain empty columns")
+		}
+	}
+	return nil
+}
+
+// Compile checks the split is complete and compiles the regex, if any
+func (s *UploadColumnSplit) Compile() error {
+	if len(s.UploadColumnID) == 0 || lo.Contains(s.TemplateColumnIDs, "") {
+		return errors.New("A split cannot contain empty columns")
+	}
+	if len(s.TemplateColumnIDs) < 2 {
+		return errors.New("A split must contain at least two destination columns")
+	}
+	if (len(s.Delimiter) == 0) == (len(s.Regex) == 0) {
+		return errors.New("A split must contain either a delimiter or a regex")
+	}
+	if len(s.Regex) != 0 {
+		regex, err := regexp.Compile(s.Regex)
+		if err != nil {
+			return fmt.Errorf("The split regex %s is invalid", s.Regex)
+		}
+		if regex.NumSubexp() != 0 && regex.NumSubexp() != len(s.TemplateColumnIDs) {
+			return fmt.Errorf("The split regex %s must contain one capture group for each destination column", s.Regex)
+		}
+		s.regex = regex
+	}
+	return nil
+}
+
+// Split returns a part of the cell for each template column, missing parts are blank
+func (s *UploadColumnSplit) Split(cell string) []string {
+	n := len(s.TemplateColumnIDs)
+	var parts []string
+	switch {
+	case s.regex != nil && s.regex.NumSubexp() != 0:
+		if matches := s.regex.FindStringSubmatch(cell); matches != nil {
+			parts = matches[1:]
+		}
+	case s.regex != nil:
+		parts = s.regex.Split(strings.TrimSpace(cell), n)
+	default:
+		parts = strings.SplitN(strings.TrimSpace(cell), s.Delimiter, n)
+	}
+	res := make([]string, n)
+	for i := 0; i < n && i < len(parts); i++ {
+		res[i] = strings.TrimSpace(parts[i])
+	}
+	return res
+}
+
+// Merge joins the non-blank cells with the separator
+func (mg *UploadColumnMerge) Merge(cells []string) string {
+	values := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		if !util.IsBlankUnicode(cell) {
+			values = append(values, strings.TrimSpace(cell))
+		}
+	}
+	return strings.Join(values, mg.Separator)
+}
+
+func (m UploadColumnMappings) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+func (m *UploadColumnMappings) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = UploadColumnMappings{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	}
+	return errors.New("type assertion failed for upload column mappings")
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUploadColumnSplitCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		split   UploadColumnSplit
+		wantErr bool
+	}{
+		{name: "delimiter", split: UploadColumnSplit{UploadColumnID: "uc", Delimiter: " ", TemplateColumnIDs: []string{"a", "b"}}},
+		{name: "regex", split: UploadColumnSplit{UploadColumnID: "uc", Regex: "\\s*,\\s*", TemplateColumnIDs: []string{"a", "b"}}},
+		{name: "capture groups", split: UploadColumnSplit{UploadColumnID: "uc", Regex: "^(\\w+) (\\w+)$", TemplateColumnIDs: []string{"a", "b"}}},
+		{name: "missing upload column", split: UploadColumnSplit{Delimiter: " ", TemplateColumnIDs: []string{"a", "b"}}, wantErr: true},
+		{name: "blank template column", split: UploadColumnSplit{UploadColumnID: "uc", Delimiter: " ", TemplateColumnIDs: []string{"a", ""}}, wantErr: true},
+		{name: "one template column", split: UploadColumnSplit{UploadColumnID: "uc", Delimiter: " ", TemplateColumnIDs: []string{"a"}}, wantErr: true},
+		{name: "no delimiter or regex", split: UploadColumnSplit{UploadColumnID: "uc", TemplateColumnIDs: []string{"a", "b"}}, wantErr: true},
+		{name: "delimiter and regex", split: UploadColumnSplit{UploadColumnID: "uc", Delimiter: " ", Regex: ",", TemplateColumnIDs: []string{"a", "b"}}, wantErr: true},
+		{name: "invalid regex", split: UploadColumnSplit{UploadColumnID: "uc", Regex: "(", TemplateColumnIDs: []string{"a", "b"}}, wantErr: true},
+		{
+			name:    "capture groups don't match the template columns",
+			split:   UploadColumnSplit{UploadColumnID: "uc", Regex: "^(\\w+) (\\w+) (\\w+)$", TemplateColumnIDs: []string{"a", "b"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.split.Compile()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUploadColumnSplitSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		split UploadColumnSplit
+		cell  string
+		want  []string
+	}{
+		{
+			name:  "delimiter",
+			split: UploadColumnSplit{Delimiter: " ", TemplateColumnIDs: []string{"first", "last"}},
+			cell:  "Mary Jenkins",
+			want:  []string{"Mary", "Jenkins"},
+		},
+		{
+			name:  "delimiter keeps the remainder in the last part",
+			split: UploadColumnSplit{Delimiter: " ", TemplateColumnIDs: []string{"first", "last"}},
+			cell:  "Mary Ann Jenkins",
+			want:  []string{"Mary", "Ann Jenkins"},
+		},
+		{
+			name:  "delimiter with the cell and parts trimmed",
+			split: UploadColumnSplit{Delimiter: ",", TemplateColumnIDs: []string{"city", "state", "zip"}},
+			cell:  " Austin , TX,78701 ",
+			want:  []string{"Austin", "TX", "78701"},
+		},
+		{
+			name:  "delimiter with missing parts",
+			split: UploadColumnSplit{Delimiter: ",", TemplateColumnIDs: []string{"city", "state", "zip"}},
+			cell:  "Austin",
+			want:  []string{"Austin", "", ""},
+		},
+		{
+			name:  "blank cell",
+			split: UploadColumnSplit{Delimiter: " ", TemplateColumnIDs: []string{"first", "last"}},
+			cell:  "  ",
+			want:  []string{"", ""},
+		},
+		{
+			name:  "regex",
+			split: UploadColumnSplit{Regex: "\\s*[,;]\\s*", TemplateColumnIDs: []string{"a", "b"}},
+			cell:  "red ; green",
+			want:  []string{"red", "green"},
+		},
+		{
+			name:  "regex keeps the remainder in the last part",
+			split: UploadColumnSplit{Regex: "\\s*[,;]\\s*", TemplateColumnIDs: []string{"a", "b"}},
+			cell:  "red, green; blue",
+			want:  []string{"red", "green; blue"},
+		},
+		{
+			name:  "regex with missing parts",
+			split: UploadColumnSplit{Regex: "\\s*[,;]\\s*", TemplateColumnIDs: []string{"a", "b", "c"}},
+			cell:  "red",
+			want:  []string{"red", "", ""},
+		},
+		{
+			name:  "capture groups",
+			split: UploadColumnSplit{Regex: "^(\\d{3})-(\\d{4})$", TemplateColumnIDs: []string{"prefix", "line"}},
+			cell:  "555-1234",
+			want:  []string{"555", "1234"},
+		},
+		{
+			name:  "capture groups with an optional group",
+			split: UploadColumnSplit{Regex: "^(\\w+)(?: (\\w+))?$", TemplateColumnIDs: []string{"first", "last"}},
+			cell:  "Mary",
+			want:  []string{"Mary", ""},
+		},
+		{
+			name:  "capture groups that don't match",
+			split: UploadColumnSplit{Regex: "^(\\d{3})-(\\d{4})$", TemplateColumnIDs: []string{"prefix", "line"}},
+			cell:  "5551234",
+			want:  []string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split := tt.split
+			split.UploadColumnID = "uc"
+			if err := split.Compile(); err != nil {
+				t.Fatalf("Compile() returned error: %v", err)
+			}
+			if got := split.Split(tt.cell); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUploadColumnMergeMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		separator string
+		cells     []string
+		want      string
+	}{
+		{name: "separator", separator: ", ", cells: []string{"1 Main St", "Apt 2"}, want: "1 Main St, Apt 2"},
+		{name: "cells are trimmed", separator: " ", cells: []string{" Mary ", " Jenkins"}, want: "Mary Jenkins"},
+		{name: "blank cells are skipped", separator: ", ", cells: []string{"1 Main St", " ", "", "Austin"}, want: "1 Main St, Austin"},
+		{name: "all blank", separator: ", ", cells: []string{"", " "}, want: ""},
+		{name: "no separator", separator: "", cells: []string{"555", "1234"}, want: "5551234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merge := UploadColumnMerge{Separator: tt.separator}
+			if got := merge.Merge(tt.cells); got != tt.want {
+				t.Errorf("Merge(%q) = %q, want %q", tt.cells, got, tt.want)
+			}
+		})
+	}
+}
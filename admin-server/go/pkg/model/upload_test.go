@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/guregu/null"
+	"testing"
+)
+
+func TestUploadHeaderRows(t *testing.T) {
+	tests := []struct {
+		name              string
+		headerRowIndex    null.Int
+		headerRowEndIndex null.Int
+		wantNumHeaderRows int
+		wantDataRowIndex  int
+	}{
+		{name: "header row not set", wantNumHeaderRows: 0, wantDataRowIndex: 1},
+		{name: "first row", headerRowIndex: null.IntFrom(0), wantNumHeaderRows: 1, wantDataRowIndex: 1},
+		{name: "later row", headerRowIndex: null.IntFrom(3), wantNumHeaderRows: 1, wantDataRowIndex: 4},
+		{name: "several rows", headerRowIndex: null.IntFrom(2), headerRowEndIndex: null.IntFrom(4), wantNumHeaderRows: 3, wantDataRowIndex: 5},
+		{name: "end before the start", headerRowIndex: null.IntFrom(2), headerRowEndIndex: null.IntFrom(1), wantNumHeaderRows: 1, wantDataRowIndex: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &Upload{HeaderRowIndex: tt.headerRowIndex, HeaderRowEndIndex: tt.headerRowEndIndex}
+			if got := u.NumHeaderRows(); got != tt.wantNumHeaderRows {
+				t.Errorf("NumHeaderRows() = %d, want %d", got, tt.wantNumHeaderRows)
+			}
+			if got := u.DataRowIndex(); got != tt.wantDataRowIndex {
+				t.Errorf("DataRowIndex() = %d, want %d", got, tt.wantDataRowIndex)
+			}
+		})
+	}
+}
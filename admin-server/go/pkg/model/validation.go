@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/samber/lo"
 	"gorm.io/gorm"
+	"sort"
 	"strings"
 	"tableflow/go/pkg/evaluator"
 	"tableflow/go/pkg/model/jsonb"
@@ -25,6 +26,12 @@ var validSeverities = map[string]ValidationSeverity{
 	string(ValidationSeverityInfo):  ValidationSeverityInfo,
 }
 
+// RequiredValidationID is the ID of the not_blank validation implied by a required template column. The validation
+// isn't stored, IDs of stored and SDK-defined validations start at 1.
+const RequiredValidationID uint = 0
+
+const RequiredValidationMessage = "This column is required, the cell must contain a value"
+
 type Validation struct {
 	ID               uint               `json:"id" swaggertype:"integer" example:"1"`
 	TemplateColumnID ID                 `json:"template_column_id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
@@ -49,6 +56,59 @@ func (v Validation) Evaluate(cell string) (bool, string) {
 	return passed, value
 }
 
+// EvaluateRow evaluates the cell along with the other values in the row. Validations that don't reference other cells
+// fall back to Evaluate.
+func (v Validation) EvaluateRow(cell string, row map[string]string) (bool, string) {
+	rowEvaluator, ok := v.Evaluator.(evaluator.RowEvaluator)
+	if !ok {
+		return v.Evaluate(cell)
+	}
+	passed, value, err := rowEvaluator.EvaluateRow(cell, row)
+	if err != nil {
+		tf.Log.Warnw("Row validation error", "validation_id", v.ID, "cell", cell, "options", v.Options.ToString(), "error", err)
+	}
+	return passed, value
+}
+
+// Suggestions returns the values suggested for a cell that didn't pass, if the validation supports it
+func (v Validation) Suggestions(cell string) []string {
+	if suggestionEvaluator, ok := v.Evaluator.(evaluator.SuggestionEvaluator); ok {
+		return suggestionEvaluator.Suggestions(cell)
+	}
+	return nil
+}
+
+// Prefetch evaluates the cells in a batch ahead of the calls to Evaluate, if the validation supports it
+func (v Validation) Prefetch(cells []string) {
+	batchEvaluator, ok := v.Evaluator.(evaluator.BatchEvaluator)
+	if !ok {
+		return
+	}
+	if err := batchEvaluator.Prefetch(cells); err != nil {
+		tf.Log.Warnw("Batch validation error", "validation_id", v.ID, "num_cells", len(cells), "options", v.Options.ToString(), "error", err)
+	}
+}
+
+// IsBatchValidation returns true if the validation can evaluate cells in batches
+func (v Validation) IsBatchValidation() bool {
+	_, ok := v.Evaluator.(evaluator.BatchEvaluator)
+	return ok
+}
+
+// IsRowValidation returns true if the validation references other cells in the row
+func (v Validation) IsRowValidation() bool {
+	_, ok := v.Evaluator.(evaluator.RowEvaluator)
+	return ok
+}
+
+// ReferencedKeys returns the template column keys of the other cells the validation depends on, if any
+func (v Validation) ReferencedKeys() []string {
+	if rowEvaluator, ok := v.Evaluator.(evaluator.RowEvaluator); ok {
+		return rowEvaluator.ReferencedKeys()
+	}
+	return nil
+}
+
 func ParseValidation(id uint, templateColumnID, validateStr string, options jsonb.JSONB, message, severity string, dataType TemplateColumnDataType) (*Validation, error) {
 	v := &Validation{
 		ID:               id,
@@ -65,11 +125,16 @@ func ParseValidation(id uint, templateColumnID, validateStr string, options json
 	if v.Evaluator, err = evaluator.Parse(validateStr, options); err != nil {
 		return nil, err
 	}
-	if !lo.Contains(v.Evaluator.AllowedDataTypes(), string(dataType)) {
+	allowedDataTypes := v.Evaluator.AllowedDataTypes()
+	if definition, ok := evaluator.Lookup(validateStr); ok {
+		// Registered definitions can further restrict the data types the evaluator allows
+		allowedDataTypes = lo.Intersect(allowedDataTypes, definition.AllowedDataTypes)
+	}
+	if !lo.Contains(allowedDataTypes, string(dataType)) {
 		return nil, fmt.Errorf("The validation %s is only compatible with the data type%s %s",
 			validateStr,
-			lo.Ternary(len(v.Evaluator.AllowedDataTypes()) == 1, "", "s"),
-			strings.Join(v.Evaluator.AllowedDataTypes(), ", "))
+			lo.Ternary(len(allowedDataTypes) == 1, "", "s"),
+			strings.Join(allowedDataTypes, ", "))
 	}
 	if len(v.Message) == 0 {
 		v.Message = v.Evaluator.DefaultMessage()
@@ -77,6 +142,35 @@ func ParseValidation(id uint, templateColumnID, validateStr string, options json
 	return v, nil
 }
 
+// NewRequiredValidation returns the not_blank validation implied by a required template column
+func NewRequiredValidation(templateColumnID ID) *Validation {
+	return &Validation{
+		ID:               RequiredValidationID,
+		TemplateColumnID: templateColumnID,
+		Validate:         "not_blank",
+		Options:          jsonb.NewNull(),
+		Message:          RequiredValidationMessage,
+		Severity:         ValidationSeverityError,
+		Evaluator:        &evaluator.NotBlankEvaluator{},
+	}
+}
+
+// IsImpliedByRequired returns true if the validation is a not_blank validation with the error severity and the default
+// message, which duplicates the check performed on the cells of required columns
+func IsImpliedByRequired(v *Validation) bool {
+	return v.Validate == "not_blank" &&
+		v.Severity == ValidationSeverityError &&
+		(len(v.Message) == 0 || (v.Evaluator != nil && v.Message == v.Evaluator.DefaultMessage()))
+}
+
+// SortValidations orders data type validations first, as they normalize the cell value (i.e. the number format) for
+// the validations after them
+func SortValidations(validations []*Validation) {
+	sort.SliceStable(validations, func(i, j int) bool {
+		return evaluator.IsDataTypeEvaluator(validations[i].Validate) && !evaluator.IsDataTypeEvaluator(validations[j].Validate)
+	})
+}
+
 func ParseValidationSeverity(severity string) (ValidationSeverity, error) {
 	s, ok := validSeverities[severity]
 	if !ok {
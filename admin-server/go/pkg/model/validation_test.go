@@ -0,0 +1,83 @@
+package model
+
+import (
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+func TestParseValidationSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     ValidationSeverity
+		wantErr  bool
+	}{
+		{severity: "", want: ValidationSeverityError},
+		{severity: "error", want: ValidationSeverityError},
+		{severity: "warn", want: ValidationSeverityWarn},
+		{severity: "info", want: ValidationSeverityInfo},
+		{severity: "warning", wantErr: true},
+		{severity: "ERROR", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got, err := ParseValidationSeverity(tt.severity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseValidationSeverity(%q) error = %v, wantErr %v", tt.severity, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseValidationSeverity(%q) = %q, want %q", tt.severity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		validate     string
+		options      jsonb.JSONB
+		message      string
+		severity     string
+		dataType     TemplateColumnDataType
+		wantMessage  string
+		wantSeverity ValidationSeverity
+		wantErr      bool
+	}{
+		{
+			name:         "default message and severity",
+			validate:     "not_blank",
+			options:      jsonb.NewNull(),
+			dataType:     TemplateColumnDataTypeString,
+			wantMessage:  "The cell must contain a value",
+			wantSeverity: ValidationSeverityError,
+		},
+		{
+			name:         "warning with a message",
+			validate:     "email",
+			options:      jsonb.NewNull(),
+			message:      " Check the email address ",
+			severity:     "warn",
+			dataType:     TemplateColumnDataTypeString,
+			wantMessage:  "Check the email address",
+			wantSeverity: ValidationSeverityWarn,
+		},
+		{name: "info", validate: "not_blank", options: jsonb.NewNull(), severity: "info", dataType: TemplateColumnDataTypeString, wantMessage: "The cell must contain a value", wantSeverity: ValidationSeverityInfo},
+		{name: "invalid severity", validate: "not_blank", options: jsonb.NewNull(), severity: "fatal", dataType: TemplateColumnDataTypeString, wantErr: true},
+		{name: "unknown validate type", validate: "zip", options: jsonb.NewNull(), dataType: TemplateColumnDataTypeString, wantErr: true},
+		{name: "data type not allowed", validate: "email", options: jsonb.NewNull(), dataType: TemplateColumnDataTypeNumber, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ParseValidation(1, "", tt.validate, tt.options, tt.message, tt.severity, tt.dataType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseValidation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if v.Message != tt.wantMessage || v.Severity != tt.wantSeverity {
+				t.Errorf("ParseValidation() = %q, %q, want %q, %q", v.Message, v.Severity, tt.wantMessage, tt.wantSeverity)
+			}
+		})
+	}
+}
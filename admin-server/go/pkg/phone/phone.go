@@ -0,0 +1,287 @@
+// Package phone parses, validates and formats phone numbers using offline metadata embedded in the binary. The
+// metadata covers the calling code, national prefix, valid lengths and leading digits of the national numbers of each
+// supported region, and the grouping used to format them. Numbers with the calling code of a region without metadata
+// are only checked against the E.164 length.
+package phone
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed metadata.json
+var metadataJSON []byte
+
+// Region is the numbering plan of a region (ISO 3166-1 alpha-2)
+type Region struct {
+	Region                 string   `json:"region"`
+	CallingCode            string   `json:"calling_code"`
+	NationalPrefix         string   `json:"national_prefix"`
+	NationalPrefixInFormat bool     `json:"national_prefix_in_format"`
+	Lengths                []int    `json:"lengths"`
+	Pattern                string   `json:"pattern"`
+	Formats                []Format `json:"formats"`
+
+	pattern *regexp.Regexp
+}
+
+// Format groups the digits of national numbers, X is replaced with each digit
+type Format struct {
+	Length        int    `json:"length"`
+	Leading       string `json:"leading"`
+	National      string `json:"national"`
+	International string `json:"international"`
+
+	leading *regexp.Regexp
+}
+
+// Number is a parsed phone number
+type Number struct {
+	Region         *Region
+	NationalNumber string
+}
+
+const (
+	FormatE164          = "e164"
+	FormatNational      = "national"
+	FormatInternational = "international"
+)
+
+var regions = make(map[string]*Region)
+var regionsByCallingCode = make(map[string][]*Region)
+
+// callingCodes are the country calling codes assigned by the ITU (E.164)
+var callingCodes = strings.Fields(`
+	1 7 20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49 51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86
+	90 91 92 93 94 95 98
+	211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 233 234 235 236 237 238 239 240 241 242
+	243 244 245 246 247 248 249 250 251 252 253 254 255 256 257 258 260 261 262 263 264 265 266 267 268 269 290 291
+	297 298 299 350 351 352 353 354 355 356 357 358 359 370 371 372 373 374 375 376 377 378 379 380 381 382 383 385
+	386 387 389 420 421 423 500 501 502 503 504 505 506 507 508 509 590 591 592 593 594 595 596 597 598 599 670 672
+	673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692 800 808 850 852 853 855 856 870 878
+	880 881 882 883 886 888 960 961 962 963 964 965 966 967 968 970 971 972 973 974 975 976 977 979 992 993 994 995
+	996 998
+`)
+
+const (
+	// maxE164Length is the maximum number of digits of a number including the calling code
+	maxE164Length = 15
+	// minUnlistedNationalNumberLength is the shortest national number accepted for calling codes without metadata
+	minUnlistedNationalNumberLength = 4
+)
+
+func init() {
+	var metadata []*Region
+	if err := json.Unmarshal(metadataJSON, &metadata); err != nil {
+		panic(fmt.Sprintf("invalid phone metadata: %v", err))
+	}
+	for _, r := range metadata {
+		r.pattern = regexp.MustCompile("^(?:" + r.Pattern + ")$")
+		for i := range r.Formats {
+			if len(r.Formats[i].Leading) != 0 {
+				r.Formats[i].leading = regexp.MustCompile("^(?:" + r.Formats[i].Leading + ")")
+			}
+		}
+		regions[r.Region] = r
+		// Regions sharing a calling code are checked in the order of the metadata, so more specific patterns come first
+		regionsByCallingCode[r.CallingCode] = append(regionsByCallingCode[r.CallingCode], r)
+	}
+	for _, callingCode := range callingCodes {
+		if _, ok := regionsByCallingCode[callingCode]; !ok {
+			regionsByCallingCode[callingCode] = []*Region{newUnlistedRegion(callingCode)}
+		}
+	}
+}
+
+// newUnlistedRegion returns the numbering plan of a calling code without metadata, accepting any national number that
+// fits in the E.164 length. The region code is empty as the calling code can be shared by several regions.
+func newUnlistedRegion(callingCode string) *Region {
+	r := &Region{CallingCode: callingCode, Pattern: `\d+`}
+	for l := minUnlistedNationalNumberLength; l <= maxE164Length-len(callingCode); l++ {
+		r.Lengths = append(r.Lengths, l)
+	}
+	r.pattern = regexp.MustCompile("^(?:" + r.Pattern + ")$")
+	return r
+}
+
+// IsRegionSupported returns true if there is metadata for the region
+func IsRegionSupported(region string) bool {
+	_, ok := regions[strings.ToUpper(region)]
+	return ok
+}
+
+// SupportedRegions returns the regions with metadata
+func SupportedRegions() []string {
+	supported := make([]string, 0, len(regions))
+	for region := range regions {
+		supported = append(supported, region)
+	}
+	sort.Strings(supported)
+	return supported
+}
+
+// Parse parses a phone number written in the international format (+ or 00 followed by the calling code), or in the
+// national format of the default region
+func Parse(s string, defaultRegion string) (Number, error) {
+	region, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return Number{}, fmt.Errorf("the region %s is not supported", defaultRegion)
+	}
+	digits, international, err := extractDigits(s)
+	if err != nil {
+		return Number{}, err
+	}
+	if !international {
+		switch {
+		case strings.HasPrefix(digits, "00"):
+			international = true
+			digits = digits[2:]
+		case region.CallingCode == "1" && strings.HasPrefix(digits, "011"):
+			international = true
+			digits = digits[3:]
+		}
+	}
+	if international {
+		return parseInternational(digits)
+	}
+	if n, ok := matchNationalNumber(region.CallingCode, digits); ok {
+		return n, nil
+	}
+	// Numbers are sometimes written with the calling code but without the +
+	if strings.HasPrefix(digits, region.CallingCode) {
+		if n, err := parseInternational(digits); err == nil {
+			return n, nil
+		}
+	}
+	return Number{}, errors.New("invalid phone number")
+}
+
+func extractDigits(s string) (string, bool, error) {
+	var sb strings.Builder
+	international := false
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case r >= '０' && r <= '９':
+			// Full-width digits
+			sb.WriteRune('0' + (r - '０'))
+		case (r == '+' || r == '＋') && sb.Len() == 0 && !international:
+			international = true
+		case strings.ContainsRune(" -.()/\u00a0\u2010\u2011\u2012\u2013", r):
+		default:
+			return "", false, fmt.Errorf("invalid character '%c' in phone number", r)
+		}
+	}
+	if sb.Len() == 0 {
+		return "", false, errors.New("no digits in phone number")
+	}
+	return sb.String(), international, nil
+}
+
+func parseInternational(digits string) (Number, error) {
+	// Calling codes are 1 to 3 digits and no calling code is a prefix of another
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		if _, ok := regionsByCallingCode[digits[:i]]; !ok {
+			continue
+		}
+		if n, ok := matchNationalNumber(digits[:i], digits[i:]); ok {
+			return n, nil
+		}
+		return Number{}, errors.New("invalid phone number")
+	}
+	return Number{}, errors.New("unknown or unsupported calling code")
+}
+
+// matchNationalNumber finds the region of the calling code the national number is valid for, removing the national
+// prefix if it was included (i.e. +44 (0)20...)
+func matchNationalNumber(callingCode, digits string) (Number, bool) {
+	for _, r := range regionsByCallingCode[callingCode] {
+		if r.IsValidNationalNumber(digits) {
+			return Number{Region: r, NationalNumber: digits}, true
+		}
+		if len(r.NationalPrefix) != 0 && strings.HasPrefix(digits, r.NationalPrefix) {
+			if stripped := digits[len(r.NationalPrefix):]; r.IsValidNationalNumber(stripped) {
+				return Number{Region: r, NationalNumber: stripped}, true
+			}
+		}
+	}
+	return Number{}, false
+}
+
+// IsValidNationalNumber returns true if the national significant number (without the national prefix) is valid
+func (r *Region) IsValidNationalNumber(nsn string) bool {
+	validLength := false
+	for _, l := range r.Lengths {
+		if l == len(nsn) {
+			validLength = true
+			break
+		}
+	}
+	return validLength && r.pattern.MatchString(nsn)
+}
+
+func (r *Region) format(nsn string, international bool) string {
+	for _, f := range r.Formats {
+		if f.Length != 0 && f.Length != len(nsn) {
+			continue
+		}
+		if f.leading != nil && !f.leading.MatchString(nsn) {
+			continue
+		}
+		template := f.National
+		if international && len(f.International) != 0 {
+			template = f.International
+		}
+		if strings.Count(template, "X") != len(nsn) {
+			continue
+		}
+		var sb strings.Builder
+		i := 0
+		for _, c := range template {
+			if c == 'X' {
+				sb.WriteByte(nsn[i])
+				i++
+			} else {
+				sb.WriteRune(c)
+			}
+		}
+		return sb.String()
+	}
+	return nsn
+}
+
+// E164 formats the number as +<calling code><national number>, i.e. +12015550123
+func (n Number) E164() string {
+	return "+" + n.Region.CallingCode + n.NationalNumber
+}
+
+// National formats the number as it's written within the region, i.e. (201) 555-0123 or 020 7946 0018
+func (n Number) National() string {
+	prefix := ""
+	if n.Region.NationalPrefixInFormat {
+		prefix = n.Region.NationalPrefix
+	}
+	return prefix + n.Region.format(n.NationalNumber, false)
+}
+
+// International formats the number as it's written from other regions, i.e. +44 20 7946 0018
+func (n Number) International() string {
+	return "+" + n.Region.CallingCode + " " + n.Region.format(n.NationalNumber, true)
+}
+
+// Format formats the number as e164, national or international
+func (n Number) Format(format string) string {
+	switch format {
+	case FormatNational:
+		return n.National()
+	case FormatInternational:
+		return n.International()
+	}
+	return n.E164()
+}
@@ -0,0 +1,73 @@
+package phone
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		defaultRegion string
+		wantRegion    string
+		wantE164      string
+		wantErr       bool
+	}{
+		{name: "national US", input: "(201) 555-0123", defaultRegion: "US", wantRegion: "US", wantE164: "+12015550123"},
+		{name: "international GB", input: "+44 20 7946 0018", defaultRegion: "US", wantRegion: "GB", wantE164: "+442079460018"},
+		{name: "00 prefix", input: "0044 20 7946 0018", defaultRegion: "US", wantRegion: "GB", wantE164: "+442079460018"},
+		{name: "national prefix in international number", input: "+44 (0)20 7946 0018", defaultRegion: "US", wantRegion: "GB", wantE164: "+442079460018"},
+		{name: "national GB", input: "020 7946 0018", defaultRegion: "GB", wantRegion: "GB", wantE164: "+442079460018"},
+		{name: "calling code without plus", input: "44 20 7946 0018", defaultRegion: "GB", wantRegion: "GB", wantE164: "+442079460018"},
+		{name: "full-width digits", input: "＋１ ２０１ ５５５ ０１２３", defaultRegion: "GB", wantRegion: "US", wantE164: "+12015550123"},
+		{name: "region without metadata", input: "+372 5123 4567", defaultRegion: "US", wantRegion: "", wantE164: "+37251234567"},
+		{name: "region without metadata too short", input: "+372 12", defaultRegion: "US", wantErr: true},
+		{name: "region without metadata too long", input: "+372 1234 5678 9012 3", defaultRegion: "US", wantErr: true},
+		{name: "unassigned calling code", input: "+210 1234567", defaultRegion: "US", wantErr: true},
+		{name: "invalid length", input: "201 555 012", defaultRegion: "US", wantErr: true},
+		{name: "letters", input: "201-555-CALL", defaultRegion: "US", wantErr: true},
+		{name: "no digits", input: "()", defaultRegion: "US", wantErr: true},
+		{name: "unsupported default region", input: "2015550123", defaultRegion: "XX", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.input, tt.defaultRegion)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q, %q) = %s, want an error", tt.input, tt.defaultRegion, n.E164())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %q) returned error: %v", tt.input, tt.defaultRegion, err)
+			}
+			if n.Region.Region != tt.wantRegion || n.E164() != tt.wantE164 {
+				t.Errorf("Parse(%q, %q) = %s in %q, want %s in %q", tt.input, tt.defaultRegion, n.E164(), n.Region.Region, tt.wantE164, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		input  string
+		format string
+		want   string
+	}{
+		{input: "+12015550123", format: FormatE164, want: "+12015550123"},
+		{input: "+12015550123", format: FormatNational, want: "(201) 555-0123"},
+		{input: "+12015550123", format: FormatInternational, want: "+1 201-555-0123"},
+		{input: "+442079460018", format: FormatNational, want: "020 7946 0018"},
+		{input: "+442079460018", format: FormatInternational, want: "+44 20 7946 0018"},
+		{input: "+37251234567", format: FormatInternational, want: "+372 51234567"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input+" "+tt.format, func(t *testing.T) {
+			n, err := Parse(tt.input, "US")
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if got := n.Format(tt.format); got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package scylla
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/gocql/gocql"
+	"github.com/samber/lo"
 	"sort"
 	"strings"
 	"sync"
@@ -69,29 +72,143 @@ func GetAnyImportRowErrorFirst(importID string, index int) (types.ImportRow, boo
 
 func GetImportRow(importID string, index int) (types.ImportRow, error) {
 	row := types.ImportRow{}
-	err := tf.Scylla.Query("select row_index, values from import_rows where import_id = ? and row_index = ?", importID, index).Scan(&row.Index, &row.Values)
+	warnings := make(map[string][]uint)
+	errorDetails := make(ImportRowErrorDetails)
+	err := tf.Scylla.Query("select row_index, values, raw_values, warnings, error_details from import_rows where import_id = ? and row_index = ?", importID, index).Scan(&row.Index, &row.Values, &row.RawValues, &warnings, &errorDetails)
+	if err != nil {
+		return row, err
+	}
+	row.Errors = make(map[string][]types.ImportRowError)
+	addImportRowErrors(row.Errors, warnings, nil, nil, nil, errorDetails)
 	return row, err
 }
 
-// GetImportRowError Note the errors retrieved from this only have the cell keys and validation IDs, they don't contain
-// the additional validation information from Postgres
+// GetImportRowError Note the errors retrieved from this only have the cell keys, validation IDs and the stored error
+// details, they don't contain the additional validation information from Postgres
 func GetImportRowError(importID string, index int) (types.ImportRow, error) {
 	row := types.ImportRow{}
 	errors := make(map[string][]uint)
-	err := tf.Scylla.Query("select row_index, values, errors from import_row_errors where import_id = ? and row_index = ?", importID, index).Scan(&row.Index, &row.Values, &errors)
+	warnings := make(map[string][]uint)
+	errorDetails := make(ImportRowErrorDetails)
+	err := tf.Scylla.Query("select row_index, values, raw_values, errors, warnings, error_details from import_row_errors where import_id = ? and row_index = ?", importID, index).Scan(&row.Index, &row.Values, &row.RawValues, &errors, &warnings, &errorDetails)
 	if err != nil {
 		return row, err
 	}
 	row.Errors = make(map[string][]types.ImportRowError)
-	for k, v := range errors {
-		if _, ok := row.Errors[k]; !ok {
-			row.Errors[k] = []types.ImportRowError{}
+	addImportRowErrors(row.Errors, errors, nil, nil, nil, errorDetails)
+	addImportRowErrors(row.Errors, warnings, nil, nil, nil, errorDetails)
+	return row, err
+}
+
+// addImportRowErrors transforms the Scylla errors or warnings map values (validation IDs) into ImportRowErrors, with
+// the details stored when the cells were evaluated. If validations is nil, only the validation IDs and details are set.
+func addImportRowErrors(rowErrors map[string][]types.ImportRowError, validationIDs map[string][]uint, validations map[uint]model.Validation, values map[string]string, listDelimiters map[string]string, errorDetails ImportRowErrorDetails) {
+	for rowKey, ids := range validationIDs {
+		for _, id := range ids {
+			detail := errorDetails.Get(rowKey, id)
+			if validations == nil {
+				rowErrors[rowKey] = append(rowErrors[rowKey], types.ImportRowError{ValidationID: id, Suggestions: detail.Suggestions, FailedElements: detail.FailedElements})
+				continue
+			}
+			v, ok := validations[id]
+			if !ok {
+				tf.Log.Warnw("Attempted to set import row error with validation ID that was not provided", "validation_id", id, "row_key", rowKey)
+				continue
+			}
+			rowErrors[rowKey] = append(rowErrors[rowKey], NewImportRowError(v, values[rowKey], listDelimiters[rowKey], detail))
 		}
-		for _, validationID := range v {
-			row.Errors[k] = append(row.Errors[k], types.ImportRowError{ValidationID: validationID})
+	}
+}
+
+// NewImportRowError returns the error of a validation that didn't pass on a cell from the details stored when the cell
+// was evaluated. If the cell is a list (the delimiter is set), the error says which elements failed.
+func NewImportRowError(v model.Validation, cell, delimiter string, detail types.ImportRowErrorDetail) types.ImportRowError {
+	if len(delimiter) == 0 {
+		return types.ImportRowError{
+			ValidationID: v.ID,
+			Validate:     v.Validate,
+			Severity:     string(v.Severity),
+			Message:      v.Message,
+			Suggestions:  detail.Suggestions,
 		}
 	}
-	return row, err
+	elements := model.SplitListCell(cell, delimiter)
+	// The stored indexes are of the elements when the cell was evaluated, ignore any that no longer exist
+	failedElements := lo.Filter(detail.FailedElements, func(i int, _ int) bool { return i >= 0 && i < len(elements) })
+	return types.ImportRowError{
+		ValidationID:   v.ID,
+		Validate:       v.Validate,
+		Severity:       string(v.Severity),
+		Message:        model.ListElementsMessage(v.Message, elements, failedElements),
+		Suggestions:    detail.Suggestions,
+		FailedElements: failedElements,
+	}
+}
+
+// NewImportRowErrorDetail returns the details of a validation that didn't pass on a cell, with any suggested values
+// for the cell. If a single element of a list cell failed, the suggestions are the cell with that element replaced.
+// The suggestions are only computed once, when the cell is evaluated.
+func NewImportRowErrorDetail(v model.Validation, cell, delimiter string, failedElements []int) types.ImportRowErrorDetail {
+	detail := types.ImportRowErrorDetail{FailedElements: failedElements}
+	if len(delimiter) == 0 {
+		detail.Suggestions = v.Suggestions(cell)
+		return detail
+	}
+	elements := model.SplitListCell(cell, delimiter)
+	if len(failedElements) == 1 && failedElements[0] < len(elements) {
+		failedElement := failedElements[0]
+		for _, suggestion := range v.Suggestions(elements[failedElement]) {
+			suggested := append([]string{}, elements...)
+			suggested[failedElement] = suggestion
+			detail.Suggestions = append(detail.Suggestions, model.JoinListCell(suggested, delimiter))
+		}
+	}
+	return detail
+}
+
+// ImportRowErrorDetails holds the details of the validations that didn't pass on a row as they're stored in Scylla, by
+// the row key and validation ID. Only the failed validations with details (i.e. suggestions or the failed list
+// elements) are stored.
+type ImportRowErrorDetails map[string]string
+
+// NewImportRowErrorDetails returns the details of the row errors, so they can be stored with the row
+func NewImportRowErrorDetails(rowErrors map[string][]types.ImportRowError) ImportRowErrorDetails {
+	errorDetails := make(ImportRowErrorDetails)
+	for rowKey, ires := range rowErrors {
+		for _, ire := range ires {
+			errorDetails.Add(rowKey, ire.ValidationID, types.ImportRowErrorDetail{FailedElements: ire.FailedElements, Suggestions: ire.Suggestions})
+		}
+	}
+	return errorDetails
+}
+
+func (d ImportRowErrorDetails) Add(rowKey string, validationID uint, detail types.ImportRowErrorDetail) {
+	if len(detail.FailedElements) == 0 && len(detail.Suggestions) == 0 {
+		return
+	}
+	detailBytes, err := json.Marshal(detail)
+	if err != nil {
+		tf.Log.Errorw("Could not marshal import row error detail", "row_key", rowKey, "validation_id", validationID, "error", err)
+		return
+	}
+	d[importRowErrorDetailKey(rowKey, validationID)] = string(detailBytes)
+}
+
+func (d ImportRowErrorDetails) Get(rowKey string, validationID uint) types.ImportRowErrorDetail {
+	detail := types.ImportRowErrorDetail{}
+	detailStr, ok := d[importRowErrorDetailKey(rowKey, validationID)]
+	if !ok {
+		return detail
+	}
+	if err := json.Unmarshal([]byte(detailStr), &detail); err != nil {
+		tf.Log.Warnw("Could not unmarshal import row error detail", "row_key", rowKey, "validation_id", validationID, "error", err)
+	}
+	return detail
+}
+
+// importRowErrorDetailKey returns the key of a detail, row keys can only contain letters, numbers, and underscores
+func importRowErrorDetailKey(rowKey string, validationID uint) string {
+	return fmt.Sprintf("%s:%d", rowKey, validationID)
 }
 
 func RetrieveAllImportRows(imp *model.Import) []types.ImportRow {
@@ -100,29 +217,7 @@ func RetrieveAllImportRows(imp *model.Import) []types.ImportRow {
 		return make([]types.ImportRow, 0)
 	}
 
-	validations := make(map[uint]model.Validation)
-	var err error
-
-	if imp.HasErrors() {
-		if imp.Upload.Template.Valid {
-			template, err := types.ConvertRawTemplate(imp.Upload.Template, false, nil, false)
-			if err == nil {
-				for _, templateColumn := range template.TemplateColumns {
-					for _, v := range templateColumn.Validations {
-						validation, err := model.ParseValidation(v.ValidationID, templateColumn.ID.String(), v.Validate, v.Options, v.Message, v.Severity, model.TemplateColumnDataType(templateColumn.DataType))
-						if err == nil {
-							validations[v.ValidationID] = *validation
-						}
-					}
-				}
-			}
-		} else {
-			validations, err = db.GetValidationsMapForImporterUnscoped(imp.ImporterID.String())
-			if err != nil {
-				tf.Log.Errorw("Could not retrieve template by importer to get validations", "import_id", imp.ID, "error", err)
-			}
-		}
-	}
+	validations := getImportValidations(imp)
 
 	rows := make([]types.ImportRow, 0, imp.NumRows.Int64)
 	for offset := 0; ; offset += DefaultPaginationSize {
@@ -135,10 +230,17 @@ func RetrieveAllImportRows(imp *model.Import) []types.ImportRow {
 }
 
 func PaginateImportRows(imp *model.Import, offset, limit int, filter types.Filter) []types.ImportRow {
+	validations := getImportValidations(imp)
+	return paginateImportRowsWithValidations(imp, validations, offset, limit, filter)
+}
+
+// getImportValidations retrieves the validations used to process the import, which are needed to return the messages
+// of the failed validations with the rows
+func getImportValidations(imp *model.Import) map[uint]model.Validation {
 	validations := make(map[uint]model.Validation)
 	var err error
 
-	if imp.HasErrors() {
+	if imp.HasErrors() || imp.HasWarnings() {
 		if imp.Upload.Template.Valid {
 			template, err := types.ConvertRawTemplate(imp.Upload.Template, false, nil, false)
 			if err == nil {
@@ -157,13 +259,17 @@ func PaginateImportRows(imp *model.Import, offset, limit int, filter types.Filte
 				tf.Log.Errorw("Could not retrieve template by importer to get validations", "import_id", imp.ID, "error", err)
 			}
 		}
+		if validations != nil {
+			// Required columns imply a not_blank validation, which isn't stored with the other validations
+			validations[model.RequiredValidationID] = *model.NewRequiredValidation(model.ID{})
+		}
 	}
-
-	return paginateImportRowsWithValidations(imp, validations, offset, limit, filter)
+	return validations
 }
 
 func paginateImportRowsWithValidations(imp *model.Import, validations map[uint]model.Validation, offset, limit int, filter types.Filter) []types.ImportRow {
 	importID := imp.ID.String()
+	listDelimiters := imp.GetListDelimiters()
 	if limit > maxPageSize {
 		tf.Log.Errorw("Attempted to paginate import greater than max page size", "import_id", importID, "page_size", limit)
 		return []types.ImportRow{}
@@ -175,9 +281,9 @@ func paginateImportRowsWithValidations(imp *model.Import, validations map[uint]m
 
 		// No errors, just return the data from import_rows
 		if !imp.HasErrors() {
-			return getImportRows(importID, offset, limit)
+			return getImportRows(importID, offset, limit, validations, listDelimiters)
 		}
-		importRows := getImportRows(importID, offset, limit)
+		importRows := getImportRows(importID, offset, limit, validations, listDelimiters)
 
 		// If all the import rows exist in the expected page size, don't bother querying import_row_errors as no errors
 		// exist for the page, or they have been resolved
@@ -187,7 +293,7 @@ func paginateImportRowsWithValidations(imp *model.Import, validations map[uint]m
 		}
 
 		// Retrieve the rows with errors to combine the results
-		importRowErrors := getImportRowErrors(importID, offset, limit, validations)
+		importRowErrors := getImportRowErrors(importID, offset, limit, validations, listDelimiters)
 		rows := append(importRows, importRowErrors...)
 
 		// Sort the combined rows by the row index
@@ -197,13 +303,13 @@ func paginateImportRowsWithValidations(imp *model.Import, validations map[uint]m
 		return rows
 
 	case types.ImportRowFilterValid:
-		return getImportRows(importID, offset, limit)
+		return getImportRows(importID, offset, limit, validations, listDelimiters)
 
 	case types.ImportRowFilterError:
 		if !imp.HasErrors() {
 			return []types.ImportRow{}
 		}
-		return getImportRowErrors(importID, offset, limit, validations)
+		return getImportRowErrors(importID, offset, limit, validations, listDelimiters)
 
 	default:
 		tf.Log.Errorw("Invalid filter provided to import row pagination", "import_id", importID, "filter", filter)
@@ -211,10 +317,13 @@ func paginateImportRowsWithValidations(imp *model.Import, validations map[uint]m
 	}
 }
 
-func getImportRows(importID string, offset, limit int) []types.ImportRow {
+func getImportRows(importID string, offset, limit int, validations map[uint]model.Validation, listDelimiters map[string]string) []types.ImportRow {
 	iter := tf.Scylla.Query(
 		`select row_index
 					     , values
+					     , raw_values
+					     , warnings
+					     , error_details
 					from import_rows
 					where import_id = ?
 					  and row_index >= ?
@@ -225,9 +334,15 @@ func getImportRows(importID string, offset, limit int) []types.ImportRow {
 	res := make([]types.ImportRow, 0, limit)
 	for i := 0; ; i++ {
 		row := types.ImportRow{}
-		if !iter.Scan(&row.Index, &row.Values) {
+		warnings := make(map[string][]uint)
+		errorDetails := make(ImportRowErrorDetails)
+		if !iter.Scan(&row.Index, &row.Values, &row.RawValues, &warnings, &errorDetails) {
 			break
 		}
+		if len(warnings) != 0 {
+			row.Errors = make(map[string][]types.ImportRowError)
+			addImportRowErrors(row.Errors, warnings, validations, row.Values, listDelimiters, errorDetails)
+		}
 		res = append(res, row)
 	}
 	if err := iter.Close(); err != nil {
@@ -236,11 +351,14 @@ func getImportRows(importID string, offset, limit int) []types.ImportRow {
 	return res
 }
 
-func getImportRowErrors(importID string, offset, limit int, validations map[uint]model.Validation) []types.ImportRow {
+func getImportRowErrors(importID string, offset, limit int, validations map[uint]model.Validation, listDelimiters map[string]string) []types.ImportRow {
 	iter := tf.Scylla.Query(
 		`select row_index
 					     , values
+					     , raw_values
 					     , errors
+					     , warnings
+					     , error_details
 					from import_row_errors
 					where import_id = ?
 					  and row_index >= ?
@@ -252,27 +370,14 @@ func getImportRowErrors(importID string, offset, limit int, validations map[uint
 	for i := 0; ; i++ {
 		row := types.ImportRow{}
 		errors := make(map[string][]uint)
-		if !iter.Scan(&row.Index, &row.Values, &errors) {
+		warnings := make(map[string][]uint)
+		errorDetails := make(ImportRowErrorDetails)
+		if !iter.Scan(&row.Index, &row.Values, &row.RawValues, &errors, &warnings, &errorDetails) {
 			break
 		}
-		// Transform the Scylla errors map values (validation IDs) into ImportRowErrors
 		row.Errors = make(map[string][]types.ImportRowError)
-		for rowKey, validationIDs := range errors {
-			importRowErrors := make([]types.ImportRowError, len(validationIDs))
-			for j, id := range validationIDs {
-				if v, ok := validations[id]; ok {
-					importRowErrors[j] = types.ImportRowError{
-						ValidationID: id,
-						Validate:     v.Validate,
-						Severity:     string(v.Severity),
-						Message:      v.Message,
-					}
-				} else {
-					tf.Log.Warnw("Attempted to set import row error with validation ID that was not provided", "import_id", importID, "validation_id", id, "row_index", row.Index, "row_key", rowKey)
-				}
-			}
-			row.Errors[rowKey] = importRowErrors
-		}
+		addImportRowErrors(row.Errors, errors, validations, row.Values, listDelimiters, errorDetails)
+		addImportRowErrors(row.Errors, warnings, validations, row.Values, listDelimiters, errorDetails)
 		res = append(res, row)
 	}
 	if err := iter.Close(); err != nil {
@@ -336,6 +441,7 @@ func GetScyllaSchemaConfigurationCQL() []string {
 		    import_id  uuid,
 		    row_index int,
 		    values     map<text, text>,
+		    warnings   map<text, frozen<set<int>>>, -- <Row key, Set of Validation IDs with the warn or info severity that failed>
 		    primary key ((import_id),row_index)
 		);`,
 		`create table if not exists import_row_errors (
@@ -345,7 +451,30 @@ func GetScyllaSchemaConfigurationCQL() []string {
 		    values    map<text, text>,             -- <Row key, Cell Value>
 		    -- errors: Holds a set of Validation IDs that failed for a given cell. As errors are resolved the IDs are removed from the set. If all errors are resolved for a cell, the key is removed from the map.
 		    errors    map<text, frozen<set<int>>>, -- <Row key, Set of Validation IDs (stored in Postgres to reference complete validation information and keep this table smaller)>
+		    -- warnings: Holds a set of Validation IDs with the warn or info severity that failed for a given cell. These don't block the import from being submitted.
+		    warnings  map<text, frozen<set<int>>>,
 		    primary key ((import_id),row_index)
 		);`,
 	}
 }
+
+// SchemaColumn is a column added to a table after the table was created
+type SchemaColumn struct {
+	Table  string
+	Column string
+	Type   string
+}
+
+// GetScyllaSchemaColumns returns the columns added to existing tables. Scylla doesn't support "add column if not
+// exists", so they are only added if they don't exist in the table metadata.
+func GetScyllaSchemaColumns() []SchemaColumn {
+	return []SchemaColumn{
+		{Table: "import_rows", Column: "warnings", Type: "map<text, frozen<set<int>>>"},
+		{Table: "import_row_errors", Column: "warnings", Type: "map<text, frozen<set<int>>>"},
+		{Table: "import_rows", Column: "raw_values", Type: "map<text, text>"},
+		{Table: "import_row_errors", Column: "raw_values", Type: "map<text, text>"},
+		// <Row key:Validation ID, JSON details of the failed validation>
+		{Table: "import_rows", Column: "error_details", Type: "map<text, text>"},
+		{Table: "import_row_errors", Column: "error_details", Type: "map<text, text>"},
+	}
+}
@@ -0,0 +1,168 @@
+package scylla
+
+import (
+	"reflect"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/types"
+	"testing"
+)
+
+func colorValidation(t *testing.T) model.Validation {
+	t.Helper()
+	options := jsonb.FromMap(map[string]interface{}{"values": []interface{}{"Red", "Pink", "Purple"}, "suggestions": float64(2)})
+	v, err := model.ParseValidation(1, "", "list", options, "The cell must be a color", "", model.TemplateColumnDataTypeString)
+	if err != nil {
+		t.Fatalf("ParseValidation() returned error: %v", err)
+	}
+	return *v
+}
+
+func TestAddImportRowErrorsSeverities(t *testing.T) {
+	validations := map[uint]model.Validation{
+		1: {ID: 1, Validate: "not_blank", Severity: model.ValidationSeverityError, Message: "The cell must contain a value"},
+		2: {ID: 2, Validate: "email", Severity: model.ValidationSeverityWarn, Message: "Check the email address"},
+		3: {ID: 3, Validate: "regex", Severity: model.ValidationSeverityInfo, Message: "Company emails are preferred"},
+	}
+	rowErrors := make(map[string][]types.ImportRowError)
+	errorDetails := make(ImportRowErrorDetails)
+	addImportRowErrors(rowErrors, map[string][]uint{"name": {1}}, validations, nil, nil, errorDetails)
+	addImportRowErrors(rowErrors, map[string][]uint{"email": {2, 3}}, validations, nil, nil, errorDetails)
+
+	want := map[string][]types.ImportRowError{
+		"name": {{ValidationID: 1, Validate: "not_blank", Severity: "error", Message: "The cell must contain a value"}},
+		"email": {
+			{ValidationID: 2, Validate: "email", Severity: "warn", Message: "Check the email address"},
+			{ValidationID: 3, Validate: "regex", Severity: "info", Message: "Company emails are preferred"},
+		},
+	}
+	if !reflect.DeepEqual(rowErrors, want) {
+		t.Errorf("addImportRowErrors() = %+v, want %+v", rowErrors, want)
+	}
+}
+
+func TestNewImportRowErrorDetail(t *testing.T) {
+	v := colorValidation(t)
+	tests := []struct {
+		name           string
+		cell           string
+		delimiter      string
+		failedElements []int
+		want           types.ImportRowErrorDetail
+	}{
+		{name: "cell", cell: "pnik", want: types.ImportRowErrorDetail{Suggestions: []string{"Pink"}}},
+		{name: "nothing similar", cell: "blue", want: types.ImportRowErrorDetail{Suggestions: []string{}}},
+		{
+			name:           "one failed element",
+			cell:           "red, pnik",
+			delimiter:      ",",
+			failedElements: []int{1},
+			want:           types.ImportRowErrorDetail{FailedElements: []int{1}, Suggestions: []string{"red, Pink"}},
+		},
+		{
+			name:           "several failed elements",
+			cell:           "pnik, rde",
+			delimiter:      ",",
+			failedElements: []int{0, 1},
+			want:           types.ImportRowErrorDetail{FailedElements: []int{0, 1}},
+		},
+		{
+			name:           "failed element no longer in the cell",
+			cell:           "red",
+			delimiter:      ",",
+			failedElements: []int{1},
+			want:           types.ImportRowErrorDetail{FailedElements: []int{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewImportRowErrorDetail(v, tt.cell, tt.delimiter, tt.failedElements); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewImportRowErrorDetail() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewImportRowError(t *testing.T) {
+	v := colorValidation(t)
+	tests := []struct {
+		name      string
+		cell      string
+		delimiter string
+		detail    types.ImportRowErrorDetail
+		want      types.ImportRowError
+	}{
+		{
+			name:   "cell",
+			cell:   "pnik",
+			detail: types.ImportRowErrorDetail{Suggestions: []string{"Pink"}},
+			want:   types.ImportRowError{ValidationID: 1, Validate: "list", Severity: "error", Message: "The cell must be a color", Suggestions: []string{"Pink"}},
+		},
+		{
+			name:      "list",
+			cell:      "red, pnik, rde",
+			delimiter: ",",
+			detail:    types.ImportRowErrorDetail{FailedElements: []int{1, 2}},
+			want: types.ImportRowError{
+				ValidationID:   1,
+				Validate:       "list",
+				Severity:       "error",
+				Message:        "The cell must be a color (elements 2: 'pnik', 3: 'rde')",
+				FailedElements: []int{1, 2},
+			},
+		},
+		{
+			name:      "failed elements no longer in the cell are ignored",
+			cell:      "pnik",
+			delimiter: ",",
+			detail:    types.ImportRowErrorDetail{FailedElements: []int{0, 3, -1}},
+			want: types.ImportRowError{
+				ValidationID:   1,
+				Validate:       "list",
+				Severity:       "error",
+				Message:        "The cell must be a color (element 1: 'pnik')",
+				FailedElements: []int{0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewImportRowError(v, tt.cell, tt.delimiter, tt.detail); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewImportRowError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportRowErrorDetails(t *testing.T) {
+	rowErrors := map[string][]types.ImportRowError{
+		"color":  {{ValidationID: 1, Suggestions: []string{"Pink"}}},
+		"colors": {{ValidationID: 1, FailedElements: []int{0, 2}}, {ValidationID: 2}},
+	}
+	errorDetails := NewImportRowErrorDetails(rowErrors)
+
+	// Only the errors with details are stored
+	want := ImportRowErrorDetails{
+		"color:1":  `{"suggestions":["Pink"]}`,
+		"colors:1": `{"failed_elements":[0,2]}`,
+	}
+	if !reflect.DeepEqual(errorDetails, want) {
+		t.Errorf("NewImportRowErrorDetails() = %v, want %v", errorDetails, want)
+	}
+
+	tests := []struct {
+		rowKey       string
+		validationID uint
+		want         types.ImportRowErrorDetail
+	}{
+		{rowKey: "color", validationID: 1, want: types.ImportRowErrorDetail{Suggestions: []string{"Pink"}}},
+		{rowKey: "colors", validationID: 1, want: types.ImportRowErrorDetail{FailedElements: []int{0, 2}}},
+		{rowKey: "colors", validationID: 2, want: types.ImportRowErrorDetail{}},
+		{rowKey: "name", validationID: 1, want: types.ImportRowErrorDetail{}},
+	}
+	for _, tt := range tests {
+		if got := errorDetails.Get(tt.rowKey, tt.validationID); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Get(%s, %d) = %+v, want %+v", tt.rowKey, tt.validationID, got, tt.want)
+		}
+	}
+}
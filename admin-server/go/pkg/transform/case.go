@@ -0,0 +1,36 @@
+package transform
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"strings"
+)
+
+type Case string
+
+const (
+	CaseUpper Case = "upper"
+	CaseLower Case = "lower"
+	CaseTitle Case = "title"
+)
+
+// CaseTransformer converts the cell to uppercase, lowercase or title case (i.e. "mary ann" to "Mary Ann")
+type CaseTransformer struct {
+	Case Case
+}
+
+func (t *CaseTransformer) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (t CaseTransformer) Transform(cell string) string {
+	switch t.Case {
+	case CaseUpper:
+		return strings.ToUpper(cell)
+	case CaseLower:
+		return strings.ToLower(cell)
+	case CaseTitle:
+		return cases.Title(language.Und).String(cell)
+	}
+	return cell
+}
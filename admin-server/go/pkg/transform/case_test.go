@@ -0,0 +1,27 @@
+package transform
+
+import "testing"
+
+func TestCaseTransformer(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Case
+		cell string
+		want string
+	}{
+		{name: "upper", c: CaseUpper, cell: "Mary Ann", want: "MARY ANN"},
+		{name: "upper accents", c: CaseUpper, cell: "josé", want: "JOSÉ"},
+		{name: "lower", c: CaseLower, cell: "Mary ANN", want: "mary ann"},
+		{name: "title", c: CaseTitle, cell: "mary ann", want: "Mary Ann"},
+		{name: "title lowercases the rest of the word", c: CaseTitle, cell: "MARY o'NEIL", want: "Mary O'neil"},
+		{name: "unknown case", c: Case("snake"), cell: "Mary Ann", want: "Mary Ann"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &CaseTransformer{Case: tt.c}
+			if got := tr.Transform(tt.cell); got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
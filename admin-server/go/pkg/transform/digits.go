@@ -0,0 +1,22 @@
+package transform
+
+import (
+	"strings"
+)
+
+// StripNonDigitsTransformer removes every character that isn't a digit from 0 to 9, i.e. "(555) 123-4567" to
+// "5551234567"
+type StripNonDigitsTransformer struct{}
+
+func (t *StripNonDigitsTransformer) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (t StripNonDigitsTransformer) Transform(cell string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, cell)
+}
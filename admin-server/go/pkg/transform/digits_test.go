@@ -0,0 +1,23 @@
+package transform
+
+import "testing"
+
+func TestStripNonDigitsTransformer(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{cell: "(555) 123-4567", want: "5551234567"},
+		{cell: "+1 555.123.4567", want: "15551234567"},
+		{cell: "12.50", want: "1250"},
+		{cell: "٣٤٥", want: ""},
+		{cell: "abc", want: ""},
+		{cell: "", want: ""},
+	}
+	tr := &StripNonDigitsTransformer{}
+	for _, tt := range tests {
+		if got := tr.Transform(tt.cell); got != tt.want {
+			t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
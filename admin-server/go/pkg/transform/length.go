@@ -0,0 +1,92 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tableflow/go/pkg/util"
+	"unicode/utf8"
+)
+
+const (
+	PadSideLeft  = "left"
+	PadSideRight = "right"
+)
+
+// padMaxLength limits the length cells are padded to, as every cell of the column is padded
+const padMaxLength = 1000
+
+// PadTransformer pads cells shorter than the length with the character, on the left unless the side is provided.
+// Blank cells aren't padded.
+// Example options: {"length": 5, "character": "0", "side": "left"}
+type PadTransformer struct {
+	Length    int
+	Character string
+	Left      bool
+}
+
+func (t *PadTransformer) Initialize(options interface{}) error {
+	m, err := optionsMap(options)
+	if err != nil {
+		return err
+	}
+	if t.Length, err = lengthOption(m); err != nil {
+		return err
+	}
+	if t.Length > padMaxLength {
+		return fmt.Errorf("length cannot be greater than %d", padMaxLength)
+	}
+	t.Character = " "
+	if v, exists := m["character"]; exists && v != nil {
+		character, _ := v.(string)
+		if utf8.RuneCountInString(character) != 1 {
+			return errors.New("character must be a single character")
+		}
+		t.Character = character
+	}
+	t.Left = true
+	if v, exists := m["side"]; exists && v != nil {
+		side, _ := v.(string)
+		switch side {
+		case PadSideLeft:
+		case PadSideRight:
+			t.Left = false
+		default:
+			return errors.New("side must be left or right")
+		}
+	}
+	return nil
+}
+
+func (t PadTransformer) Transform(cell string) string {
+	n := t.Length - utf8.RuneCountInString(cell)
+	if n <= 0 || util.IsBlankUnicode(cell) {
+		return cell
+	}
+	if t.Left {
+		return strings.Repeat(t.Character, n) + cell
+	}
+	return cell + strings.Repeat(t.Character, n)
+}
+
+// TruncateTransformer shortens cells longer than the length
+// Example options: {"length": 255}
+type TruncateTransformer struct {
+	Length int
+}
+
+func (t *TruncateTransformer) Initialize(options interface{}) error {
+	m, err := optionsMap(options)
+	if err != nil {
+		return err
+	}
+	t.Length, err = lengthOption(m)
+	return err
+}
+
+func (t TruncateTransformer) Transform(cell string) string {
+	if utf8.RuneCountInString(cell) <= t.Length {
+		return cell
+	}
+	return string([]rune(cell)[:t.Length])
+}
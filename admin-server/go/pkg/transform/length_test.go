@@ -0,0 +1,58 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPadTransformer(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		cell    string
+		want    string
+	}{
+		{name: "left with zeros", options: map[string]interface{}{"length": float64(5), "character": "0"}, cell: "42", want: "00042"},
+		{name: "right", options: map[string]interface{}{"length": float64(5), "character": "-", "side": "right"}, cell: "ab", want: "ab---"},
+		{name: "default character", options: map[string]interface{}{"length": float64(4)}, cell: "ab", want: "  ab"},
+		{name: "multi-byte character", options: map[string]interface{}{"length": float64(4), "character": "·"}, cell: "é", want: "···é"},
+		{name: "already long enough", options: map[string]interface{}{"length": float64(3), "character": "0"}, cell: "12345", want: "12345"},
+		{name: "exact length", options: map[string]interface{}{"length": float64(3), "character": "0"}, cell: "123", want: "123"},
+		{name: "blank cell", options: map[string]interface{}{"length": float64(3), "character": "0"}, cell: "", want: ""},
+		{name: "max length", options: map[string]interface{}{"length": float64(padMaxLength), "character": "0"}, cell: "1", want: strings.Repeat("0", padMaxLength-1) + "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &PadTransformer{}
+			if err := tr.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			if got := tr.Transform(tt.cell); got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPadTransformerInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+	}{
+		{name: "length too large", options: map[string]interface{}{"length": float64(padMaxLength + 1)}},
+		{name: "missing length", options: map[string]interface{}{"character": "0"}},
+		{name: "negative length", options: map[string]interface{}{"length": float64(-1)}},
+		{name: "several characters", options: map[string]interface{}{"length": float64(5), "character": "00"}},
+		{name: "empty character", options: map[string]interface{}{"length": float64(5), "character": ""}},
+		{name: "invalid side", options: map[string]interface{}{"length": float64(5), "side": "center"}},
+		{name: "not an object", options: float64(5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &PadTransformer{}
+			if err := tr.Initialize(tt.options); err == nil {
+				t.Errorf("Initialize(%v) returned no error", tt.options)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package transform
+
+import (
+	"errors"
+	"regexp"
+)
+
+// RegexReplaceTransformer replaces the matches of the pattern with the replacement, which can reference the capture
+// groups of the pattern (i.e. $1)
+// Example options: {"pattern": "^(\\d{3})(\\d{4})$", "replacement": "$1-$2"}
+type RegexReplaceTransformer struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+func (t *RegexReplaceTransformer) Initialize(options interface{}) error {
+	m, err := optionsMap(options)
+	if err != nil {
+		return err
+	}
+	pattern, _ := m["pattern"].(string)
+	if len(pattern) == 0 {
+		return errors.New("pattern is required")
+	}
+	if t.Pattern, err = regexp.Compile(pattern); err != nil {
+		return errors.New("pattern must be a valid regular expression")
+	}
+	if v, exists := m["replacement"]; exists && v != nil {
+		var ok bool
+		if t.Replacement, ok = v.(string); !ok {
+			return errors.New("replacement must be a string")
+		}
+	}
+	return nil
+}
+
+func (t RegexReplaceTransformer) Transform(cell string) string {
+	if t.Pattern == nil {
+		return cell
+	}
+	return t.Pattern.ReplaceAllString(cell, t.Replacement)
+}
@@ -0,0 +1,57 @@
+package transform
+
+import "testing"
+
+func TestRegexReplaceTransformer(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		cell    string
+		want    string
+	}{
+		{name: "capture groups", options: map[string]interface{}{"pattern": `^(\d{3})(\d{4})$`, "replacement": "$1-$2"}, cell: "5551234", want: "555-1234"},
+		{name: "no match", options: map[string]interface{}{"pattern": `^(\d{3})(\d{4})$`, "replacement": "$1-$2"}, cell: "555-1234", want: "555-1234"},
+		{name: "every match", options: map[string]interface{}{"pattern": `\s+`, "replacement": "_"}, cell: "a b  c", want: "a_b_c"},
+		{name: "no replacement removes the matches", options: map[string]interface{}{"pattern": `[^a-z]`}, cell: "a1b2c3", want: "abc"},
+		{name: "null replacement", options: map[string]interface{}{"pattern": `x`, "replacement": nil}, cell: "axb", want: "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &RegexReplaceTransformer{}
+			if err := tr.Initialize(tt.options); err != nil {
+				t.Fatalf("Initialize(%v) returned error: %v", tt.options, err)
+			}
+			if got := tr.Transform(tt.cell); got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexReplaceTransformerInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options interface{}
+	}{
+		{name: "no options", options: nil},
+		{name: "missing pattern", options: map[string]interface{}{"replacement": "x"}},
+		{name: "invalid pattern", options: map[string]interface{}{"pattern": "(a"}},
+		{name: "replacement not a string", options: map[string]interface{}{"pattern": "a", "replacement": float64(1)}},
+		{name: "not an object", options: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &RegexReplaceTransformer{}
+			if err := tr.Initialize(tt.options); err == nil {
+				t.Errorf("Initialize(%v) returned no error", tt.options)
+			}
+		})
+	}
+}
+
+func TestRegexReplaceTransformerUninitialized(t *testing.T) {
+	tr := RegexReplaceTransformer{}
+	if got := tr.Transform("abc"); got != "abc" {
+		t.Errorf("Transform(%q) = %q, want the cell unchanged", "abc", got)
+	}
+}
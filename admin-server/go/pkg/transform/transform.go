@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"fmt"
+	"tableflow/go/pkg/model/jsonb"
+)
+
+// Transformer changes the value of a cell before it is validated, i.e. to clean up the formatting of the data
+type Transformer interface {
+	Initialize(options interface{}) error
+	Transform(cell string) string
+}
+
+var transformers = map[string]func() Transformer{
+	"trim":                func() Transformer { return &TrimTransformer{} },
+	"collapse_whitespace": func() Transformer { return &CollapseWhitespaceTransformer{} },
+	"upper":               func() Transformer { return &CaseTransformer{Case: CaseUpper} },
+	"lower":               func() Transformer { return &CaseTransformer{Case: CaseLower} },
+	"title":               func() Transformer { return &CaseTransformer{Case: CaseTitle} },
+	"strip_non_digits":    func() Transformer { return &StripNonDigitsTransformer{} },
+	"regex_replace":       func() Transformer { return &RegexReplaceTransformer{} },
+	"pad":                 func() Transformer { return &PadTransformer{} },
+	"truncate":            func() Transformer { return &TruncateTransformer{} },
+}
+
+func Parse(transform string, options jsonb.JSONB) (Transformer, error) {
+	newTransformer, ok := transformers[transform]
+	if !ok {
+		return nil, fmt.Errorf("The transform %s is invalid", transform)
+	}
+	t := newTransformer()
+	if err := t.Initialize(options.Data); err != nil {
+		return nil, fmt.Errorf("Invalid %s transform options: %s", transform, err.Error())
+	}
+	return t, nil
+}
+
+// IsTransform returns true if the transform is supported
+func IsTransform(transform string) bool {
+	_, ok := transformers[transform]
+	return ok
+}
+
+// optionsMap returns the options as an object, or an empty object if no options are provided
+func optionsMap(options interface{}) (map[string]interface{}, error) {
+	if options == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := options.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object")
+	}
+	return m, nil
+}
+
+// lengthOption returns the length option, which must be a positive whole number
+func lengthOption(m map[string]interface{}) (int, error) {
+	length, ok := m["length"].(float64)
+	if !ok || length < 1 || length != float64(int(length)) {
+		return 0, fmt.Errorf("length must be a whole number greater than 0")
+	}
+	return int(length), nil
+}
@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform string
+		options   jsonb.JSONB
+		cell      string
+		want      string
+		wantErr   bool
+	}{
+		{name: "trim", transform: "trim", cell: " a ", want: "a"},
+		{name: "collapse whitespace", transform: "collapse_whitespace", cell: " a  b ", want: "a b"},
+		{name: "upper", transform: "upper", cell: "ab", want: "AB"},
+		{name: "lower", transform: "lower", cell: "AB", want: "ab"},
+		{name: "title", transform: "title", cell: "ab cd", want: "Ab Cd"},
+		{name: "strip non-digits", transform: "strip_non_digits", cell: "a1b2", want: "12"},
+		{
+			name:      "regex replace",
+			transform: "regex_replace",
+			options:   jsonb.FromMap(map[string]interface{}{"pattern": "b", "replacement": "c"}),
+			cell:      "abc",
+			want:      "acc",
+		},
+		{name: "pad", transform: "pad", options: jsonb.FromMap(map[string]interface{}{"length": float64(3), "character": "0"}), cell: "7", want: "007"},
+		{name: "truncate", transform: "truncate", options: jsonb.FromMap(map[string]interface{}{"length": float64(2)}), cell: "abc", want: "ab"},
+		{name: "unknown transform", transform: "reverse", wantErr: true},
+		{name: "invalid options", transform: "regex_replace", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := Parse(tt.transform, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.transform, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := tr.Transform(tt.cell); got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTransform(t *testing.T) {
+	for _, transform := range []string{"trim", "upper", "regex_replace", "truncate"} {
+		if !IsTransform(transform) {
+			t.Errorf("IsTransform(%q) = false, want true", transform)
+		}
+	}
+	for _, transform := range []string{"", "reverse", "Trim"} {
+		if IsTransform(transform) {
+			t.Errorf("IsTransform(%q) = true, want false", transform)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package transform
+
+import (
+	"strings"
+)
+
+// TrimTransformer removes the whitespace at the start and end of the cell
+type TrimTransformer struct{}
+
+func (t *TrimTransformer) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (t TrimTransformer) Transform(cell string) string {
+	return strings.TrimSpace(cell)
+}
+
+// CollapseWhitespaceTransformer replaces each run of whitespace (including line breaks) with a single space and trims
+// the cell
+type CollapseWhitespaceTransformer struct{}
+
+func (t *CollapseWhitespaceTransformer) Initialize(_ interface{}) error {
+	return nil
+}
+
+func (t CollapseWhitespaceTransformer) Transform(cell string) string {
+	return strings.Join(strings.Fields(cell), " ")
+}
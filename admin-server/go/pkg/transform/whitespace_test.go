@@ -0,0 +1,39 @@
+package transform
+
+import "testing"
+
+func TestTrimTransformer(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{cell: "  Mary Ann \t", want: "Mary Ann"},
+		{cell: "\nMary\n", want: "Mary"},
+		{cell: " Mary ", want: "Mary"},
+		{cell: "   ", want: ""},
+	}
+	tr := &TrimTransformer{}
+	for _, tt := range tests {
+		if got := tr.Transform(tt.cell); got != tt.want {
+			t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestCollapseWhitespaceTransformer(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{cell: "  Mary   Ann ", want: "Mary Ann"},
+		{cell: "123 Main St\r\nApt 4", want: "123 Main St Apt 4"},
+		{cell: "a\t\tb", want: "a b"},
+		{cell: " \n ", want: ""},
+	}
+	tr := &CollapseWhitespaceTransformer{}
+	for _, tt := range tests {
+		if got := tr.Transform(tt.cell); got != tt.want {
+			t.Errorf("Transform(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/guregu/null"
 	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"strconv"
 	"strings"
 	"tableflow/go/pkg/evaluator"
 	"tableflow/go/pkg/model"
@@ -32,14 +37,20 @@ type Template struct {
 }
 
 type TemplateColumn struct {
-	ID                model.ID      `json:"id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
-	Name              string        `json:"name" example:"First Name"`
-	Key               string        `json:"key" example:"email"`
-	Required          bool          `json:"required" example:"false"`
-	DataType          string        `json:"data_type" example:"string"`
-	Description       string        `json:"description" example:"The first name"`
-	Validations       []*Validation `json:"validations,omitempty"`
-	SuggestedMappings []string      `json:"suggested_mappings" swaggertype:"array,string" example:"first_name"`
+	ID                model.ID                       `json:"id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+	Name              string                         `json:"name" example:"First Name"`
+	Key               string                         `json:"key" example:"email"`
+	Required          bool                           `json:"required" example:"false"`
+	DataType          string                         `json:"data_type" example:"string"`
+	Description       string                         `json:"description" example:"The first name"`
+	Validations       []*Validation                  `json:"validations,omitempty"`
+	SuggestedMappings []string                       `json:"suggested_mappings" swaggertype:"array,string" example:"first_name"`
+	Synonyms          []string                       `json:"synonyms,omitempty" swaggertype:"array,string" example:"vorname,nombre"`
+	Transforms        model.TemplateColumnTransforms `json:"transforms,omitempty"`
+	DefaultValue      *model.TemplateColumnDefault   `json:"default_value,omitempty"`
+	Formula           string                         `json:"formula,omitempty" example:"first_name + ' ' + last_name"`
+	IsList            bool                           `json:"is_list,omitempty" example:"false"`
+	Delimiter         string                         `json:"delimiter,omitempty" example:";"`
 }
 
 type Validation struct {
@@ -53,35 +64,67 @@ type Validation struct {
 /* ---------------------------  Upload types  --------------------------- */
 
 type Upload struct {
-	ID                    model.ID       `json:"id" swaggertype:"string" example:"50ca61e1-f683-4b03-9ec4-4b3adb592bf1"`
-	TusID                 string         `json:"tus_id" example:"ee715c254ee61855b465ed61be930487"`
-	ImporterID            model.ID       `json:"importer_id" swaggertype:"string" example:"6de452a2-bd1f-4cb3-b29b-0f8a2e3d9353"`
-	FileName              null.String    `json:"file_name" swaggertype:"string" example:"example.csv"`
-	FileType              null.String    `json:"file_type" swaggertype:"string" example:"text/csv"`
-	FileExtension         null.String    `json:"file_extension" swaggertype:"string" example:"csv"`
-	FileSize              null.Int       `json:"file_size" swaggertype:"integer" example:"1024"`
-	Metadata              jsonb.JSONB    `json:"metadata" swaggertype:"string" example:"{\"user_id\": 1234}"`
-	Template              *Template      `json:"template"` // Set if the user passes in a template to the SDK, which overrides the template on the importer
-	IsStored              bool           `json:"is_stored" example:"false"`
-	HeaderRowIndex        null.Int       `json:"header_row_index" swaggertype:"integer" example:"0"`
-	MatchedHeaderRowIndex null.Int       `json:"matched_header_row_index" swaggertype:"integer" example:"0"`
-	SheetList             []string       `json:"sheet_list" swaggertype:"array,string" example:"Sheet 1"`
-	CreatedAt             model.NullTime `json:"created_at" swaggertype:"integer" example:"1682366228"`
+	ID                         model.ID                   `json:"id" swaggertype:"string" example:"50ca61e1-f683-4b03-9ec4-4b3adb592bf1"`
+	TusID                      string                     `json:"tus_id" example:"ee715c254ee61855b465ed61be930487"`
+	ImporterID                 model.ID                   `json:"importer_id" swaggertype:"string" example:"6de452a2-bd1f-4cb3-b29b-0f8a2e3d9353"`
+	FileName                   null.String                `json:"file_name" swaggertype:"string" example:"example.csv"`
+	FileType                   null.String                `json:"file_type" swaggertype:"string" example:"text/csv"`
+	FileExtension              null.String                `json:"file_extension" swaggertype:"string" example:"csv"`
+	FileSize                   null.Int                   `json:"file_size" swaggertype:"integer" example:"1024"`
+	Metadata                   jsonb.JSONB                `json:"metadata" swaggertype:"string" example:"{\"user_id\": 1234}"`
+	Template                   *Template                  `json:"template"` // Set if the user passes in a template to the SDK, which overrides the template on the importer
+	IsStored                   bool                       `json:"is_stored" example:"false"`
+	HeaderRowIndex             null.Int                   `json:"header_row_index" swaggertype:"integer" example:"0"`
+	HeaderRowEndIndex          null.Int                   `json:"header_row_end_index" swaggertype:"integer" example:"1"`
+	MatchedHeaderRowIndex      null.Int                   `json:"matched_header_row_index" swaggertype:"integer" example:"0"`
+	MatchedHeaderRowConfidence null.Float                 `json:"matched_header_row_confidence" swaggertype:"number" example:"0.92"`
+	SheetList                  []string                   `json:"sheet_list" swaggertype:"array,string" example:"Sheet 1"`
+	ColumnMappings             model.UploadColumnMappings `json:"column_mappings"`
+	CreatedAt                  model.NullTime             `json:"created_at" swaggertype:"integer" example:"1682366228"`
 
 	UploadRows    []UploadRow     `json:"upload_rows"`
 	UploadColumns []*UploadColumn `json:"upload_columns"`
 }
 
 type UploadColumn struct {
-	ID                        model.ID       `json:"id" swaggertype:"string" example:"3c79e7fd-1018-4a27-8b86-9cee84221cd8"`
-	Name                      string         `json:"name" example:"Work Email"`
-	Index                     int            `json:"index" example:"0"`
-	SampleData                pq.StringArray `json:"sample_data" gorm:"type:text[]" swaggertype:"array,string" example:"test@example.com"`
-	SuggestedTemplateColumnID model.ID       `json:"suggested_template_column_id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+	ID                         model.ID                `json:"id" swaggertype:"string" example:"3c79e7fd-1018-4a27-8b86-9cee84221cd8"`
+	Name                       string                  `json:"name" example:"Work Email"`
+	Index                      int                     `json:"index" example:"0"`
+	SampleData                 pq.StringArray          `json:"sample_data" gorm:"type:text[]" swaggertype:"array,string" example:"test@example.com"`
+	SuggestedTemplateColumnID  model.ID                `json:"suggested_template_column_id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+	InferredDataType           string                  `json:"inferred_data_type,omitempty" example:"number"`
+	InferredDataTypeConfidence float64                 `json:"inferred_data_type_confidence,omitempty" example:"0.98"`
+	MatchCandidates            []*ColumnMatchCandidate `json:"match_candidates,omitempty"`
 }
 
+// ColumnMatchCandidate is a template column an upload column could be mapped to. The confidence combines the name
+// score (how closely the header matches the name or suggested mappings of the template column) with the value score
+// (the fraction of the sample data that passes the data type and validations of the template column), if the template
+// column has validations to score the values with.
+type ColumnMatchCandidate struct {
+	TemplateColumnID model.ID   `json:"template_column_id" swaggertype:"string" example:"a1ed136d-33ce-4b7e-a7a4-8a5ccfe54cd5"`
+	Confidence       float64    `json:"confidence" example:"0.97"`
+	Learned          bool       `json:"learned" example:"false"`
+	NameScore        float64    `json:"name_score" example:"1"`
+	ValueScore       null.Float `json:"value_score" swaggertype:"number" example:"0.9"`
+}
+
+// UploadColumnMappingRequest is the column mapping with any splits or merges. The column mapping can also be sent
+// without the splits and merges as an object of upload column IDs to template column IDs. On schemaless uploads, the
+// data types are the types accepted for the destination keys (i.e. the inferred data types of the upload columns),
+// otherwise the columns are strings.
+type UploadColumnMappingRequest struct {
+	Columns   map[string]string          `json:"columns"`
+	Splits    []*model.UploadColumnSplit `json:"splits"`
+	Merges    []*model.UploadColumnMerge `json:"merges"`
+	DataTypes map[string]string          `json:"data_types"`
+}
+
+// UploadHeaderRowSelection is the header row of the upload. If the header spans several rows (i.e. "Q1" over
+// "Revenue" and "Cost"), the end index is the last header row.
 type UploadHeaderRowSelection struct {
-	Index *int `json:"index" example:"0"`
+	Index    *int `json:"index" example:"0"`
+	EndIndex *int `json:"end_index,omitempty" example:"1"`
 }
 
 type UploadRow struct {
@@ -103,6 +146,8 @@ type Import struct {
 	HasErrors          bool                `json:"has_errors" example:"false"`
 	NumErrorRows       null.Int            `json:"num_error_rows" swaggertype:"integer" example:"32"`
 	NumValidRows       null.Int            `json:"num_valid_rows" swaggertype:"integer" example:"224"`
+	NumWarningRows     null.Int            `json:"num_warning_rows" swaggertype:"integer" example:"12"`
+	NumInfoRows        null.Int            `json:"num_info_rows" swaggertype:"integer" example:"4"`
 	CreatedAt          model.NullTime      `json:"created_at" swaggertype:"integer" example:"1682366228"`
 	UpdatedAt          model.NullTime      `json:"updated_at" swaggertype:"integer" example:"1682366228"`
 	Error              null.String         `json:"error,omitempty" swaggerignore:"true"`
@@ -115,24 +160,38 @@ type ImportData struct {
 	Rows       []ImportRow `json:"rows"`
 }
 
+// ImportRow Errors contains the failed validations of every severity, only the error severity blocks the import from
+// being submitted. RawValues contains the original values of the cells that were changed by the column transforms or
+// the validations.
 type ImportRow struct {
-	Index  int                         `json:"index" example:"0"`
-	Values map[string]string           `json:"values"`
-	Errors map[string][]ImportRowError `json:"errors,omitempty"`
+	Index     int                         `json:"index" example:"0"`
+	Values    map[string]string           `json:"values"`
+	RawValues map[string]string           `json:"raw_values,omitempty"`
+	Errors    map[string][]ImportRowError `json:"errors,omitempty"`
 }
 
 // ImportRowResponse used to return values externally in the data type expected
 type ImportRowResponse struct {
-	Index  int                         `json:"index" example:"0"`
-	Values map[string]interface{}      `json:"values"`
-	Errors map[string][]ImportRowError `json:"errors,omitempty"`
+	Index     int                         `json:"index" example:"0"`
+	Values    map[string]interface{}      `json:"values"`
+	RawValues map[string]string           `json:"raw_values,omitempty"`
+	Errors    map[string][]ImportRowError `json:"errors,omitempty"`
 }
 
 type ImportRowError struct {
-	ValidationID uint   `json:"-"`
-	Validate     string `json:"validate"`
-	Severity     string `json:"severity"`
-	Message      string `json:"message"`
+	ValidationID   uint     `json:"-"`
+	Validate       string   `json:"validate"`
+	Severity       string   `json:"severity"`
+	Message        string   `json:"message"`
+	Suggestions    []string `json:"suggestions,omitempty"`
+	FailedElements []int    `json:"failed_elements,omitempty"`
+}
+
+// ImportRowErrorDetail is stored with a validation that didn't pass on a cell when the cell is evaluated, so the error
+// can be returned with the row without evaluating the cell again
+type ImportRowErrorDetail struct {
+	FailedElements []int    `json:"failed_elements,omitempty"`
+	Suggestions    []string `json:"suggestions,omitempty"`
 }
 
 type ImportCell struct {
@@ -142,11 +201,13 @@ type ImportCell struct {
 }
 
 type ImportCellEditResponse struct {
-	NumRows      null.Int  `json:"num_rows" swaggertype:"integer" example:"256"`
-	NumValidRows null.Int  `json:"num_valid_rows" swaggertype:"integer" example:"224"`
-	NumErrorRows null.Int  `json:"num_error_rows" swaggertype:"integer" example:"32"`
-	HasErrors    bool      `json:"has_errors" example:"false"`
-	Row          ImportRow `json:"row,omitempty"`
+	NumRows        null.Int  `json:"num_rows" swaggertype:"integer" example:"256"`
+	NumValidRows   null.Int  `json:"num_valid_rows" swaggertype:"integer" example:"224"`
+	NumErrorRows   null.Int  `json:"num_error_rows" swaggertype:"integer" example:"32"`
+	NumWarningRows null.Int  `json:"num_warning_rows" swaggertype:"integer" example:"12"`
+	NumInfoRows    null.Int  `json:"num_info_rows" swaggertype:"integer" example:"4"`
+	HasErrors      bool      `json:"has_errors" example:"false"`
+	Row            ImportRow `json:"row,omitempty"`
 }
 
 func ConvertUpload(upload *model.Upload, uploadRows []UploadRow) (*Upload, error) {
@@ -156,11 +217,13 @@ func ConvertUpload(upload *model.Upload, uploadRows []UploadRow) (*Upload, error
 	importerUploadColumns := make([]*UploadColumn, len(upload.UploadColumns))
 	for n, uc := range upload.UploadColumns {
 		importerUploadColumns[n] = &UploadColumn{
-			ID:                        uc.ID,
-			Name:                      uc.Name,
-			Index:                     uc.Index,
-			SampleData:                uc.SampleData,
-			SuggestedTemplateColumnID: uc.TemplateColumnID,
+			ID:                         uc.ID,
+			Name:                       uc.Name,
+			Index:                      uc.Index,
+			SampleData:                 uc.SampleData,
+			SuggestedTemplateColumnID:  uc.TemplateColumnID,
+			InferredDataType:           uc.InferredDataType.String,
+			InferredDataTypeConfidence: uc.InferredDataTypeConfidence.Float64,
 		}
 	}
 	uploadTemplate, err := ConvertRawTemplate(upload.Template, false, nil, false)
@@ -169,27 +232,40 @@ func ConvertUpload(upload *model.Upload, uploadRows []UploadRow) (*Upload, error
 		return nil, err
 	}
 	importerUpload := &Upload{
-		ID:                    upload.ID,
-		TusID:                 upload.TusID,
-		ImporterID:            upload.ImporterID,
-		FileName:              upload.FileName,
-		FileType:              upload.FileType,
-		FileExtension:         upload.FileExtension,
-		FileSize:              upload.FileSize,
-		Metadata:              upload.Metadata,
-		Template:              uploadTemplate,
-		IsStored:              upload.IsStored,
-		HeaderRowIndex:        upload.HeaderRowIndex,
-		MatchedHeaderRowIndex: upload.MatchedHeaderRowIndex,
-		SheetList:             upload.SheetList,
-		CreatedAt:             upload.CreatedAt,
-		UploadColumns:         importerUploadColumns,
-		UploadRows:            uploadRows,
+		ID:                         upload.ID,
+		TusID:                      upload.TusID,
+		ImporterID:                 upload.ImporterID,
+		FileName:                   upload.FileName,
+		FileType:                   upload.FileType,
+		FileExtension:              upload.FileExtension,
+		FileSize:                   upload.FileSize,
+		Metadata:                   upload.Metadata,
+		Template:                   uploadTemplate,
+		IsStored:                   upload.IsStored,
+		HeaderRowIndex:             upload.HeaderRowIndex,
+		HeaderRowEndIndex:          upload.HeaderRowEndIndex,
+		MatchedHeaderRowIndex:      upload.MatchedHeaderRowIndex,
+		MatchedHeaderRowConfidence: upload.MatchedHeaderRowConfidence,
+		SheetList:                  upload.SheetList,
+		ColumnMappings:             upload.ColumnMappings,
+		CreatedAt:                  upload.CreatedAt,
+		UploadColumns:              importerUploadColumns,
+		UploadRows:                 uploadRows,
 	}
 	return importerUpload, nil
 }
 
 func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidateTypes map[string]bool, failOnNotAllowedType bool) (*Template, error) {
+	return convertRawTemplate(rawTemplate, isCreation, allowedValidateTypes, failOnNotAllowedType, nil)
+}
+
+// ConvertRawTemplateColumns converts columns to add to an existing template. The row validations and formulas of the
+// columns can reference the keys of the existing template columns as well as the keys of the new columns.
+func ConvertRawTemplateColumns(rawTemplate jsonb.JSONB, allowedValidateTypes map[string]bool, existingColumns []*model.TemplateColumn) (*Template, error) {
+	return convertRawTemplate(rawTemplate, true, allowedValidateTypes, true, existingColumns)
+}
+
+func convertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidateTypes map[string]bool, failOnNotAllowedType bool, existingColumns []*model.TemplateColumn) (*Template, error) {
 	if !rawTemplate.Valid {
 		// No template provided, this means the template from the importer will be used
 		return nil, nil
@@ -215,6 +291,14 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 	seenSuggestedMappings := make(map[string]bool)
 	var generatedValidationID uint = 1
 
+	// Keys referenced by row validations, checked once all the columns are parsed
+	type referencedKey struct {
+		columnKey string
+		validate  string
+		key       string
+	}
+	var referencedKeys []referencedKey
+
 	for _, item := range columnSlice {
 		columnMap, ok := item.(map[string]interface{})
 		if !ok {
@@ -227,6 +311,9 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 		required, _ := columnMap["required"].(bool)
 		dataTypeStr, _ := columnMap["data_type"].(string)
 		description, _ := columnMap["description"].(string)
+		formula, _ := columnMap["formula"].(string)
+		isList, _ := columnMap["is_list"].(bool)
+		delimiter, _ := columnMap["delimiter"].(string)
 		suggestedMappings := make([]string, 0)
 		validations := make([]*Validation, 0)
 
@@ -275,10 +362,30 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 			}
 		}
 
+		// Synonyms, unlike suggested mappings, can be shared by several columns and are matched regardless of accents
+		var synonyms []string
+		if synonymsInterface, ok := columnMap["synonyms"].([]interface{}); ok {
+			for _, v := range synonymsInterface {
+				if synonym, ok := v.(string); ok {
+					synonyms = append(synonyms, synonym)
+				}
+			}
+			if synonyms, err = model.ParseSynonyms(synonyms); err != nil {
+				return nil, fmt.Errorf("Invalid template: %s", err.Error())
+			}
+		}
+
 		if isCreation {
 			id = model.NewID().String()
 
-			if evaluator.IsDataTypeEvaluator(string(dataType)) {
+			// The validator of the column data type can be provided to set its options, otherwise add the default
+			validationsInterface, _ := columnMap["validations"].([]interface{})
+			hasDataTypeValidation := lo.ContainsBy(validationsInterface, func(v interface{}) bool {
+				validationMap, _ := v.(map[string]interface{})
+				validate, _ := validationMap["validate"].(string)
+				return validate == string(dataType)
+			})
+			if evaluator.IsDataTypeEvaluator(string(dataType)) && !hasDataTypeValidation {
 				// Add the default data type validation
 				validation, err := model.ParseValidation(generatedValidationID, id, string(dataType), jsonb.NewNull(), "", "", dataType)
 				generatedValidationID++
@@ -295,6 +402,23 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 			}
 		}
 
+		// Transforms
+		transforms, err := model.ParseTemplateColumnTransforms(columnMap["transforms"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid template: the transforms on the column %s are invalid: %s", key, err.Error())
+		}
+
+		// Default value
+		defaultValue, err := model.ParseTemplateColumnDefaultFromMap(columnMap["default_value"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid template: the default value on the column %s is invalid: %s", key, err.Error())
+		}
+
+		// List
+		if err = model.ValidateListColumn(isList, delimiter, dataType); err != nil {
+			return nil, fmt.Errorf("Invalid template: the column %s is invalid: %s", key, err.Error())
+		}
+
 		// Validations
 		if validationsInterface, ok := columnMap["validations"].([]interface{}); ok {
 			for _, v := range validationsInterface {
@@ -305,6 +429,15 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 					validationMessage, _ := validationMap["message"].(string)
 					validationSeverity, _ := validationMap["severity"].(string)
 
+					if _, registered := evaluator.Lookup(validationValidate); !registered {
+						if isCreation {
+							return nil, fmt.Errorf("Invalid template: the validate type %s on the column %s is invalid", validationValidate, key)
+						}
+						// Stored templates can have validate types that were since removed, which are skipped so the
+						// upload can still be read
+						tf.Log.Warnw("Skipping unknown validate type on stored template", "column_key", key, "validate", validationValidate)
+						continue
+					}
 					if allowedValidateTypes != nil && !allowedValidateTypes[validationValidate] {
 						if failOnNotAllowedType {
 							return nil, fmt.Errorf("Invalid template: please upgrade your plan to use the %s validate type", validationValidate)
@@ -315,8 +448,9 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 						validationID = float64(generatedValidationID)
 						generatedValidationID++
 
-						// Don't allow the user to add a data type validator (these are added automatically based on the data type)
-						if evaluator.IsDataTypeEvaluator(validationValidate) {
+						// Don't allow the user to add a data type validator (these are added automatically based on the data type),
+						// unless it's the validator of the column data type
+						if evaluator.IsDataTypeEvaluator(validationValidate) && validationValidate != string(dataType) {
 							return nil, fmt.Errorf("Invalid template: the validate type %v cannot be added directly and is automatically added when setting a data type", validationValidate)
 						}
 					}
@@ -336,6 +470,9 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 					if err != nil {
 						return nil, err
 					}
+					for _, k := range validation.ReferencedKeys() {
+						referencedKeys = append(referencedKeys, referencedKey{columnKey: key, validate: validation.Validate, key: k})
+					}
 					validations = append(validations, &Validation{
 						ValidationID: validation.ID,
 						Validate:     validation.Validate,
@@ -355,13 +492,33 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 			DataType:          string(dataType),
 			Description:       description,
 			SuggestedMappings: suggestedMappings,
+			Synonyms:          synonyms,
 			Validations:       validations,
+			Transforms:        transforms,
+			DefaultValue:      defaultValue,
+			Formula:           strings.TrimSpace(formula),
+			IsList:            isList,
+			Delimiter:         delimiter,
 		})
 	}
 
 	if len(columns) == 0 {
 		return nil, fmt.Errorf("Invalid template: No template columns were provided")
 	}
+	existingKeys := lo.SliceToMap(existingColumns, func(tc *model.TemplateColumn) (string, bool) {
+		return tc.Key, true
+	})
+	for _, rk := range referencedKeys {
+		if !seenKeys[rk.key] && !existingKeys[rk.key] {
+			return nil, fmt.Errorf("Invalid template: The %s validation on the column %s references the key %s, which does not exist", rk.validate, rk.columnKey, rk.key)
+		}
+	}
+	formulaColumns := lo.Map(columns, func(tc *TemplateColumn, _ int) *model.TemplateColumn {
+		return &model.TemplateColumn{Key: tc.Key, Formula: null.NewString(tc.Formula, len(tc.Formula) != 0)}
+	})
+	if _, err := model.ParseComputedColumns(append(append([]*model.TemplateColumn{}, existingColumns...), formulaColumns...)); err != nil {
+		return nil, fmt.Errorf("Invalid template: %s", err.Error())
+	}
 
 	templateID := model.ID{}
 	if isCreation {
@@ -379,12 +536,53 @@ func ConvertRawTemplate(rawTemplate jsonb.JSONB, isCreation bool, allowedValidat
 	}, nil
 }
 
-// ConvertImportRowsResponse converts []ImportRow to []ImportRowResponse to the response will have the values in the correct data type
-func ConvertImportRowsResponse(rows []ImportRow, imp *model.Import) []ImportRowResponse {
+// ConvertTemplateToModel converts an importer template (i.e. an SDK-defined template stored on an upload) to a model
+// template with parsed validations so it can be used to process an import
+func ConvertTemplateToModel(importerTemplate *Template, workspaceID model.ID) *model.Template {
+	template := &model.Template{
+		ID:          importerTemplate.ID,
+		Name:        importerTemplate.Name,
+		WorkspaceID: workspaceID,
+	}
+	for _, importColumn := range importerTemplate.TemplateColumns {
+		templateColumn := &model.TemplateColumn{
+			ID:                importColumn.ID,
+			TemplateID:        importerTemplate.ID,
+			Name:              importColumn.Name,
+			Key:               importColumn.Key,
+			Required:          importColumn.Required,
+			DataType:          model.TemplateColumnDataType(importColumn.DataType),
+			Description:       null.NewString(importColumn.Description, len(importColumn.Description) != 0),
+			SuggestedMappings: importColumn.SuggestedMappings,
+			Synonyms:          importColumn.Synonyms,
+			Transforms:        importColumn.Transforms,
+			DefaultValue:      importColumn.DefaultValue,
+			Formula:           null.NewString(importColumn.Formula, len(importColumn.Formula) != 0),
+			IsList:            importColumn.IsList,
+			Delimiter:         importColumn.Delimiter,
+		}
+		for _, v := range importColumn.Validations {
+			validation, err := model.ParseValidation(v.ValidationID, importColumn.ID.String(), v.Validate, v.Options, v.Message, v.Severity, templateColumn.DataType)
+			if err == nil {
+				templateColumn.Validations = append(templateColumn.Validations, validation)
+			}
+		}
+		template.TemplateColumns = append(template.TemplateColumns, templateColumn)
+	}
+	return template
+}
+
+// ImportRowConverter converts the values of import rows, which are stored as strings, to their data types
+type ImportRowConverter struct {
+	dataTypes      map[string]model.TemplateColumnDataType
+	numberFormats  map[string]util.NumberFormat
+	listDelimiters map[string]string
+}
+
+func NewImportRowConverter(imp *model.Import) (*ImportRowConverter, error) {
 	dataTypesRaw, ok := imp.DataTypes.AsMap()
 	if !ok {
-		tf.Log.Errorw("Failed to parse import data types", "import_id", imp.ID)
-		return make([]ImportRowResponse, 0)
+		return nil, errors.New("failed to parse import data types")
 	}
 
 	dataTypes := make(map[string]model.TemplateColumnDataType, len(dataTypesRaw))
@@ -402,40 +600,157 @@ func ConvertImportRowsResponse(rows []ImportRow, imp *model.Import) []ImportRowR
 		dataTypes[k] = dataType
 	}
 
+	// The number formats of the columns are used to convert values that are stored as they were in the file
+	numberFormats := make(map[string]util.NumberFormat)
+	if imp.ColumnFormats.Valid {
+		if err := json.Unmarshal([]byte(imp.ColumnFormats.ToString()), &numberFormats); err != nil {
+			tf.Log.Errorw("Failed to parse import column formats", "import_id", imp.ID, "error", err)
+		}
+	}
+	return &ImportRowConverter{dataTypes: dataTypes, numberFormats: numberFormats, listDelimiters: imp.GetListDelimiters()}, nil
+}
+
+// ConvertImportRowsResponse converts []ImportRow to []ImportRowResponse to the response will have the values in the correct data type
+func ConvertImportRowsResponse(rows []ImportRow, imp *model.Import) []ImportRowResponse {
+	converter, err := NewImportRowConverter(imp)
+	if err != nil {
+		tf.Log.Errorw("Failed to parse import data types", "import_id", imp.ID, "error", err)
+		return make([]ImportRowResponse, 0)
+	}
 	rowsResponse := make([]ImportRowResponse, len(rows), len(rows))
 	for i, row := range rows {
-		rowsResponse[i] = convertImportRow(row, dataTypes)
+		rowsResponse[i] = converter.ConvertRow(row)
 	}
 	return rowsResponse
 }
 
-func convertImportRow(row ImportRow, dataTypes map[string]model.TemplateColumnDataType) ImportRowResponse {
+// ConvertRow converts the values of the row to their data types, i.e. to be returned as JSON
+func (c *ImportRowConverter) ConvertRow(row ImportRow) ImportRowResponse {
 	response := ImportRowResponse{
-		Index:  row.Index,
-		Values: make(map[string]interface{}, len(row.Values)),
-		Errors: row.Errors,
+		Index:     row.Index,
+		Values:    make(map[string]interface{}, len(row.Values)),
+		RawValues: row.RawValues,
+		Errors:    row.Errors,
 	}
 	for k, v := range row.Values {
-		dataType := dataTypes[k]
-		switch dataType {
-		case model.TemplateColumnDataTypeString:
-			response.Values[k] = v
-		case model.TemplateColumnDataTypeNumber:
-			val, _, err := util.StringToNumberOrNil(v)
-			if err != nil {
-				tf.Log.Warnw("Failed to convert import row value from data type", "index", row.Index, "value", v, "data_type", dataType)
-			}
-			response.Values[k] = val
-		case model.TemplateColumnDataTypeBoolean:
-			val, _, err := util.StringToBoolOrNil(v)
-			if err != nil {
-				tf.Log.Warnw("Failed to convert import row value from data type", "index", row.Index, "value", v, "data_type", dataType)
-			}
-			response.Values[k] = val
-		case model.TemplateColumnDataTypeDate:
-			// Dates are already converted at this point to the correct format
-			response.Values[k] = v
+		val, err := c.convertCell(k, v, row.Errors[k])
+		if err != nil {
+			tf.Log.Warnw("Failed to convert import row value from data type", "index", row.Index, "value", v, "data_type", c.dataTypes[k])
 		}
+		response.Values[k] = val
 	}
 	return response
 }
+
+// convertCell converts the cell to its data type, or to an array of the elements converted to their data type if the
+// column is a list
+func (c *ImportRowConverter) convertCell(key, value string, cellErrors []ImportRowError) (interface{}, error) {
+	delimiter, isList := c.listDelimiters[key]
+	if !isList {
+		return c.convertValue(key, value, c.isNormalized(key, cellErrors, -1))
+	}
+	elements := model.SplitListCell(value, delimiter)
+	values := make([]interface{}, len(elements))
+	var convertErr error
+	for i, element := range elements {
+		val, err := c.convertValue(key, element, c.isNormalized(key, cellErrors, i))
+		if err != nil {
+			convertErr = err
+		}
+		values[i] = val
+	}
+	return values, convertErr
+}
+
+// isNormalized returns true if the value (or the list element at the index, -1 if the column isn't a list) is stored
+// in the canonical form. Values are normalized when the data type validation of the column passes on them, and are
+// stored as they were in the file otherwise.
+func (c *ImportRowConverter) isNormalized(key string, cellErrors []ImportRowError, element int) bool {
+	if _, ok := c.numberFormats[key]; !ok {
+		return false
+	}
+	for _, e := range cellErrors {
+		if !evaluator.IsDataTypeEvaluator(e.Validate) {
+			continue
+		}
+		if element < 0 || len(e.FailedElements) == 0 || lo.Contains(e.FailedElements, element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ImportRowConverter) convertValue(key, value string, normalized bool) (interface{}, error) {
+	switch c.dataTypes[key] {
+	case model.TemplateColumnDataTypeNumber, model.TemplateColumnDataTypeInteger:
+		val, _, err := util.ParseStoredNumber(value, c.numberFormats[key], normalized)
+		return val, err
+	case model.TemplateColumnDataTypeDecimal:
+		if util.IsBlankUnicode(value) {
+			return nil, nil
+		}
+		// Decimals are returned as JSON numbers with the scale they were normalized to, i.e. 12.50
+		if _, err := strconv.ParseFloat(value, 64); err == nil && normalized && json.Valid([]byte(value)) {
+			return json.Number(value), nil
+		}
+		val, _, err := util.ParseStoredNumber(value, c.numberFormats[key], normalized)
+		return val, err
+	case model.TemplateColumnDataTypeBoolean:
+		val, _, err := util.StringToBoolOrNil(value)
+		return val, err
+	case model.TemplateColumnDataTypeJSON:
+		if util.IsBlankUnicode(value) {
+			return nil, nil
+		}
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("invalid json: %s", value)
+		}
+		return json.RawMessage(value), nil
+	default:
+		// Strings, enums, and dates and datetimes (which are already converted to the correct format)
+		return value, nil
+	}
+}
+
+// ExportValue converts the value of the key in the row to the string written to an export file, i.e. a CSV. Numbers and
+// booleans that are stored as they were in the file are normalized, and values that can't be converted are written as
+// they are stored. List cells are written as a JSON array of the elements converted to their data type.
+func (c *ImportRowConverter) ExportValue(row ImportRow, key string) string {
+	value := row.Values[key]
+	if _, isList := c.listDelimiters[key]; isList {
+		values, err := c.convertCell(key, value, row.Errors[key])
+		if err != nil {
+			return value
+		}
+		arr, err := json.Marshal(values)
+		if err != nil {
+			return value
+		}
+		return string(arr)
+	}
+	var str string
+	var err error
+	switch c.dataTypes[key] {
+	case model.TemplateColumnDataTypeNumber, model.TemplateColumnDataTypeInteger:
+		_, str, err = util.ParseStoredNumber(value, c.numberFormats[key], c.isNormalized(key, row.Errors[key], -1))
+	case model.TemplateColumnDataTypeDecimal:
+		normalized := c.isNormalized(key, row.Errors[key], -1)
+		if _, parseErr := strconv.ParseFloat(value, 64); parseErr == nil && normalized && json.Valid([]byte(value)) {
+			return value
+		}
+		_, str, err = util.ParseStoredNumber(value, c.numberFormats[key], normalized)
+	case model.TemplateColumnDataTypeBoolean:
+		_, str, err = util.StringToBoolOrNil(value)
+	case model.TemplateColumnDataTypeJSON:
+		var compacted bytes.Buffer
+		if err = json.Compact(&compacted, []byte(value)); err == nil {
+			str = compacted.String()
+		}
+	default:
+		return value
+	}
+	if err != nil {
+		return value
+	}
+	return str
+}
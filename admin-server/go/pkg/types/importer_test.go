@@ -0,0 +1,209 @@
+package types
+
+import (
+	"encoding/json"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"testing"
+)
+
+func TestConvertRawTemplateColumns(t *testing.T) {
+	existingColumns := []*model.TemplateColumn{{Key: "start_date"}, {Key: "amount"}}
+	tests := []struct {
+		name    string
+		columns []interface{}
+		wantErr bool
+	}{
+		{
+			name: "row validation references an existing column",
+			columns: []interface{}{map[string]interface{}{
+				"name": "End Date",
+				"key":  "end_date",
+				"validations": []interface{}{map[string]interface{}{
+					"validate": "compare",
+					"options":  map[string]interface{}{"operator": "gte", "key": "start_date"},
+				}},
+			}},
+		},
+		{
+			name: "row validation references a new column",
+			columns: []interface{}{
+				map[string]interface{}{"name": "Country", "key": "country"},
+				map[string]interface{}{
+					"name": "State",
+					"key":  "state",
+					"validations": []interface{}{map[string]interface{}{
+						"validate": "conditional",
+						"options": map[string]interface{}{
+							"when":     map[string]interface{}{"key": "country", "value": "US"},
+							"validate": "not_blank",
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "row validation references an unknown column",
+			columns: []interface{}{map[string]interface{}{
+				"name": "End Date",
+				"key":  "end_date",
+				"validations": []interface{}{map[string]interface{}{
+					"validate": "compare",
+					"options":  map[string]interface{}{"operator": "gte", "key": "begin_date"},
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "formula references an existing column",
+			columns: []interface{}{map[string]interface{}{"name": "Total", "key": "total", "formula": "amount * 2"}},
+		},
+		{
+			name:    "formula references an unknown column",
+			columns: []interface{}{map[string]interface{}{"name": "Total", "key": "total", "formula": "price * 2"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := jsonb.FromMap(map[string]interface{}{"columns": tt.columns})
+			_, err := ConvertRawTemplateColumns(raw, nil, existingColumns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConvertRawTemplateColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newTestImportRowConverter(t *testing.T) *ImportRowConverter {
+	t.Helper()
+	imp := &model.Import{
+		DataTypes: jsonb.FromMap(map[string]interface{}{
+			"amount":   "number",
+			"quantity": "integer",
+			"price":    "decimal",
+			"active":   "boolean",
+			"metadata": "json",
+			"name":     "string",
+			"amounts":  "number",
+			"tags":     "string",
+		}),
+		ColumnFormats: jsonb.FromMap(map[string]interface{}{
+			"amount":   map[string]interface{}{"decimal_separator": ",", "group_separator": "."},
+			"quantity": map[string]interface{}{"decimal_separator": ".", "group_separator": ","},
+			"price":    map[string]interface{}{"decimal_separator": ",", "group_separator": "."},
+			"amounts":  map[string]interface{}{"decimal_separator": ",", "group_separator": "."},
+		}),
+		ListDelimiters: jsonb.FromMap(map[string]interface{}{"amounts": ";", "tags": ","}),
+	}
+	converter, err := NewImportRowConverter(imp)
+	if err != nil {
+		t.Fatalf("NewImportRowConverter returned error: %v", err)
+	}
+	return converter
+}
+
+func TestImportRowConverterConvertRow(t *testing.T) {
+	converter := newTestImportRowConverter(t)
+	numberError := ImportRowError{Validate: "number", Severity: "error", Message: "The cell must be a number"}
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		errors []ImportRowError
+		want   string
+	}{
+		{name: "normalized number", key: "amount", value: "1234.5", want: `1234.5`},
+		{name: "number stored as it was in the file", key: "amount", value: "1.234,5", errors: []ImportRowError{{Validate: "range"}, numberError}, want: `1234.5`},
+		{name: "number with another failed validation is normalized", key: "amount", value: "1234.5", errors: []ImportRowError{{Validate: "range"}}, want: `1234.5`},
+		{name: "blank number", key: "amount", value: "", want: `null`},
+		{name: "integer", key: "quantity", value: "42", want: `42`},
+		{name: "large integer", key: "quantity", value: "12345678901234567890", want: `12345678901234567890`},
+		{name: "decimal keeps the scale", key: "price", value: "12.50", want: `12.50`},
+		{name: "decimal stored as it was in the file", key: "price", value: "12,5", errors: []ImportRowError{{Validate: "decimal"}}, want: `12.5`},
+		{name: "boolean", key: "active", value: "true", want: `true`},
+		{name: "blank boolean", key: "active", value: " ", want: `null`},
+		{name: "json", key: "metadata", value: `{"a": [1, 2]}`, want: `{"a":[1,2]}`},
+		{name: "blank json", key: "metadata", value: "", want: `null`},
+		{name: "string", key: "name", value: " Mary ", want: `" Mary "`},
+		{name: "list of normalized numbers", key: "amounts", value: "1234.5; 2", want: `[1234.5,2]`},
+		{
+			name:   "list element stored as it was in the file",
+			key:    "amounts",
+			value:  "1234.5; 1.234,5",
+			errors: []ImportRowError{{Validate: "number", FailedElements: []int{1}}},
+			want:   `[1234.5,1234.5]`,
+		},
+		{
+			name:   "list stored as it was in the file",
+			key:    "amounts",
+			value:  "1.234,5; 2,5",
+			errors: []ImportRowError{{Validate: "number"}},
+			want:   `[1234.5,2.5]`,
+		},
+		{name: "blank list", key: "amounts", value: " ", want: `[]`},
+		{name: "list of strings", key: "tags", value: "red, ,blue", want: `["red","blue"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := ImportRow{Index: 3, Values: map[string]string{tt.key: tt.value}, Errors: map[string][]ImportRowError{}}
+			if tt.errors != nil {
+				row.Errors[tt.key] = tt.errors
+			}
+			response := converter.ConvertRow(row)
+			got, err := json.Marshal(response.Values[tt.key])
+			if err != nil {
+				t.Fatalf("Could not marshal %v: %v", response.Values[tt.key], err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ConvertRow(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+			if response.Index != row.Index {
+				t.Errorf("ConvertRow() index = %d, want %d", response.Index, row.Index)
+			}
+		})
+	}
+}
+
+func TestImportRowConverterExportValue(t *testing.T) {
+	converter := newTestImportRowConverter(t)
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		errors []ImportRowError
+		want   string
+	}{
+		{name: "normalized number", key: "amount", value: "1234.5", want: "1234.5"},
+		{name: "number stored as it was in the file", key: "amount", value: "1.234,5", errors: []ImportRowError{{Validate: "number"}}, want: "1234.5"},
+		{name: "invalid number is written as stored", key: "amount", value: "n/a", errors: []ImportRowError{{Validate: "number"}}, want: "n/a"},
+		{name: "decimal keeps the scale", key: "price", value: "12.50", want: "12.50"},
+		{name: "boolean", key: "active", value: "T", want: "true"},
+		{name: "json is compacted", key: "metadata", value: "{\"a\": 1}", want: `{"a":1}`},
+		{name: "string", key: "name", value: "Mary", want: "Mary"},
+		{name: "list as a JSON array", key: "amounts", value: "1234.5; 2", want: "[1234.5,2]"},
+		{
+			name:   "list element stored as it was in the file",
+			key:    "amounts",
+			value:  "2; 1.234,5",
+			errors: []ImportRowError{{Validate: "number", FailedElements: []int{1}}},
+			want:   "[2,1234.5]",
+		},
+		{
+			name:   "invalid list element is written as stored",
+			key:    "amounts",
+			value:  "2; n/a",
+			errors: []ImportRowError{{Validate: "number", FailedElements: []int{1}}},
+			want:   "2; n/a",
+		},
+		{name: "list of strings", key: "tags", value: "red,blue", want: `["red","blue"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := ImportRow{Values: map[string]string{tt.key: tt.value}, Errors: map[string][]ImportRowError{tt.key: tt.errors}}
+			if got := converter.ExportValue(row, tt.key); got != tt.want {
+				t.Errorf("ExportValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+	"math/big"
+	"strings"
+)
+
+// NumberFormat is the decimal and grouping (thousands) separator used to write numbers in a file
+type NumberFormat struct {
+	DecimalSeparator string `json:"decimal_separator"`
+	GroupSeparator   string `json:"group_separator"`
+}
+
+var DefaultNumberFormat = NumberFormat{DecimalSeparator: ".", GroupSeparator: ","}
+
+var (
+	numberFormatDotComma   = NumberFormat{DecimalSeparator: ".", GroupSeparator: ","}
+	numberFormatCommaDot   = NumberFormat{DecimalSeparator: ",", GroupSeparator: "."}
+	numberFormatCommaSpace = NumberFormat{DecimalSeparator: ",", GroupSeparator: " "}
+	numberFormatDotQuote   = NumberFormat{DecimalSeparator: ".", GroupSeparator: "'"}
+)
+
+// numberFormatsByLanguage are the number formats of locales, by base language. Regions with a different format than
+// their language are in numberFormatsByLocale.
+var numberFormatsByLanguage = map[string]NumberFormat{
+	"en": numberFormatDotComma, "ja": numberFormatDotComma, "zh": numberFormatDotComma, "ko": numberFormatDotComma,
+	"he": numberFormatDotComma, "th": numberFormatDotComma, "hi": numberFormatDotComma, "ms": numberFormatDotComma,
+	"de": numberFormatCommaDot, "es": numberFormatCommaDot, "it": numberFormatCommaDot, "pt": numberFormatCommaDot,
+	"nl": numberFormatCommaDot, "id": numberFormatCommaDot, "da": numberFormatCommaDot, "tr": numberFormatCommaDot,
+	"el": numberFormatCommaDot, "ro": numberFormatCommaDot, "hr": numberFormatCommaDot, "sl": numberFormatCommaDot,
+	"sr": numberFormatCommaDot, "vi": numberFormatCommaDot,
+	"fr": numberFormatCommaSpace, "ru": numberFormatCommaSpace, "pl": numberFormatCommaSpace,
+	"cs": numberFormatCommaSpace, "sk": numberFormatCommaSpace, "sv": numberFormatCommaSpace,
+	"fi": numberFormatCommaSpace, "nb": numberFormatCommaSpace, "no": numberFormatCommaSpace,
+	"uk": numberFormatCommaSpace, "hu": numberFormatCommaSpace, "bg": numberFormatCommaSpace,
+	"lt": numberFormatCommaSpace, "lv": numberFormatCommaSpace, "et": numberFormatCommaSpace,
+}
+
+var numberFormatsByLocale = map[string]NumberFormat{
+	"de-CH": numberFormatDotQuote,
+	"de-LI": numberFormatDotQuote,
+	"it-CH": numberFormatDotQuote,
+	"pt-PT": numberFormatCommaSpace,
+	"es-MX": numberFormatDotComma,
+	"es-US": numberFormatDotComma,
+	"fr-CH": numberFormatCommaSpace,
+}
+
+// spaceGroupSeparators are accepted in place of each other when the group separator is a space
+var spaceGroupSeparators = []string{" ", "\u00a0", "\u202f"}
+
+// NumberFormatForLocale returns the number format of a locale such as "de-DE" or "fr"
+func NumberFormatForLocale(locale string) (NumberFormat, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return NumberFormat{}, fmt.Errorf("invalid locale %s", locale)
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if f, ok := numberFormatsByLocale[base.String()+"-"+region.String()]; ok {
+		return f, nil
+	}
+	if f, ok := numberFormatsByLanguage[base.String()]; ok {
+		return f, nil
+	}
+	return NumberFormat{}, fmt.Errorf("the number format of the locale %s is not supported, please provide the separators", locale)
+}
+
+// Validate checks that the separators can be used to parse numbers
+func (f NumberFormat) Validate() error {
+	if len(f.DecimalSeparator) == 0 {
+		return errors.New("decimal_separator is required")
+	}
+	if f.DecimalSeparator == f.GroupSeparator {
+		return errors.New("decimal_separator and group_separator cannot be the same")
+	}
+	if strings.ContainsAny(f.DecimalSeparator+f.GroupSeparator, "0123456789+-eE") {
+		return errors.New("separators cannot contain digits, signs or exponents")
+	}
+	return nil
+}
+
+// NormalizeNumberString converts a number written in the format to the canonical form, i.e. "1.234,5" with the
+// format {",", "."} is converted to "1234.5". Group separators are only allowed before the decimal separator.
+func NormalizeNumberString(s string, f NumberFormat) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(f.DecimalSeparator) == 0 {
+		f = DefaultNumberFormat
+	}
+	if strings.Count(s, f.DecimalSeparator) > 1 {
+		return "", fmt.Errorf("the number %s contains multiple decimal separators", s)
+	}
+	integerPart, fractionPart, hasFraction := strings.Cut(s, f.DecimalSeparator)
+
+	groupSeparators := []string{f.GroupSeparator}
+	if lo.Contains(spaceGroupSeparators, f.GroupSeparator) {
+		groupSeparators = spaceGroupSeparators
+	}
+	for _, sep := range groupSeparators {
+		if len(sep) == 0 {
+			continue
+		}
+		if hasFraction && strings.Contains(fractionPart, sep) {
+			return "", fmt.Errorf("the number %s contains a group separator after the decimal separator", s)
+		}
+		integerPart = strings.ReplaceAll(integerPart, sep, "")
+	}
+	if !hasFraction {
+		return integerPart, nil
+	}
+	return integerPart + "." + fractionPart, nil
+}
+
+// StringToNumberWithFormat converts a string in the number format to a BigInt or BigFloat, returning the number and
+// its canonical string
+func StringToNumberWithFormat(s string, f NumberFormat) (interface{}, string, error) {
+	if IsBlankUnicode(s) {
+		return nil, "", nil
+	}
+	lower := strings.ToLower(strings.TrimSpace(s))
+	if lower == "null" || lower == "nil" {
+		return nil, "", nil
+	}
+	normalized, err := NormalizeNumberString(s, f)
+	if err != nil {
+		return nil, "", err
+	}
+	if bi, ok := StringToBigInt(normalized); ok {
+		return bi, bi.String(), nil
+	}
+	if bf, ok := StringToBigFloat(normalized); ok {
+		return bf, bf.String(), nil
+	}
+	// Return an error if the string couldn't be converted to any number type
+	return nil, "", fmt.Errorf("failed to convert string to number: %s", s)
+}
+
+// canonicalNumberFormat is the format numbers are normalized to, without group separators
+var canonicalNumberFormat = NumberFormat{DecimalSeparator: "."}
+
+// ParseStoredNumber converts a stored cell value to a number. Cells that passed the number validation are stored in
+// the canonical form (normalized), otherwise the value is stored as it was in the file and is parsed using the number
+// format, i.e. "1.234" is 1234 with the de-DE format.
+func ParseStoredNumber(s string, f NumberFormat, normalized bool) (interface{}, string, error) {
+	if normalized {
+		return StringToNumberWithFormat(s, canonicalNumberFormat)
+	}
+	return StringToNumberWithFormat(s, f)
+}
+
+// DecimalPlaces returns the number of digits after the decimal point of the number
+func DecimalPlaces(f *big.Float) int {
+	text := f.Text('f', -1)
+	_, fraction, ok := strings.Cut(text, ".")
+	if !ok {
+		return 0
+	}
+	return len(fraction)
+}
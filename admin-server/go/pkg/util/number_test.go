@@ -0,0 +1,36 @@
+package util
+
+import "testing"
+
+func TestParseStoredNumber(t *testing.T) {
+	deDE := NumberFormat{DecimalSeparator: ",", GroupSeparator: "."}
+	tests := []struct {
+		name       string
+		value      string
+		format     NumberFormat
+		normalized bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "normalized integer", value: "1234", format: deDE, normalized: true, want: "1234"},
+		{name: "normalized decimal", value: "1234.5", format: deDE, normalized: true, want: "1234.5"},
+		{name: "raw group separator", value: "1.234", format: deDE, want: "1234"},
+		{name: "raw decimal separator", value: "1.234,5", format: deDE, want: "1234.5"},
+		{name: "raw default format", value: "1,234.5", format: DefaultNumberFormat, want: "1234.5"},
+		{name: "raw without a format", value: "1,234", want: "1234"},
+		{name: "blank", value: " ", format: deDE, want: ""},
+		{name: "raw text", value: "twelve", format: deDE, wantErr: true},
+		{name: "normalized value with a group separator", value: "1,234", normalized: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := ParseStoredNumber(tt.value, tt.format, tt.normalized)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStoredNumber(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseStoredNumber(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
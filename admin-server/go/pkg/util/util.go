@@ -7,8 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/hbollon/go-edlib"
+	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 	"net/mail"
 	"reflect"
 	"runtime/debug"
@@ -55,6 +59,20 @@ func IsBlankUnicode(s string) bool {
 	return true
 }
 
+// FoldText normalizes text to compare it regardless of accents, case, compatibility characters and word separators,
+// i.e. "Correo Electrónico" and "correo_electronico" both fold to "correo electronico"
+func FoldText(s string) string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+	folded = cases.Fold().String(folded)
+	return strings.Join(strings.FieldsFunc(folded, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '-' || r == '.' || r == '/'
+	}), " ")
+}
+
 func IsBlankASCII(s string) bool {
 	if s == "" {
 		return true
@@ -235,28 +253,9 @@ func StringSimilarity(str1 string, str2 string) float32 {
 	}
 }
 
+// StringToNumberOrNil converts a string in the default number format (i.e. 1,234.5) to a BigInt or BigFloat
 func StringToNumberOrNil(s string) (interface{}, string, error) {
-	if IsBlankUnicode(s) {
-		return nil, "", nil
-	}
-	s = strings.TrimSpace(s)
-	s = strings.Replace(s, ",", "", -1) // Remove commas
-	lower := strings.ToLower(s)
-
-	if lower == "null" || lower == "nil" {
-		return nil, "", nil
-	}
-
-	if bi, ok := StringToBigInt(s); ok {
-		return bi, bi.String(), nil
-	}
-
-	if bf, ok := StringToBigFloat(s); ok {
-		return bf, bf.String(), nil
-	}
-
-	// Return an error if the string couldn't be converted to any number type
-	return nil, "", fmt.Errorf("failed to convert string to number: %s", s)
+	return StringToNumberWithFormat(s, DefaultNumberFormat)
 }
 
 func StringToBoolOrNil(s string) (*bool, string, error) {
@@ -0,0 +1,29 @@
+package util
+
+import "testing"
+
+func TestFoldText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Correo Electrónico", want: "correo electronico"},
+		{input: "correo_electronico", want: "correo electronico"},
+		{input: "  E-Mail-Adresse ", want: "e mail adresse"},
+		{input: "Straße", want: "strasse"},
+		{input: "PRÉNOM", want: "prenom"},
+		{input: "first.name/alias", want: "first name alias"},
+		{input: "ﬁrst name", want: "first name"},
+		{input: "Ｎａｍｅ", want: "name"},
+		{input: "Zip\tCode", want: "zip code"},
+		{input: "__", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := FoldText(tt.input); got != tt.want {
+				t.Errorf("FoldText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
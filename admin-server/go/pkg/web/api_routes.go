@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/guregu/null"
 	"gorm.io/gorm"
 	"io"
 	"net/http"
@@ -154,6 +153,14 @@ func downloadImportForExternalAPI(c *gin.Context) {
 		_ = downloadFile.Close()
 	}(downloadFile)
 
+	// Values are written in the normalized form of their data type
+	converter, err := types.NewImportRowConverter(imp)
+	if err != nil {
+		tf.Log.Errorw("Could not parse data types to download import for external API", "error", err, "import_id", id)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: "Could not download import"})
+		return
+	}
+
 	sampleImportRow, err := scylla.GetAnyImportRow(imp.ID.String(), 0)
 	if err != nil {
 		tf.Log.Errorw("Could not retrieve sample import row to download import for external API", "error", err, "import_id", id)
@@ -182,7 +189,7 @@ func downloadImportForExternalAPI(c *gin.Context) {
 		for pageRowIndex := 0; pageRowIndex < len(importRows); pageRowIndex++ {
 			row := make([]string, len(columnHeaders), len(columnHeaders))
 			for i, key := range columnHeaders {
-				row[i] = importRows[pageRowIndex].Values[key]
+				row[i] = converter.ExportValue(importRows[pageRowIndex], key)
 			}
 			if err = w.Write(row); err != nil {
 				tf.Log.Warnw("Error while writing row to import file for external API download", "error", err, "import_id", imp.ID)
@@ -314,30 +321,10 @@ func createImporterForExternalAPI(c *gin.Context, getAllowedValidateTypes func(s
 		return
 	}
 
-	for _, tc := range templateType.TemplateColumns {
-		templateColumn := &model.TemplateColumn{
-			ID:                tc.ID,
-			TemplateID:        templateType.ID,
-			Name:              tc.Name,
-			Key:               tc.Key,
-			Required:          tc.Required,
-			DataType:          model.TemplateColumnDataType(tc.DataType),
-			Description:       null.NewString(tc.Description, len(tc.Description) != 0),
-			SuggestedMappings: tc.SuggestedMappings,
-			CreatedBy:         user.ID,
-			UpdatedBy:         user.ID,
-		}
-
-		for _, v := range tc.Validations {
-			v.ValidationID = 0
-			validation, err := model.ParseValidation(v.ValidationID, tc.ID.String(), v.Validate, v.Options, v.Message, v.Severity, model.TemplateColumnDataType(tc.DataType))
-			if err != nil {
-				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
-				return
-			}
-			templateColumn.Validations = append(templateColumn.Validations, validation)
-		}
-		template.TemplateColumns = append(template.TemplateColumns, templateColumn)
+	template.TemplateColumns, err = convertTemplateColumnsToModel(templateType, template.ID, workspaceID, user)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
 	}
 
 	err = tf.DB.Create(template.TemplateColumns).Error
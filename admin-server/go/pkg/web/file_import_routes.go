@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/evaluator"
 	"tableflow/go/pkg/file"
 	"tableflow/go/pkg/model"
 	"tableflow/go/pkg/model/jsonb"
@@ -165,6 +166,14 @@ func importerGetImporter(c *gin.Context, getAllowedValidateTypes func(string) ma
 			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 			return
 		}
+		for _, tc := range requestTemplate.TemplateColumns {
+			if lo.ContainsBy(tc.Validations, func(v *types.Validation) bool { return v.Validate == "http" }) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{
+					Err: fmt.Sprintf("Invalid template: the http validate type on the column %s can't be used in an SDK-defined template", tc.Key),
+				})
+				return
+			}
+		}
 		importServiceImporter := types.Importer{
 			ID:       importer.ID,
 			Name:     importer.Name,
@@ -211,12 +220,18 @@ func importerGetImporter(c *gin.Context, getAllowedValidateTypes func(string) ma
 				return &types.Validation{
 					ValidationID: v.ID,
 					Validate:     v.Validate,
-					Options:      v.Options,
+					Options:      evaluator.PublicOptions(v.Validate, v.Options),
 					Severity:     string(v.Severity),
 					Message:      v.Message,
 				}
 			}),
 			SuggestedMappings: tc.SuggestedMappings,
+			Synonyms:          tc.Synonyms,
+			Transforms:        tc.Transforms,
+			DefaultValue:      tc.DefaultValue,
+			Formula:           tc.Formula.String,
+			IsList:            tc.IsList,
+			Delimiter:         tc.Delimiter,
 		}
 	}
 	importerTemplate := &types.Template{
@@ -307,30 +322,10 @@ func importerGetUpload(c *gin.Context, getColumnMatches func(*types.Upload, []*m
 
 	// Add suggested template column mappings if the HeaderRowIndex has been set
 	if upload.HeaderRowIndex.Valid {
-		templateColumns := make([]*model.TemplateColumn, 0)
-		if importerUpload.Template != nil {
-			// If the template exists on the upload, use those template columns for the mapping
-			for _, tc := range importerUpload.Template.TemplateColumns {
-				templateColumns = append(templateColumns, &model.TemplateColumn{
-					ID:                tc.ID,
-					Name:              tc.Name,
-					Key:               tc.Key,
-					Required:          tc.Required,
-					DataType:          model.TemplateColumnDataType(tc.DataType),
-					Description:       null.NewString(tc.Description, len(tc.Description) != 0),
-					SuggestedMappings: tc.SuggestedMappings,
-					//Validations:       nil,
-				})
-			}
-		} else {
-			template, err := db.GetTemplateByImporter(importerUpload.ImporterID.String())
-			if err != nil {
-				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
-				return
-			}
-			templateColumns = template.TemplateColumns
+		if err = addColumnMappingSuggestions(importerUpload, getColumnMatches); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
 		}
-		file.AddColumnMappingSuggestions(importerUpload, templateColumns, getColumnMatches)
 	}
 
 	c.JSON(http.StatusOK, importerUpload)
@@ -339,7 +334,7 @@ func importerGetUpload(c *gin.Context, getColumnMatches func(*types.Upload, []*m
 // importerSetHeaderRow
 //
 //	@Summary		Set upload header row
-//	@Description	Set the header row index on the upload
+//	@Description	Set the header row index on the upload, or the first and last header rows if the header spans several rows
 //	@Tags			File Import
 //	@Success		200	{object}	types.Upload
 //	@Failure		400	{object}	types.Res
@@ -386,6 +381,22 @@ func importerSetHeaderRow(c *gin.Context, getColumnMatches func(*types.Upload, [
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The header row cannot be greater than the number of rows in the file"})
 		return
 	}
+	endIndex := index
+	if req.EndIndex != nil {
+		endIndex = int64(*req.EndIndex)
+	}
+	if endIndex < index {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The parameter 'end_index' cannot be less than 'index'"})
+		return
+	}
+	if endIndex-index+1 > file.MaxHeaderRows {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The header cannot span more than %d rows", file.MaxHeaderRows)})
+		return
+	}
+	if endIndex >= upload.NumRows.Int64-1 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The header rows cannot include the last row"})
+		return
+	}
 
 	imp, err := db.GetImportByUploadID(id)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -399,37 +410,17 @@ func importerSetHeaderRow(c *gin.Context, getColumnMatches func(*types.Upload, [
 	}
 
 	// If the header row is already set and is being set again to its current value, just return the upload
-	if upload.HeaderRowIndex.Valid && index == upload.HeaderRowIndex.Int64 {
+	if upload.HeaderRowIndex.Valid && index == upload.HeaderRowIndex.Int64 && int(endIndex-index+1) == upload.NumHeaderRows() {
 		importerUpload, err := types.ConvertUpload(upload, nil)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 			return
 		}
 		// Add suggested template column mappings
-		templateColumns := make([]*model.TemplateColumn, 0)
-		if importerUpload.Template != nil {
-			// If the template exists on the upload, use those template columns for the mapping
-			for _, tc := range importerUpload.Template.TemplateColumns {
-				templateColumns = append(templateColumns, &model.TemplateColumn{
-					ID:                tc.ID,
-					Name:              tc.Name,
-					Key:               tc.Key,
-					Required:          tc.Required,
-					DataType:          model.TemplateColumnDataType(tc.DataType),
-					Description:       null.NewString(tc.Description, len(tc.Description) != 0),
-					SuggestedMappings: tc.SuggestedMappings,
-					//Validations:       nil,
-				})
-			}
-		} else {
-			template, err := db.GetTemplateByImporter(importerUpload.ImporterID.String())
-			if err != nil {
-				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
-				return
-			}
-			templateColumns = template.TemplateColumns
+		if err = addColumnMappingSuggestions(importerUpload, getColumnMatches); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
 		}
-		file.AddColumnMappingSuggestions(importerUpload, templateColumns, getColumnMatches)
 
 		c.JSON(http.StatusOK, importerUpload)
 		return
@@ -457,10 +448,12 @@ func importerSetHeaderRow(c *gin.Context, getColumnMatches func(*types.Upload, [
 	}
 
 	upload.HeaderRowIndex = null.IntFrom(index)
+	upload.HeaderRowEndIndex = null.IntFrom(endIndex)
 
 	// Retrieve row data from Scylla to create the upload columns
-	rows := make([][]string, 0, file.UploadColumnSampleDataSize)
-	uploadRowData := scylla.PaginateUploadRows(upload.ID.String(), int(index), file.UploadColumnSampleDataSize)
+	numRows := upload.NumHeaderRows() - 1 + file.UploadColumnSampleDataSize
+	rows := make([][]string, 0, numRows)
+	uploadRowData := scylla.PaginateUploadRows(upload.ID.String(), int(index), numRows)
 	for _, rowMap := range uploadRowData {
 		rows = append(rows, util.MapToKeyOrderedSlice(rowMap))
 	}
@@ -485,32 +478,76 @@ func importerSetHeaderRow(c *gin.Context, getColumnMatches func(*types.Upload, [
 	}
 
 	// Add suggested template column mappings
-	templateColumns := make([]*model.TemplateColumn, 0)
+	if err = addColumnMappingSuggestions(importerUpload, getColumnMatches); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, importerUpload)
+}
+
+// addColumnMappingSuggestions suggests the template columns to map the upload columns to. If the template exists on
+// the upload, those template columns are used, otherwise the template of the importer. The template columns are loaded
+// with their validations so the sample data can be scored.
+func addColumnMappingSuggestions(importerUpload *types.Upload, getColumnMatches func(*types.Upload, []*model.TemplateColumn) map[string]string) error {
+	var templateColumns []*model.TemplateColumn
 	if importerUpload.Template != nil {
-		// If the template exists on the upload, use those template columns for the mapping
-		for _, tc := range importerUpload.Template.TemplateColumns {
-			templateColumns = append(templateColumns, &model.TemplateColumn{
-				ID:                tc.ID,
-				Name:              tc.Name,
-				Key:               tc.Key,
-				Required:          tc.Required,
-				DataType:          model.TemplateColumnDataType(tc.DataType),
-				Description:       null.NewString(tc.Description, len(tc.Description) != 0),
-				SuggestedMappings: tc.SuggestedMappings,
-				//Validations:       nil,
-			})
-		}
+		templateColumns = types.ConvertTemplateToModel(importerUpload.Template, model.ID{}).TemplateColumns
 	} else {
 		template, err := db.GetTemplateByImporter(importerUpload.ImporterID.String())
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
-			return
+			return err
 		}
 		templateColumns = template.TemplateColumns
 	}
-	file.AddColumnMappingSuggestions(importerUpload, templateColumns, getColumnMatches)
+	learnedMappings := make(map[string]*model.LearnedColumnMapping)
+	mappings, err := db.GetLearnedColumnMappings(importerUpload.ImporterID.String())
+	if err != nil {
+		// The suggestions can still be made without the learned mappings
+		tf.Log.Warnw("Could not retrieve learned column mappings", "importer_id", importerUpload.ImporterID, "error", err)
+	}
+	for _, m := range mappings {
+		learnedMappings[m.UploadColumnName] = m
+	}
+	var synonyms *file.SynonymDictionary
+	importer, err := db.GetImporterWithoutTemplate(importerUpload.ImporterID.String())
+	if err != nil {
+		// The suggestions can still be made without the synonym dictionary
+		tf.Log.Warnw("Could not retrieve importer to load the header synonyms", "importer_id", importerUpload.ImporterID, "error", err)
+	} else {
+		synonyms = file.LoadSynonymDictionary(importer.WorkspaceID.String())
+	}
+	file.AddColumnMappingSuggestions(importerUpload, templateColumns, learnedMappings, synonyms, getColumnMatches)
+	return nil
+}
 
-	c.JSON(http.StatusOK, importerUpload)
+// learnColumnMappings saves the column mapping confirmed on the upload, so it can be suggested on the next upload to
+// the importer. Only the upload columns mapped directly are learned, not the splits and merges. Headers shared by
+// several upload columns are skipped, as they can't be told apart.
+func learnColumnMappings(upload *model.Upload, template *model.Template, columnMapping map[string]string, columnMappings model.UploadColumnMappings) {
+	templateColumnKeys := lo.SliceToMap(template.TemplateColumns, func(tc *model.TemplateColumn) (string, string) {
+		return tc.ID.String(), tc.Key
+	})
+	namesCount := lo.CountValuesBy(upload.UploadColumns, func(uc *model.UploadColumn) string {
+		return model.NormalizeUploadColumnName(uc.Name)
+	})
+	splitOrMergedIDs := columnMappings.UploadColumnIDs()
+	mappings := make(map[string]string)
+	unmappedNames := make([]string, 0)
+	for _, uc := range upload.UploadColumns {
+		name := model.NormalizeUploadColumnName(uc.Name)
+		if len(name) == 0 || namesCount[name] > 1 || lo.Contains(splitOrMergedIDs, uc.ID.String()) {
+			continue
+		}
+		if key, ok := templateColumnKeys[columnMapping[uc.ID.String()]]; ok {
+			mappings[name] = key
+		} else {
+			unmappedNames = append(unmappedNames, name)
+		}
+	}
+	if err := db.LearnColumnMappings(upload.ImporterID, mappings, unmappedNames); err != nil {
+		tf.Log.Errorw("Could not learn column mappings", "upload_id", upload.ID, "importer_id", upload.ImporterID, "error", err)
+	}
 }
 
 // importerSetColumnMapping
@@ -532,13 +569,22 @@ func importerSetColumnMapping(c *gin.Context) {
 
 	// Non-schemaless: Upload column ID -> Template column ID
 	// Schemaless:     Upload column ID -> User-provided key (i.e. first_name) (only from the request, this will be updated to IDs after the template is generated)
-	columnMapping := make(map[string]string)
-	if err := c.ShouldBindJSON(&columnMapping); err != nil {
+	// The same applies to the destination columns of the splits and merges
+	req := types.UploadColumnMappingRequest{}
+	body, err := c.GetRawData()
+	if err == nil && json.Unmarshal(body, &req.Columns) != nil {
+		// The request contains splits or merges
+		req.Columns = nil
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
 		tf.Log.Warnw("Could not bind JSON", "error", err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 		return
 	}
-	if len(columnMapping) == 0 {
+	columnMapping := lo.Ternary(req.Columns == nil, make(map[string]string), req.Columns)
+	columnMappings := model.UploadColumnMappings{Splits: req.Splits, Merges: req.Merges}
+	if len(columnMapping) == 0 && columnMappings.IsEmpty() {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Please select at least one destination column"})
 		return
 	}
@@ -553,8 +599,13 @@ func importerSetColumnMapping(c *gin.Context) {
 			return
 		}
 	}
+	if err = columnMappings.Validate(); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
 	// Validate there are no duplicate template column IDs
-	if util.HasDuplicateValues(columnMapping) {
+	destinations := append(lo.Values(columnMapping), columnMappings.TemplateColumnIDs()...)
+	if len(lo.Uniq(destinations)) != len(destinations) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Destination columns must be unique and not contain duplicate values"})
 		return
 	}
@@ -564,6 +615,24 @@ func importerSetColumnMapping(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 		return
 	}
+	// Validate the source columns of the splits and merges exist, as they are read by their index during the import
+	uploadColumnIDs := lo.SliceToMap(upload.UploadColumns, func(uc *model.UploadColumn) (string, bool) {
+		return uc.ID.String(), true
+	})
+	for _, split := range columnMappings.Splits {
+		if !uploadColumnIDs[split.UploadColumnID] {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The upload column %s does not exist", split.UploadColumnID)})
+			return
+		}
+	}
+	for _, merge := range columnMappings.Merges {
+		for _, ucID := range merge.UploadColumnIDs {
+			if !uploadColumnIDs[ucID] {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The upload column %s does not exist", ucID)})
+				return
+			}
+		}
+	}
 	if !upload.IsStored {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Upload is not yet stored, please wait until the upload has finished processing"})
 		return
@@ -591,9 +660,22 @@ func importerSetColumnMapping(c *gin.Context) {
 	//  2. If the template is SDK-defined, the upload will have a template saved on it, so use that
 	//  3. Else, use the template in the database attached to the importer
 
+	if len(req.DataTypes) != 0 && !upload.Schemaless {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Data types can only be set on schemaless uploads"})
+		return
+	}
+	for destKey := range req.DataTypes {
+		if !lo.Contains(destinations, destKey) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The data type is set on the column '%s', which is not mapped", destKey)})
+			return
+		}
+	}
+
 	if upload.Schemaless {
 		var columns []*types.TemplateColumn
-		for v, destKey := range columnMapping {
+		templateColumnIDs := make(map[string]string, len(destinations))
+		var generatedValidationID uint = 1
+		for _, destKey := range destinations {
 			if !util.ValidateKey(destKey) {
 				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{
 					Err: fmt.Sprintf("The column '%s' is invalid. Desintation columns can only contain letters, numbers, and underscores", destKey),
@@ -601,13 +683,55 @@ func importerSetColumnMapping(c *gin.Context) {
 				return
 			}
 			tcID := model.NewID()
+			// The data type accepted for the column (i.e. the inferred data type) and its validation, if any
+			dataType, validate, err := model.ParseInferredType(req.DataTypes[destKey])
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The data type of the column '%s' is invalid: %s", destKey, err.Error())})
+				return
+			}
+			validates := make([]string, 0, 2)
+			if evaluator.IsDataTypeEvaluator(string(dataType)) {
+				validates = append(validates, string(dataType))
+			}
+			if len(validate) != 0 {
+				validates = append(validates, validate)
+			}
+			validations := make([]*types.Validation, 0, len(validates))
+			for _, v := range validates {
+				validation, err := model.ParseValidation(generatedValidationID, tcID.String(), v, jsonb.NewNull(), "", "", dataType)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+					return
+				}
+				generatedValidationID++
+				validations = append(validations, &types.Validation{
+					ValidationID: validation.ID,
+					Validate:     validation.Validate,
+					Options:      validation.Options,
+					Message:      validation.Message,
+					Severity:     string(validation.Severity),
+				})
+			}
 			columns = append(columns, &types.TemplateColumn{
-				ID:   tcID,
-				Name: destKey,
-				Key:  destKey,
+				ID:          tcID,
+				Name:        destKey,
+				Key:         destKey,
+				DataType:    string(dataType),
+				Validations: validations,
+			})
+			templateColumnIDs[destKey] = tcID.String()
+		}
+		// Update the column mapping, splits and merges to the newly generated template column IDs
+		for v, destKey := range columnMapping {
+			columnMapping[v] = templateColumnIDs[destKey]
+		}
+		for _, split := range columnMappings.Splits {
+			split.TemplateColumnIDs = lo.Map(split.TemplateColumnIDs, func(destKey string, _ int) string {
+				return templateColumnIDs[destKey]
 			})
-			// Update the column mapping to the newly generated template column key
-			columnMapping[v] = tcID.String()
+		}
+		for _, merge := range columnMappings.Merges {
+			merge.TemplateColumnID = templateColumnIDs[merge.TemplateColumnID]
 		}
 		importServiceTemplate := &types.Template{
 			ID:              model.NewID(),
@@ -633,18 +757,7 @@ func importerSetColumnMapping(c *gin.Context) {
 		}
 
 		// Generate a template to be used for the import processing
-		template = &model.Template{
-			Name:        importServiceTemplate.Name,
-			WorkspaceID: upload.WorkspaceID,
-		}
-		for _, importColumn := range importServiceTemplate.TemplateColumns {
-			templateColumn := &model.TemplateColumn{
-				ID:   importColumn.ID,
-				Name: importColumn.Name,
-				Key:  importColumn.Key,
-			}
-			template.TemplateColumns = append(template.TemplateColumns, templateColumn)
-		}
+		template = types.ConvertTemplateToModel(importServiceTemplate, upload.WorkspaceID)
 
 	} else if upload.Template.Valid {
 		// A template was set on the upload (SDK-defined template), use that instead of the importer template
@@ -654,28 +767,7 @@ func importerSetColumnMapping(c *gin.Context) {
 			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 			return
 		}
-		template = &model.Template{
-			Name:        importServiceTemplate.Name,
-			WorkspaceID: upload.WorkspaceID,
-		}
-		for _, importColumn := range importServiceTemplate.TemplateColumns {
-			templateColumn := &model.TemplateColumn{
-				ID:                importColumn.ID,
-				Name:              importColumn.Name,
-				Key:               importColumn.Key,
-				Required:          importColumn.Required,
-				DataType:          model.TemplateColumnDataType(importColumn.DataType),
-				Description:       null.NewString(importColumn.Description, len(importColumn.Description) != 0),
-				SuggestedMappings: importColumn.SuggestedMappings,
-			}
-			for _, v := range importColumn.Validations {
-				validation, err := model.ParseValidation(v.ValidationID, importColumn.ID.String(), v.Validate, v.Options, v.Message, v.Severity, templateColumn.DataType)
-				if err == nil {
-					templateColumn.Validations = append(templateColumn.Validations, validation)
-				}
-			}
-			template.TemplateColumns = append(template.TemplateColumns, templateColumn)
-		}
+		template = types.ConvertTemplateToModel(importServiceTemplate, upload.WorkspaceID)
 	} else {
 		// Use the importer template
 		template, err = db.GetTemplateByImporter(upload.ImporterID.String())
@@ -690,12 +782,21 @@ func importerSetColumnMapping(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Template does not have columns"})
 		return
 	}
-	// Validate that all required template column IDs are provided
-	providedTemplateColumnIDs := lo.MapEntries(columnMapping, func(k string, v string) (string, interface{}) {
-		return v, struct{}{}
+	providedTemplateColumnIDs := lo.SliceToMap(append(lo.Values(columnMapping), columnMappings.TemplateColumnIDs()...), func(tcID string) (string, interface{}) {
+		return tcID, struct{}{}
 	})
+	// Validate that computed columns aren't mapped, these are computed from the other columns
+	computedColumn, mapsComputedColumn := lo.Find(template.TemplateColumns, func(tc *model.TemplateColumn) bool {
+		_, has := providedTemplateColumnIDs[tc.ID.String()]
+		return has && tc.IsComputed()
+	})
+	if mapsComputedColumn {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The column %s is computed from other columns and cannot be mapped", computedColumn.Name)})
+		return
+	}
+	// Validate that all required template column IDs are provided, unless the column has a default value or is computed
 	hasAllRequiredColumns := lo.EveryBy(template.TemplateColumns, func(tc *model.TemplateColumn) bool {
-		if tc.Required {
+		if tc.Required && tc.DefaultValue == nil && !tc.IsComputed() {
 			_, has := providedTemplateColumnIDs[tc.ID.String()]
 			return has
 		}
@@ -712,9 +813,9 @@ func importerSetColumnMapping(c *gin.Context) {
 	// The next cases handle the column mapping having already been submitted with either the same or different mapping
 	columnsAlreadyMapped := lo.ContainsBy(upload.UploadColumns, func(uc *model.UploadColumn) bool {
 		return uc.TemplateColumnID.Valid
-	})
+	}) || !upload.ColumnMappings.IsEmpty()
 	if columnsAlreadyMapped {
-		sameColumnMapping := true
+		sameColumnMapping := upload.ColumnMappings.Equals(columnMappings)
 		for _, uc := range upload.UploadColumns {
 			tcID, ok := columnMapping[uc.ID.String()]
 
@@ -761,6 +862,12 @@ func importerSetColumnMapping(c *gin.Context) {
 		}
 	}
 
+	err = db.SetUploadColumnMappings(upload, columnMappings)
+	if err != nil {
+		tf.Log.Errorw("Could not set column splits and merges", "error", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "An error occurred updating the column mapping"})
+		return
+	}
 	err = db.SetTemplateColumnIDs(upload, columnMapping)
 	if err != nil {
 		tf.Log.Errorw("Could not set template column mapping", "error", err)
@@ -768,6 +875,10 @@ func importerSetColumnMapping(c *gin.Context) {
 		return
 	}
 
+	if !upload.Schemaless {
+		learnColumnMappings(upload, template, columnMapping, columnMappings)
+	}
+
 	// Trigger the import where all rows are loaded and validated to be displayed next on the review step
 	util.SafeGo(func() {
 		file.ImportData(upload, template)
@@ -808,6 +919,8 @@ func importerReviewImport(c *gin.Context) {
 		HasErrors:          imp.HasErrors(),
 		NumErrorRows:       imp.NumErrorRows,
 		NumValidRows:       imp.NumValidRows,
+		NumWarningRows:     imp.NumWarningRows,
+		NumInfoRows:        imp.NumInfoRows,
 		CreatedAt:          imp.CreatedAt,
 		UpdatedAt:          imp.UpdatedAt,
 	}
@@ -960,53 +1073,61 @@ func importerEditImportCell(c *gin.Context) {
 	cellKey := *req.CellKey
 	cellValue := *req.CellValue
 
-	// Retrieve any validations to perform on the cell
-	var validations []*model.Validation
+	// Retrieve the template used to process the import, as row validations on other columns may reference this cell
+	template, err := file.GetImportTemplate(imp.Upload)
+	if err != nil {
+		tf.Log.Errorw("Could not retrieve template for cell edit", "upload_id", imp.Upload.ID, "error", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "An error occurred retrieving the validations for this cell"})
+		return
+	}
+	templateColumn, ok := lo.Find(template.TemplateColumns, func(tc *model.TemplateColumn) bool { return tc.Key == cellKey })
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The cell_key %s does not exist on the template", cellKey)})
+		return
+	}
+	if templateColumn.IsComputed() {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The column %s is computed from other columns and cannot be edited", cellKey)})
+		return
+	}
+	computedColumns, err := model.ParseComputedColumns(template.TemplateColumns)
+	if err != nil {
+		tf.Log.Errorw("Could not parse computed columns for cell edit", "upload_id", imp.Upload.ID, "error", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "An error occurred retrieving the computed columns for this cell"})
+		return
+	}
 
-	if imp.Upload.Template.Valid {
-		// If the upload uses an SDK-defined template, retrieve any validations from the template on the upload
-		template, err := types.ConvertRawTemplate(imp.Upload.Template, false, nil, false)
-		if err != nil {
-			tf.Log.Errorw("Upload template invalid retrieving validations for cell edit", "upload_id", imp.Upload.ID, "error", err)
-			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The SDK-defined template is invalid"})
-			return
-		}
-		for _, templateColumn := range template.TemplateColumns {
-			if templateColumn.Key != cellKey {
-				continue
-			}
-			for _, v := range templateColumn.Validations {
-				validation, err := model.ParseValidation(v.ValidationID, templateColumn.ID.String(), v.Validate, v.Options, v.Message, v.Severity, model.TemplateColumnDataType(templateColumn.DataType))
-				if err == nil {
-					validations = append(validations, validation)
-				}
-			}
-		}
-	} else {
-		// Retrieve any validations from the db
-		validations, err = db.GetValidationsByImporterAndTemplateColumnKey(imp.ImporterID.String(), cellKey)
-		if err != nil {
-			tf.Log.Errorw("Could not retrieve validations from db for cell edit", "upload_id", imp.Upload.ID, "error", err)
-			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "An error occurred retrieving the validations for this cell"})
-			return
+	// All the validations on the template by ID, to look up the severity and message of the existing errors on the row
+	validationsByID := make(map[uint]*model.Validation)
+	for _, tc := range template.TemplateColumns {
+		for _, validation := range tc.CellValidations() {
+			validationsByID[validation.ID] = validation
 		}
 	}
 
-	failedValidations := make([]model.Validation, 0)
-	for _, validation := range validations {
-		passed, value := validation.Evaluate(cellValue)
+	// The edited value is transformed and defaulted the same as the values in the file
+	rawCellValue := cellValue
+	cellValue = templateColumn.Transforms.Apply(cellValue)
+	if util.IsBlankUnicode(cellValue) && templateColumn.DefaultValue != nil {
+		cellValue = templateColumn.DefaultValue.Generate(imp.Metadata, imp.CreatedAt.Time)
+		rawCellValue = cellValue
+	}
+
+	// The validations that didn't pass, with the details of the failures to store with the row
+	type failedValidation struct {
+		validation model.Validation
+		detail     types.ImportRowErrorDetail
+	}
+	failedValidations := make([]failedValidation, 0)
+	for _, validation := range templateColumn.CellValidations() {
+		if validation.IsRowValidation() {
+			// Row validations are performed below once the row is retrieved
+			continue
+		}
+		// Batch validations (i.e. http) aren't prefetched here, only the edited cell is sent to the remote endpoint
+		passed, value, failedElements := validation.EvaluateCell(cellValue, templateColumn.ListDelimiter())
 		if !passed {
-			switch validation.Severity {
-			case model.ValidationSeverityError:
-				failedValidations = append(failedValidations, *validation)
-				continue
-			default:
-				// TODO: Update this condition once we support warning and info validation severities
-				// We'll most likely need to persist the warning or info into where they are stored when this occurs
-				// instead of returning an error here.
-				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Unsupported validation severity"})
-				return
-			}
+			detail := scylla.NewImportRowErrorDetail(*validation, cellValue, templateColumn.ListDelimiter(), failedElements)
+			failedValidations = append(failedValidations, failedValidation{*validation, detail})
 		} else {
 			cellValue = value
 		}
@@ -1024,39 +1145,126 @@ func importerEditImportCell(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No error exists for the current row"})
 		return
 	}
+	hadWarning, hadInfo := importRowHasSeverities(row.Errors, validationsByID)
 
-	// Update the row values for the current cell with the new value
+	// Update the row values for the current cell with the new value, keeping the value entered if it was changed
 	row.Values[cellKey] = cellValue
+	if row.RawValues == nil {
+		row.RawValues = make(map[string]string)
+	}
+	if cellValue != rawCellValue {
+		row.RawValues[cellKey] = rawCellValue
+	} else {
+		delete(row.RawValues, cellKey)
+	}
 
-	// Update the errors map for the current cell with the new validations, or nil if there are no new errors
-	if len(failedValidations) != 0 {
-		if row.Errors == nil {
-			row.Errors = make(map[string][]types.ImportRowError)
+	// Recompute the computed cells, as their formulas may reference the edited cell
+	computedKeys := make(map[string]bool, len(computedColumns))
+	computedFailedValidations := make(map[string][]failedValidation)
+	for _, cc := range computedColumns {
+		key := cc.TemplateColumn.Key
+		computedKeys[key] = true
+		computedValue, err := cc.Compute(row.Values)
+		if err != nil {
+			computedValue = ""
 		}
-		row.Errors[cellKey] = lo.Map(failedValidations, func(v model.Validation, _ int) types.ImportRowError {
-			return types.ImportRowError{
-				ValidationID: v.ID,
-				Validate:     v.Validate,
-				Severity:     string(v.Severity),
-				Message:      v.Message,
+		for _, validation := range cc.TemplateColumn.CellValidations() {
+			if validation.IsRowValidation() {
+				continue
+			}
+			passed, value, failedElements := validation.EvaluateCell(computedValue, cc.TemplateColumn.ListDelimiter())
+			if !passed {
+				detail := scylla.NewImportRowErrorDetail(*validation, computedValue, cc.TemplateColumn.ListDelimiter(), failedElements)
+				computedFailedValidations[key] = append(computedFailedValidations[key], failedValidation{*validation, detail})
+			} else {
+				computedValue = value
 			}
-		})
-	} else {
-		delete(row.Errors, cellKey)
-		if len(row.Errors) == 0 {
-			row.Errors = nil
 		}
+		row.Values[key] = computedValue
 	}
 
-	if len(row.Errors) != 0 {
-		// Update or insert into the import_row_errors record to add any new errors or remove the error for the current cell
+	// Re-evaluate the row validations on every column in the order of the template columns, the same as the import, as
+	// any of them may reference the edited cell
+	rowValidationIDs := make(map[uint]bool)
+	rowFailedValidations := make(map[string][]failedValidation)
+	for _, tc := range template.TemplateColumns {
+		if _, mapped := row.Values[tc.Key]; !mapped {
+			continue
+		}
+		for _, validation := range tc.Validations {
+			if !validation.IsRowValidation() {
+				continue
+			}
+			rowValidationIDs[validation.ID] = true
+			passed, value, failedElements := validation.EvaluateRowCell(row.Values[tc.Key], tc.ListDelimiter(), row.Values)
+			if !passed {
+				detail := scylla.NewImportRowErrorDetail(*validation, row.Values[tc.Key], tc.ListDelimiter(), failedElements)
+				rowFailedValidations[tc.Key] = append(rowFailedValidations[tc.Key], failedValidation{*validation, detail})
+			} else {
+				row.Values[tc.Key] = value
+			}
+		}
+	}
+
+	// Rebuild the errors map: keep the existing cell errors on the other columns with their stored details, replace the
+	// errors of the current cell and the computed cells with the new validations, and replace the row validation errors
+	// on every column
+	listDelimiters := imp.GetListDelimiters()
+	toImportRowErrors := func(key string, validations []failedValidation) []types.ImportRowError {
+		return lo.Map(validations, func(f failedValidation, _ int) types.ImportRowError {
+			return scylla.NewImportRowError(f.validation, row.Values[key], listDelimiters[key], f.detail)
+		})
+	}
+	rowErrors := make(map[string][]types.ImportRowError)
+	for key, keyErrors := range row.Errors {
+		if key == cellKey || computedKeys[key] {
+			continue
+		}
+		keyValidations := lo.FilterMap(keyErrors, func(ire types.ImportRowError, _ int) (failedValidation, bool) {
+			v, ok := validationsByID[ire.ValidationID]
+			if !ok || rowValidationIDs[ire.ValidationID] {
+				return failedValidation{}, false
+			}
+			return failedValidation{*v, types.ImportRowErrorDetail{FailedElements: ire.FailedElements, Suggestions: ire.Suggestions}}, true
+		})
+		if len(keyValidations) != 0 {
+			rowErrors[key] = toImportRowErrors(key, keyValidations)
+		}
+	}
+	if len(failedValidations) != 0 {
+		rowErrors[cellKey] = toImportRowErrors(cellKey, failedValidations)
+	}
+	for key, validations := range computedFailedValidations {
+		rowErrors[key] = toImportRowErrors(key, validations)
+	}
+	for key, validations := range rowFailedValidations {
+		rowErrors[key] = append(rowErrors[key], toImportRowErrors(key, validations)...)
+	}
+	row.Errors = lo.Ternary(len(rowErrors) == 0, nil, rowErrors)
 
-		rawRowErrors := lo.Map(row.Errors[cellKey], func(ire types.ImportRowError, _ int) uint { return ire.ValidationID })
-		if len(rawRowErrors) == 0 {
-			rawRowErrors = nil
+	// Split the validation IDs by where they are stored, only the error severity makes this an error row
+	rawRowErrors := make(map[string][]uint)
+	rawRowWarnings := make(map[string][]uint)
+	for key, ires := range row.Errors {
+		for _, ire := range ires {
+			if model.ValidationSeverity(ire.Severity) == model.ValidationSeverityError {
+				rawRowErrors[key] = append(rawRowErrors[key], ire.ValidationID)
+			} else {
+				rawRowWarnings[key] = append(rawRowWarnings[key], ire.ValidationID)
+			}
 		}
-		err = tf.Scylla.Query("update import_row_errors set errors[?] = ?, values = ? where import_id = ? and row_index = ?",
-			cellKey, rawRowErrors, row.Values, imp.ID.String(), rowIndex).Exec()
+	}
+	rowErrorDetails := scylla.NewImportRowErrorDetails(row.Errors)
+	hasWarning, hasInfo := importRowHasSeverities(row.Errors, validationsByID)
+	importChanged := hasWarning != hadWarning || hasInfo != hadInfo
+	// The counts are null on imports created before the severities were added, so Valid has to be set
+	imp.NumWarningRows = null.IntFrom(imp.NumWarningRows.Int64 + int64(lo.Ternary(hasWarning, 1, 0)-lo.Ternary(hadWarning, 1, 0)))
+	imp.NumInfoRows = null.IntFrom(imp.NumInfoRows.Int64 + int64(lo.Ternary(hasInfo, 1, 0)-lo.Ternary(hadInfo, 1, 0)))
+
+	if len(rawRowErrors) != 0 {
+		// Update or insert into the import_row_errors record to add any new errors or remove the error for the current cell
+		err = tf.Scylla.Query("update import_row_errors set errors = ?, warnings = ?, error_details = ?, values = ?, raw_values = ? where import_id = ? and row_index = ?",
+			rawRowErrors, rawRowWarnings, rowErrorDetails, row.Values, row.RawValues, imp.ID.String(), rowIndex).Exec()
 		if err != nil {
 			tf.Log.Errorw("Could update import_row_errors during cell edit", "import_id", imp.ID, "cell_key", cellKey, "row_index", rowIndex, "error", err)
 			c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: fmt.Sprintf("Could not update cell: %s", err)})
@@ -1073,28 +1281,12 @@ func importerEditImportCell(c *gin.Context) {
 			// Update the aggregate row numbers on the import
 			imp.NumValidRows.Int64--
 			imp.NumErrorRows.Int64++
-			err = tf.DB.Save(imp).Error
-			if err != nil {
-				tf.Log.Errorw("Could not update import in database", "import_id", imp.ID, "error", err)
-				c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: fmt.Sprintf("Could not update cell: %s", err)})
-				return
-			}
-		}
-		res := &types.ImportCellEditResponse{
-			NumRows:      imp.NumRows,
-			NumValidRows: imp.NumValidRows,
-			NumErrorRows: imp.NumErrorRows,
-			HasErrors:    imp.HasErrors(),
-			Row:          row,
+			importChanged = true
 		}
-		c.JSON(http.StatusOK, res)
-		return
-	}
-
-	// At this point all errors are resolved or there never was an error
-	if isErrorRow {
+	} else if isErrorRow {
+		// At this point all errors are resolved, any warnings or info remaining don't prevent the row from being valid
 		// Move the record from import_row_errors to import_rows (the user was editing an error row and all errors are now resolved)
-		err = tf.Scylla.Query("insert into import_rows (import_id, row_index, values) values (?, ?, ?)", imp.ID.String(), rowIndex, row.Values).Exec()
+		err = tf.Scylla.Query("insert into import_rows (import_id, row_index, values, raw_values, warnings, error_details) values (?, ?, ?, ?, ?, ?)", imp.ID.String(), rowIndex, row.Values, row.RawValues, rawRowWarnings, rowErrorDetails).Exec()
 		if err != nil {
 			tf.Log.Errorw("Could not insert into import_rows during cell edit", "import_id", imp.ID, "cell_key", cellKey, "row_index", rowIndex, "error", err)
 			c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: fmt.Sprintf("Could not update cell: %s", err)})
@@ -1110,34 +1302,54 @@ func importerEditImportCell(c *gin.Context) {
 		// Update the aggregate row numbers on the import
 		imp.NumValidRows.Int64++
 		imp.NumErrorRows.Int64--
-		err = tf.DB.Save(imp).Error
+		importChanged = true
+	} else {
+		// Update import_rows (the user was editing a non-error row and the edit was valid)
+		err = tf.Scylla.Query("update import_rows set values = ?, raw_values = ?, warnings = ?, error_details = ? where import_id = ? and row_index = ?",
+			row.Values, row.RawValues, rawRowWarnings, rowErrorDetails, imp.ID.String(), rowIndex).Exec()
 		if err != nil {
-			tf.Log.Errorw("Could not update import in database", "import_id", imp.ID, "error", err)
+			tf.Log.Errorw("Could update import_rows during cell edit", "import_id", imp.ID, "cell_key", cellKey, "row_index", rowIndex, "error", err)
 			c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: fmt.Sprintf("Could not update cell: %s", err)})
 			return
 		}
-	} else {
-		// Update import_rows (the user was editing a non-error row and the edit was valid)
-		err = tf.Scylla.Query("update import_rows set values[?] = ? where import_id = ? and row_index = ?",
-			cellKey, cellValue, imp.ID.String(), rowIndex).Exec()
+	}
+
+	if importChanged {
+		err = tf.DB.Save(imp).Error
 		if err != nil {
-			tf.Log.Errorw("Could update import_rows during cell edit", "import_id", imp.ID, "cell_key", cellKey, "row_index", rowIndex, "error", err)
+			tf.Log.Errorw("Could not update import in database", "import_id", imp.ID, "error", err)
 			c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: fmt.Sprintf("Could not update cell: %s", err)})
 			return
 		}
 	}
 
 	res := &types.ImportCellEditResponse{
-		NumRows:      imp.NumRows,
-		NumValidRows: imp.NumValidRows,
-		NumErrorRows: imp.NumErrorRows,
-		HasErrors:    imp.HasErrors(),
-		Row:          row,
+		NumRows:        imp.NumRows,
+		NumValidRows:   imp.NumValidRows,
+		NumErrorRows:   imp.NumErrorRows,
+		NumWarningRows: imp.NumWarningRows,
+		NumInfoRows:    imp.NumInfoRows,
+		HasErrors:      imp.HasErrors(),
+		Row:            row,
 	}
 	c.JSON(http.StatusOK, res)
 	return
 }
 
+// importRowHasSeverities returns whether the row errors contain a failed validation with the warn or info severity
+func importRowHasSeverities(rowErrors map[string][]types.ImportRowError, validationsByID map[uint]*model.Validation) (bool, bool) {
+	hasWarning, hasInfo := false, false
+	for _, ires := range rowErrors {
+		for _, ire := range ires {
+			if v, ok := validationsByID[ire.ValidationID]; ok {
+				hasWarning = hasWarning || v.Severity == model.ValidationSeverityWarn
+				hasInfo = hasInfo || v.Severity == model.ValidationSeverityInfo
+			}
+		}
+	}
+	return hasWarning, hasInfo
+}
+
 // importerSubmitImport
 //
 //	@Summary		Submit an import by upload ID
@@ -1191,6 +1403,8 @@ func importerSubmitImport(c *gin.Context, importCompleteHandler func(types.Impor
 		HasErrors:          imp.HasErrors(),
 		NumErrorRows:       imp.NumErrorRows,
 		NumValidRows:       imp.NumValidRows,
+		NumWarningRows:     imp.NumWarningRows,
+		NumInfoRows:        imp.NumInfoRows,
 		CreatedAt:          imp.CreatedAt,
 		UpdatedAt:          imp.UpdatedAt,
 		Rows:               []types.ImportRowResponse{},
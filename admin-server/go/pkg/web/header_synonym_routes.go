@@ -0,0 +1,226 @@
+package web
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/types"
+	"time"
+)
+
+type HeaderSynonymCreateRequest struct {
+	WorkspaceID string   `json:"workspace_id" example:"b2079476-261a-41fe-8019-46eb51c537f7"`
+	Term        string   `json:"term" example:"first name"`
+	Synonyms    []string `json:"synonyms" example:"vorname,nombre,prénom"`
+}
+
+// HeaderSynonymEditRequest only updates the fields provided
+type HeaderSynonymEditRequest struct {
+	Term     *string   `json:"term" example:"first name"`
+	Synonyms *[]string `json:"synonyms" example:"vorname,nombre,prénom"`
+}
+
+// createHeaderSynonym
+//
+//	@Summary		Create header synonym
+//	@Description	Create a group of headers that name the same column, used to match upload columns to template columns
+//	@Tags			Header Synonym
+//	@Success		200	{object}	model.HeaderSynonym
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/header-synonym [post]
+//	@Param			body	body	HeaderSynonymCreateRequest	true	"Request body"
+func createHeaderSynonym(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	req := HeaderSynonymCreateRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		tf.Log.Warnw("Could not bind JSON", "error", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	if len(req.WorkspaceID) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No workspace ID provided"})
+		return
+	}
+	userID, err := getWorkspaceUser(c, req.WorkspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	term, err := model.ParseHeaderSynonymTerm(req.Term)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	synonyms, err := model.ParseSynonyms(req.Synonyms)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid synonyms: %v", err.Error())})
+		return
+	}
+
+	headerSynonym := &model.HeaderSynonym{
+		ID:          model.NewID(),
+		WorkspaceID: model.ParseID(req.WorkspaceID),
+		Term:        term,
+		Synonyms:    synonyms,
+		CreatedBy:   user.ID,
+		UpdatedBy:   user.ID,
+	}
+	err = tf.DB.Create(headerSynonym).Error
+	if err != nil {
+		tf.Log.Errorw("Could not create header synonym", "error", err, "workspace_id", req.WorkspaceID)
+		abortHeaderSynonymSaveError(c, err, term)
+		return
+	}
+	c.JSON(http.StatusOK, headerSynonym)
+}
+
+// getHeaderSynonyms
+//
+//	@Summary		Get header synonyms
+//	@Description	Get the header synonym groups of a workspace, which extend the built-in dictionary of common fields
+//	@Tags			Header Synonym
+//	@Success		200	{object}	[]model.HeaderSynonym
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/header-synonyms/{workspace-id} [get]
+//	@Param			workspace-id	path	string	true	"Workspace ID"
+func getHeaderSynonyms(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	workspaceID := c.Param("workspace-id")
+	if len(workspaceID) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No workspace ID provided"})
+		return
+	}
+	_, err := getWorkspaceUser(c, workspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	headerSynonyms, err := db.GetHeaderSynonymsWithUsers(workspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, headerSynonyms)
+}
+
+// editHeaderSynonym
+//
+//	@Summary		Edit header synonym
+//	@Description	Edit the term or replace the synonyms of a header synonym group
+//	@Tags			Header Synonym
+//	@Success		200	{object}	model.HeaderSynonym
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/header-synonym/{id} [post]
+//	@Param			id		path	string						true	"Header synonym ID"
+//	@Param			body	body	HeaderSynonymEditRequest	true	"Request body"
+func editHeaderSynonym(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No header synonym ID provided"})
+		return
+	}
+	req := HeaderSynonymEditRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		tf.Log.Warnw("Could not bind JSON", "error", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	headerSynonym, err := db.GetHeaderSynonym(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	userID, err := getWorkspaceUser(c, headerSynonym.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	// Change any field that exists on the request and are different
+	updates := make(map[string]interface{})
+	if req.Term != nil {
+		term, err := model.ParseHeaderSynonymTerm(*req.Term)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		if term != headerSynonym.Term {
+			headerSynonym.Term = term
+			updates["term"] = term
+		}
+	}
+	if req.Synonyms != nil {
+		synonyms, err := model.ParseSynonyms(*req.Synonyms)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid synonyms: %v", err.Error())})
+			return
+		}
+		headerSynonym.Synonyms = synonyms
+		updates["synonyms"] = headerSynonym.Synonyms
+	}
+
+	if len(updates) != 0 {
+		updates["updated_by"] = user.ID
+		err = tf.DB.Model(headerSynonym).Updates(updates).Error
+		if err != nil {
+			tf.Log.Errorw("Could not save header synonym", "error", err, "header_synonym_id", headerSynonym.ID)
+			abortHeaderSynonymSaveError(c, err, headerSynonym.Term)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, headerSynonym)
+}
+
+// deleteHeaderSynonym
+//
+//	@Summary		Delete header synonym
+//	@Description	Delete a header synonym group
+//	@Tags			Header Synonym
+//	@Success		200	{object}	types.Res
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/header-synonym/{id} [delete]
+//	@Param			id	path	string	true	"Header synonym ID"
+func deleteHeaderSynonym(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No header synonym ID provided"})
+		return
+	}
+	headerSynonym, err := db.GetHeaderSynonym(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	userID, err := getWorkspaceUser(c, headerSynonym.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	err = tf.DB.Model(headerSynonym).Updates(map[string]interface{}{
+		"deleted_by": user.ID,
+		"deleted_at": gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}).Error
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, types.Res{Message: "success"})
+}
+
+// abortHeaderSynonymSaveError responds with the error from saving a header synonym group, explaining the error if
+// another group in the workspace has the same term
+func abortHeaderSynonymSaveError(c *gin.Context, err error, term string) {
+	if strings.Contains(err.Error(), "header_synonyms_workspace_id_term_idx") {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("A header synonym with the term %s already exists", term)})
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+}
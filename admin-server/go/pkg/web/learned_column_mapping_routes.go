@@ -0,0 +1,112 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/types"
+)
+
+// getLearnedColumnMappings
+//
+//	@Summary		Get learned column mappings
+//	@Description	Get the column mappings learned from the uploads to an importer, which are suggested on the next uploads with the same headers
+//	@Tags			Learned Column Mapping
+//	@Success		200	{object}	[]model.LearnedColumnMapping
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/importer/{id}/learned-column-mappings [get]
+//	@Param			id	path	string	true	"Importer ID"
+func getLearnedColumnMappings(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No importer ID provided"})
+		return
+	}
+	importer, err := db.GetImporterWithoutTemplate(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	_, err = getWorkspaceUser(c, importer.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	mappings, err := db.GetLearnedColumnMappings(importer.ID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mappings)
+}
+
+// deleteLearnedColumnMappings
+//
+//	@Summary		Delete learned column mappings
+//	@Description	Forget all the column mappings learned from the uploads to an importer
+//	@Tags			Learned Column Mapping
+//	@Success		200	{object}	types.Res
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/importer/{id}/learned-column-mappings [delete]
+//	@Param			id	path	string	true	"Importer ID"
+func deleteLearnedColumnMappings(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No importer ID provided"})
+		return
+	}
+	importer, err := db.GetImporterWithoutTemplate(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	_, err = getWorkspaceUser(c, importer.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	err = db.DeleteLearnedColumnMappings(importer.ID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, types.Res{Message: "success"})
+}
+
+// deleteLearnedColumnMapping
+//
+//	@Summary		Delete learned column mapping
+//	@Description	Forget a column mapping learned from the uploads to an importer
+//	@Tags			Learned Column Mapping
+//	@Success		200	{object}	types.Res
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/learned-column-mapping/{id} [delete]
+//	@Param			id	path	string	true	"Learned column mapping ID"
+func deleteLearnedColumnMapping(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No learned column mapping ID provided"})
+		return
+	}
+	mapping, err := db.GetLearnedColumnMapping(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	importer, err := db.GetImporterWithoutTemplate(mapping.ImporterID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	_, err = getWorkspaceUser(c, importer.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	err = db.DeleteLearnedColumnMapping(mapping.ID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, types.Res{Message: "success"})
+}
@@ -0,0 +1,348 @@
+package web
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"strings"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/evaluator"
+	"tableflow/go/pkg/model"
+	"tableflow/go/pkg/model/jsonb"
+	"tableflow/go/pkg/tf"
+	"tableflow/go/pkg/types"
+	"tableflow/go/pkg/util"
+	"time"
+)
+
+const maxLookupTableFileSize = 20 * 1024 * 1024 // 20MB
+const maxLookupTableRows = 100000
+
+// createLookupTable
+//
+//	@Summary		Create lookup table
+//	@Description	Create a lookup table from a CSV file, the first row of the file is used as the column names
+//	@Tags			Lookup Table
+//	@Accept			multipart/form-data
+//	@Success		200	{object}	model.LookupTable
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/lookup-table [post]
+//	@Param			workspace_id	formData	string	true	"Workspace ID"
+//	@Param			name			formData	string	true	"Lookup table name"
+//	@Param			file			formData	file	true	"CSV file"
+func createLookupTable(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	workspaceID := c.PostForm("workspace_id")
+	if len(workspaceID) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No workspace ID provided"})
+		return
+	}
+	userID, err := getWorkspaceUser(c, workspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	name := strings.TrimSpace(c.PostForm("name"))
+	if len(name) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The lookup table name cannot be empty"})
+		return
+	}
+	columns, rows, err := readLookupTableFile(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	rowsJSON, err := jsonb.FromInterface(rows)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+
+	lookupTable := &model.LookupTable{
+		ID:          model.NewID(),
+		WorkspaceID: model.ParseID(workspaceID),
+		Name:        name,
+		Columns:     columns,
+		NumRows:     len(rows),
+		Rows:        rowsJSON,
+		CreatedBy:   user.ID,
+		UpdatedBy:   user.ID,
+	}
+	err = tf.DB.Create(lookupTable).Error
+	if err != nil {
+		tf.Log.Errorw("Could not create lookup table", "error", err, "workspace_id", workspaceID)
+		if strings.Contains(err.Error(), "lookup_tables_workspace_id_name_idx") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("A lookup table with the name %s already exists", name)})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, lookupTable)
+}
+
+// getLookupTable
+//
+//	@Summary		Get lookup table
+//	@Description	Get a single lookup table, without the rows
+//	@Tags			Lookup Table
+//	@Success		200	{object}	model.LookupTable
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/lookup-table/{id} [get]
+//	@Param			id	path	string	true	"Lookup table ID"
+func getLookupTable(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No lookup table ID provided"})
+		return
+	}
+	lookupTable, err := db.GetLookupTableWithUsers(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	_, err = getWorkspaceUser(c, lookupTable.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, lookupTable)
+}
+
+// getLookupTables
+//
+//	@Summary		Get lookup tables
+//	@Description	Get a list of the lookup tables in a workspace, without the rows
+//	@Tags			Lookup Table
+//	@Success		200	{object}	[]model.LookupTable
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/lookup-tables/{workspace-id} [get]
+//	@Param			workspace-id	path	string	true	"Workspace ID"
+func getLookupTables(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	workspaceID := c.Param("workspace-id")
+	if len(workspaceID) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No workspace ID provided"})
+		return
+	}
+	_, err := getWorkspaceUser(c, workspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	lookupTables, err := db.GetLookupTablesWithUsers(workspaceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, lookupTables)
+}
+
+// editLookupTable
+//
+//	@Summary		Edit lookup table
+//	@Description	Rename a lookup table or replace its rows with a new CSV file
+//	@Tags			Lookup Table
+//	@Accept			multipart/form-data
+//	@Success		200	{object}	model.LookupTable
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/lookup-table/{id} [post]
+//	@Param			id		path		string	true	"Lookup table ID"
+//	@Param			name	formData	string	false	"Lookup table name"
+//	@Param			file	formData	file	false	"CSV file"
+func editLookupTable(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No lookup table ID provided"})
+		return
+	}
+	lookupTable, err := db.GetLookupTable(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	userID, err := getWorkspaceUser(c, lookupTable.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	// Change any field that exists on the request and are different
+	updates := make(map[string]interface{})
+	if name, ok := c.GetPostForm("name"); ok {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "The lookup table name cannot be empty"})
+			return
+		}
+		if name != lookupTable.Name {
+			lookupTable.Name = name
+			updates["name"] = name
+		}
+	}
+	if _, err = c.FormFile("file"); err == nil {
+		columns, rows, err := readLookupTableFile(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		// The new file must contain the columns used by the lookup validations
+		referencedColumns, err := db.GetLookupTableReferencedColumns(lookupTable.ID.String())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		if missingColumns, _ := lo.Difference(referencedColumns, columns); len(missingColumns) != 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The column%s %s used by lookup validations %s missing from the file, please remove %s from the validations first",
+				lo.Ternary(len(missingColumns) == 1, "", "s"), strings.Join(missingColumns, ", "), lo.Ternary(len(missingColumns) == 1, "is", "are"), lo.Ternary(len(missingColumns) == 1, "it", "them"))})
+			return
+		}
+		rowsJSON, err := jsonb.FromInterface(rows)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		lookupTable.Columns = columns
+		lookupTable.NumRows = len(rows)
+		updates["columns"] = lookupTable.Columns
+		updates["num_rows"] = lookupTable.NumRows
+		updates["rows"] = rowsJSON
+	}
+
+	if len(updates) != 0 {
+		updates["updated_by"] = user.ID
+		err = tf.DB.Model(lookupTable).Updates(updates).Error
+		if err != nil {
+			tf.Log.Errorw("Could not save lookup table", "error", err, "lookup_table_id", lookupTable.ID)
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		evaluator.InvalidateLookupTable(lookupTable.ID.String())
+	}
+	c.JSON(http.StatusOK, lookupTable)
+}
+
+// deleteLookupTable
+//
+//	@Summary		Delete lookup table
+//	@Description	Delete a lookup table, which is only allowed if no validations use it
+//	@Tags			Lookup Table
+//	@Success		200	{object}	types.Res
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/lookup-table/{id} [delete]
+//	@Param			id	path	string	true	"Lookup table ID"
+func deleteLookupTable(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No lookup table ID provided"})
+		return
+	}
+	lookupTable, err := db.GetLookupTable(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	userID, err := getWorkspaceUser(c, lookupTable.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	numValidations, err := db.CountLookupTableValidations(lookupTable.ID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	if numValidations != 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The lookup table is used by %d validation%s, please remove %s before deleting the lookup table",
+			numValidations, lo.Ternary(numValidations == 1, "", "s"), lo.Ternary(numValidations == 1, "it", "them"))})
+		return
+	}
+
+	err = tf.DB.Model(lookupTable).Updates(map[string]interface{}{
+		"deleted_by": user.ID,
+		"deleted_at": gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}).Error
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	evaluator.InvalidateLookupTable(lookupTable.ID.String())
+	c.JSON(http.StatusOK, types.Res{Message: "success"})
+}
+
+// readLookupTableFile reads the CSV file on the request. The first row contains the column names, blank rows are
+// skipped.
+func readLookupTableFile(c *gin.Context) ([]string, [][]string, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, errors.New("No file provided")
+	}
+	if fileHeader.Size > maxLookupTableFileSize {
+		return nil, nil, fmt.Errorf("The file exceeds the max size of %dMB", maxLookupTableFileSize/1024/1024)
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("The file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not read the file: %s", err.Error())
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = strings.TrimSpace(name)
+		if len(columns[i]) == 0 {
+			return nil, nil, fmt.Errorf("The column name in column %d of the header row cannot be empty", i+1)
+		}
+		if lo.Contains(columns[:i], columns[i]) {
+			return nil, nil, fmt.Errorf("The column name %s is used more than once in the header row", columns[i])
+		}
+	}
+
+	rows := make([][]string, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("Could not read row %d of the file: %s", line, err.Error())
+		}
+		if lo.EveryBy(record, util.IsBlankUnicode) {
+			continue
+		}
+		if len(rows) == maxLookupTableRows {
+			return nil, nil, fmt.Errorf("The file exceeds the max number of rows (%d)", maxLookupTableRows)
+		}
+		row := make([]string, len(columns))
+		for i := range row {
+			if i < len(record) {
+				row[i] = strings.ToValidUTF8(record[i], "")
+			}
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"os"
 	_ "tableflow/docs"
+	"tableflow/go/pkg/db"
+	"tableflow/go/pkg/evaluator"
 	"tableflow/go/pkg/model"
 	"tableflow/go/pkg/tf"
 	"tableflow/go/pkg/types"
@@ -56,11 +58,21 @@ type ServerConfig struct {
 	AdditionalPublicRoutes         func(group *gin.RouterGroup)
 	AdditionalImporterRoutes       func(group *gin.RouterGroup)
 	AdditionalAdminRoutes          func(group *gin.RouterGroup)
+	Evaluators                     []evaluator.Definition
 	UseZapLogger                   bool
 }
 
 func StartWebServer(config ServerConfig) *http.Server {
 	tf.Log.Debugw("Starting API server")
+
+	// Register any custom validate types before templates are parsed
+	for _, definition := range config.Evaluators {
+		if err := evaluator.Register(definition); err != nil {
+			tf.Log.Fatalw("Could not register evaluator", "validate", definition.Name, "error", err)
+		}
+	}
+	evaluator.SetLookupTableProvider(db.GetLookupTableForEvaluator)
+
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
@@ -178,12 +190,34 @@ func StartWebServer(config ServerConfig) *http.Server {
 	adm.DELETE("/importer/:id", func(c *gin.Context) { deleteImporter(c, config.GetWorkspaceUser) })
 	adm.GET("/importers/:workspace-id", func(c *gin.Context) { getImporters(c, config.GetWorkspaceUser) })
 
+	/* Learned Column Mapping */
+	adm.GET("/importer/:id/learned-column-mappings", func(c *gin.Context) { getLearnedColumnMappings(c, config.GetWorkspaceUser) })
+	adm.DELETE("/importer/:id/learned-column-mappings", func(c *gin.Context) { deleteLearnedColumnMappings(c, config.GetWorkspaceUser) })
+	adm.DELETE("/learned-column-mapping/:id", func(c *gin.Context) { deleteLearnedColumnMapping(c, config.GetWorkspaceUser) })
+
 	/* Template */
 	adm.GET("/template/:id", func(c *gin.Context) { getTemplate(c, config.GetWorkspaceUser) })
+	adm.POST("/template/:id/generate", func(c *gin.Context) { generateTemplate(c, config.GetWorkspaceUser) })
+	adm.POST("/template/:id/columns", func(c *gin.Context) {
+		createTemplateColumns(c, config.GetWorkspaceUser, config.GetAllowedValidateTypes)
+	})
 	adm.POST("/template-column", func(c *gin.Context) { createTemplateColumn(c, config.GetWorkspaceUser, config.GetAllowedValidateTypes) })
 	adm.POST("/template-column/:id", func(c *gin.Context) { editTemplateColumn(c, config.GetWorkspaceUser, config.GetAllowedValidateTypes) })
 	adm.DELETE("/template-column/:id", func(c *gin.Context) { deleteTemplateColumn(c, config.GetWorkspaceUser) })
 
+	/* Lookup Table */
+	adm.POST("/lookup-table", func(c *gin.Context) { createLookupTable(c, config.GetWorkspaceUser) })
+	adm.GET("/lookup-table/:id", func(c *gin.Context) { getLookupTable(c, config.GetWorkspaceUser) })
+	adm.POST("/lookup-table/:id", func(c *gin.Context) { editLookupTable(c, config.GetWorkspaceUser) })
+	adm.DELETE("/lookup-table/:id", func(c *gin.Context) { deleteLookupTable(c, config.GetWorkspaceUser) })
+	adm.GET("/lookup-tables/:workspace-id", func(c *gin.Context) { getLookupTables(c, config.GetWorkspaceUser) })
+
+	/* Header Synonym */
+	adm.POST("/header-synonym", func(c *gin.Context) { createHeaderSynonym(c, config.GetWorkspaceUser) })
+	adm.POST("/header-synonym/:id", func(c *gin.Context) { editHeaderSynonym(c, config.GetWorkspaceUser) })
+	adm.DELETE("/header-synonym/:id", func(c *gin.Context) { deleteHeaderSynonym(c, config.GetWorkspaceUser) })
+	adm.GET("/header-synonyms/:workspace-id", func(c *gin.Context) { getHeaderSynonyms(c, config.GetWorkspaceUser) })
+
 	/* Import */
 	adm.GET("/import/:id", func(c *gin.Context) { getImport(c, config.GetWorkspaceUser) })
 	adm.GET("/imports/:workspace-id", func(c *gin.Context) { getImports(c, config.GetWorkspaceUser) })
@@ -7,10 +7,13 @@ import (
 	"github.com/samber/lo"
 	"gorm.io/gorm"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"tableflow/go/pkg/db"
 	"tableflow/go/pkg/evaluator"
+	"tableflow/go/pkg/file"
 	"tableflow/go/pkg/model"
 	"tableflow/go/pkg/model/jsonb"
 	"tableflow/go/pkg/tf"
@@ -19,6 +22,14 @@ import (
 	"time"
 )
 
+const maxTemplateSampleFileSize = 50 * 1024 * 1024 // 50MB
+
+// templateSampleFileTypes are the file types of the sample files templates can be generated from, by file extension
+var templateSampleFileTypes = map[string]string{
+	".csv":  "text/csv",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
 type TemplateColumnCreateRequest struct {
 	TemplateID        string                            `json:"template_id" example:"f0797968-becc-422a-b135-19de1d8c5d46"`
 	Name              string                            `json:"name" example:"First Name"`
@@ -28,8 +39,16 @@ type TemplateColumnCreateRequest struct {
 	Description       string                            `json:"description" example:"The first name"`
 	Validations       []TemplateColumnValidationRequest `json:"validations"`
 	SuggestedMappings *[]string                         `json:"suggested_mappings"`
+	Synonyms          []string                          `json:"synonyms" example:"vorname,nombre"`
+	Transforms        []TemplateColumnTransformRequest  `json:"transforms"`
+	DefaultValue      *TemplateColumnDefaultRequest     `json:"default_value"`
+	Formula           string                            `json:"formula" example:"first_name + ' ' + last_name"`
+	IsList            bool                              `json:"is_list" example:"false"`
+	Delimiter         string                            `json:"delimiter" example:";"`
 }
 
+// TemplateColumnEditRequest only updates the fields provided. The default value is removed if its type is blank, and
+// the formula is removed if it's blank. The delimiter is removed if the column is no longer a list.
 type TemplateColumnEditRequest struct {
 	Name              *string                            `json:"name" example:"First Name"`
 	Key               *string                            `json:"key" example:"first_name"`
@@ -38,6 +57,12 @@ type TemplateColumnEditRequest struct {
 	DataType          *string                            `json:"data_type" example:"string"`
 	Validations       *[]TemplateColumnValidationRequest `json:"validations"`
 	SuggestedMappings *[]string                          `json:"suggested_mappings"`
+	Synonyms          *[]string                          `json:"synonyms" example:"vorname,nombre"`
+	Transforms        *[]TemplateColumnTransformRequest  `json:"transforms"`
+	DefaultValue      *TemplateColumnDefaultRequest      `json:"default_value"`
+	Formula           *string                            `json:"formula" example:"first_name + ' ' + last_name"`
+	IsList            *bool                              `json:"is_list" example:"false"`
+	Delimiter         *string                            `json:"delimiter" example:";"`
 	Index             *int                               `json:"index" example:"0"`
 }
 
@@ -49,6 +74,17 @@ type TemplateColumnValidationRequest struct {
 	Severity string      `json:"severity" example:"error"`
 }
 
+type TemplateColumnTransformRequest struct {
+	Transform string      `json:"transform" example:"trim"`
+	Options   jsonb.JSONB `json:"options" swaggertype:"string" example:"null"`
+}
+
+type TemplateColumnDefaultRequest struct {
+	Type  string `json:"type" example:"metadata"`
+	Value string `json:"value" example:""`
+	Key   string `json:"key" example:"tenant_id"`
+}
+
 // getTemplate
 //
 //	@Summary		Get template
@@ -130,6 +166,26 @@ func createTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 			return
 		}
 	}
+	synonyms, err := model.ParseSynonyms(req.Synonyms)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid synonyms: %v", err.Error())})
+		return
+	}
+
+	transforms, err := parseTransforms(req.Transforms)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+
+	var defaultValue *model.TemplateColumnDefault
+	if req.DefaultValue != nil {
+		defaultValue, err = model.ParseTemplateColumnDefault(req.DefaultValue.Type, req.DefaultValue.Value, req.DefaultValue.Key)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+	}
 
 	allowedValidateTypes := getAllowedValidateTypes(template.WorkspaceID.String())
 	dataType, err := model.ParseTemplateColumnDataType(req.DataType)
@@ -137,6 +193,10 @@ func createTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The data type '%s' is invalid", req.DataType)})
 		return
 	}
+	if err = model.ValidateListColumn(req.IsList, req.Delimiter, dataType); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
 
 	templateColumn := model.TemplateColumn{
 		ID:                model.NewID(),
@@ -147,16 +207,34 @@ func createTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 		DataType:          dataType,
 		Description:       null.NewString(req.Description, len(req.Description) != 0),
 		SuggestedMappings: suggestedMappings,
+		Synonyms:          synonyms,
+		Transforms:        transforms,
+		DefaultValue:      defaultValue,
+		Formula:           null.NewString(strings.TrimSpace(req.Formula), len(strings.TrimSpace(req.Formula)) != 0),
+		IsList:            req.IsList,
+		Delimiter:         req.Delimiter,
 		Index:             null.IntFrom(int64(len(template.TemplateColumns))), // The next index is just the current length
 		CreatedBy:         user.ID,
 		UpdatedBy:         user.ID,
 	}
+	if _, err = model.ParseComputedColumns(append(append([]*model.TemplateColumn{}, template.TemplateColumns...), &templateColumn)); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
 
 	// Validations
 	var validations []*model.Validation
+	hasDataTypeValidation := false
 	for _, v := range req.Validations {
-		// Don't allow the user to add a data type validator (these are added automatically based on the data type)
-		if evaluator.IsDataTypeEvaluator(v.Validate) {
+		// Don't allow the user to add a data type validator (these are added automatically based on the data type),
+		// unless it's the validator of the column data type, which can be provided to set its options
+		if v.Validate == string(dataType) && evaluator.IsDataTypeEvaluator(v.Validate) {
+			if hasDataTypeValidation {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid template: the validate type %v can only be added once", v.Validate)})
+				return
+			}
+			hasDataTypeValidation = true
+		} else if evaluator.IsDataTypeEvaluator(v.Validate) {
 			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid template: the validate type %v cannot be added directly and is automatically added when setting a data type", v.Validate)})
 			return
 		}
@@ -169,10 +247,22 @@ func createTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 			return
 		}
+		if err = validateReferencedKeys(validation, &templateColumn, template); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		if err = db.ValidateLookupTableReference(template.WorkspaceID.String(), validation); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		if templateColumn.Required && model.IsImpliedByRequired(validation) {
+			// Required columns already check that each cell contains a value
+			continue
+		}
 		validations = append(validations, validation)
 	}
 
-	if evaluator.IsDataTypeEvaluator(string(dataType)) {
+	if evaluator.IsDataTypeEvaluator(string(dataType)) && !hasDataTypeValidation {
 		// Add the default data type validation
 		validation, err := model.ParseValidation(0, templateColumn.ID.String(), string(dataType), jsonb.NewNull(), "", "", dataType)
 		if err != nil {
@@ -331,6 +421,61 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 		templateColumn.SuggestedMappings = suggestedMappings
 		save = true
 	}
+	if req.Synonyms != nil {
+		synonyms, err := model.ParseSynonyms(*req.Synonyms)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid synonyms: %v", err.Error())})
+			return
+		}
+		templateColumn.Synonyms = synonyms
+		save = true
+	}
+	if req.Transforms != nil {
+		transforms, err := parseTransforms(*req.Transforms)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+			return
+		}
+		templateColumn.Transforms = transforms
+		save = true
+	}
+	if req.DefaultValue != nil {
+		if len(req.DefaultValue.Type) == 0 {
+			templateColumn.DefaultValue = nil
+		} else {
+			defaultValue, err := model.ParseTemplateColumnDefault(req.DefaultValue.Type, req.DefaultValue.Value, req.DefaultValue.Key)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+				return
+			}
+			templateColumn.DefaultValue = defaultValue
+		}
+		save = true
+	}
+	if req.Formula != nil && strings.TrimSpace(*req.Formula) != templateColumn.Formula.String {
+		formula := strings.TrimSpace(*req.Formula)
+		templateColumn.Formula = null.NewString(formula, len(formula) != 0)
+		save = true
+	}
+	// Validate the formulas still reference existing columns, as the key or formula of this column may have changed
+	if _, err = model.ParseComputedColumns(template.TemplateColumns); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	// Validate the row validations still reference existing columns, as the key of this column may have changed. If the
+	// validations of this column are replaced, the new ones are validated below.
+	referencingColumns := lo.Map(template.TemplateColumns, func(tc *model.TemplateColumn, _ int) *model.TemplateColumn {
+		if req.Validations != nil && tc.ID.Equals(templateColumn.ID) {
+			withoutValidations := *tc
+			withoutValidations.Validations = nil
+			return &withoutValidations
+		}
+		return tc
+	})
+	if err = model.ValidateReferencedKeys(referencingColumns); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
 
 	var validationsToCreateOrEdit []*model.Validation
 	var validationsToDelete []*model.Validation
@@ -338,6 +483,7 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 
 	// Parse any data type changes first so these can be used to validate any new validations
 	hasNewDataType := false
+	hasDataTypeValidation := false
 	if req.DataType != nil && *req.DataType != string(templateColumn.DataType) {
 		dataType, err := model.ParseTemplateColumnDataType(*req.DataType)
 		if err != nil {
@@ -347,6 +493,21 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 		hasNewDataType = true
 		templateColumn.DataType = dataType
 	}
+	if req.IsList != nil && *req.IsList != templateColumn.IsList {
+		templateColumn.IsList = *req.IsList
+		if !templateColumn.IsList {
+			templateColumn.Delimiter = ""
+		}
+		save = true
+	}
+	if req.Delimiter != nil && *req.Delimiter != templateColumn.Delimiter {
+		templateColumn.Delimiter = *req.Delimiter
+		save = true
+	}
+	if err := model.ValidateListColumn(templateColumn.IsList, templateColumn.Delimiter, templateColumn.DataType); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
 
 	if req.Validations != nil {
 		// If validations are passed in, use these to overwrite the validations in the database
@@ -360,10 +521,26 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
 				return
 			}
+			if evaluator.IsDataTypeEvaluator(v.Validate) {
+				// The validator of the column data type can be provided to set its options
+				if hasDataTypeValidation {
+					c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid template: the validate type %v can only be added once", v.Validate)})
+					return
+				}
+				hasDataTypeValidation = true
+			}
 			if allowedValidateTypes != nil && !allowedValidateTypes[v.Validate] {
 				c.AbortWithStatusJSON(http.StatusForbidden, types.Res{Err: "Please upgrade your plan to use this validation"})
 				return
 			}
+			if err = validateReferencedKeys(validation, templateColumn, template); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+				return
+			}
+			if err = db.ValidateLookupTableReference(template.WorkspaceID.String(), validation); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+				return
+			}
 			if validation.ID != 0 {
 				// If the validation has an ID, make sure it exists in the template column
 				exists := lo.ContainsBy(templateColumn.Validations, func(v *model.Validation) bool {
@@ -384,11 +561,24 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 		validationsToDelete = util.DifferenceBy(templateColumn.Validations, existingValidationsProvided, func(v1 *model.Validation, v2 *model.Validation) bool {
 			return v1.ID == v2.ID
 		})
-		// Don't delete any default data type validations
+		// Don't delete any default data type validations, unless the request provides the data type validation (i.e. to
+		// set its options)
 		// If the data type is changed, removing/adding the default data type validation will be handled next
-		validationsToDelete = lo.Filter(validationsToDelete, func(v *model.Validation, _ int) bool {
-			return !evaluator.IsDataTypeEvaluator(v.Validate)
+		if !hasDataTypeValidation {
+			validationsToDelete = lo.Filter(validationsToDelete, func(v *model.Validation, _ int) bool {
+				return !evaluator.IsDataTypeEvaluator(v.Validate)
+			})
+		}
+	}
+
+	if templateColumn.Required {
+		// Required columns already check that each cell contains a value, remove any not_blank validations that duplicate it
+		validationsToCreateOrEdit = lo.Filter(validationsToCreateOrEdit, func(v *model.Validation, _ int) bool {
+			return !model.IsImpliedByRequired(v)
 		})
+		validationsToDelete = lo.UniqBy(append(validationsToDelete, lo.Filter(templateColumn.Validations, func(v *model.Validation, _ int) bool {
+			return model.IsImpliedByRequired(v)
+		})...), func(v *model.Validation) uint { return v.ID })
 	}
 
 	if hasNewDataType {
@@ -397,8 +587,8 @@ func editTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, stri
 			notAllowedDataType := !lo.Contains(v.Evaluator.AllowedDataTypes(), string(templateColumn.DataType))
 			return notAllowedDataType || evaluator.IsDataTypeEvaluator(v.Validate)
 		})...)
-		// Add a new data type validator
-		if evaluator.IsDataTypeEvaluator(string(templateColumn.DataType)) {
+		// Add a new data type validator, if one wasn't provided
+		if evaluator.IsDataTypeEvaluator(string(templateColumn.DataType)) && !hasDataTypeValidation {
 			validation, err := model.ParseValidation(0, templateColumn.ID.String(), string(templateColumn.DataType), jsonb.NewNull(), "", "", templateColumn.DataType)
 			if err != nil {
 				c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
@@ -479,6 +669,18 @@ func deleteTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 		c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: "Unable to find template column"})
 		return
 	}
+	// Validate the column isn't referenced by the formula of a computed column or a row validation of another column
+	remainingColumns := lo.Filter(template.TemplateColumns, func(tc *model.TemplateColumn, _ int) bool {
+		return !tc.ID.Equals(templateColumn.ID)
+	})
+	if _, err = model.ParseComputedColumns(remainingColumns); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The column cannot be deleted: %s", err.Error())})
+		return
+	}
+	if err = model.ValidateReferencedKeys(remainingColumns); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The column cannot be deleted: %s", err.Error())})
+		return
+	}
 	templateColumn.Index = null.Int{}
 	templateColumn.DeletedBy = user.ID
 	templateColumn.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
@@ -520,6 +722,236 @@ func deleteTemplateColumn(c *gin.Context, getWorkspaceUser func(*gin.Context, st
 	c.JSON(http.StatusOK, template)
 }
 
+// generateTemplate
+//
+//	@Summary		Generate template
+//	@Description	Propose the columns of a template from a sample CSV or XLSX file. The columns aren't saved, so they can be reviewed and then saved with the create template columns endpoint.
+//	@Tags			Template
+//	@Success		200	{object}	types.Template
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/template/{id}/generate [post]
+//	@Param			id		path		string	true	"Template ID"
+//	@Param			file	formData	file	true	"Sample file with a header row"
+func generateTemplate(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error)) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No template ID provided"})
+		return
+	}
+	template, err := db.GetTemplate(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Template does not exist"})
+		return
+	}
+	_, err = getWorkspaceUser(c, template.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No file provided"})
+		return
+	}
+	if fileHeader.Size > maxTemplateSampleFileSize {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("The file exceeds the max size of %dMB", maxTemplateSampleFileSize/1024/1024)})
+		return
+	}
+	fileType, ok := templateSampleFileTypes[strings.ToLower(filepath.Ext(fileHeader.Filename))]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Only CSV and XLSX files are supported"})
+		return
+	}
+
+	// The file is copied to disk as XLSX files can't be read from the request
+	sampleFile, err := os.CreateTemp(file.TempUploadsDirectory, "template-sample-*")
+	if err != nil {
+		tf.Log.Errorw("Could not create temp file for template sample", "error", err, "template_id", template.ID)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: "An error occurred reading the file"})
+		return
+	}
+	defer func() {
+		sampleFile.Close()
+		if err := os.Remove(sampleFile.Name()); err != nil {
+			tf.Log.Errorw("Could not delete template sample from file system", "error", err, "template_id", template.ID)
+		}
+	}()
+	if err = c.SaveUploadedFile(fileHeader, sampleFile.Name()); err != nil {
+		tf.Log.Errorw("Could not save template sample to file system", "error", err, "template_id", template.ID)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, types.Res{Err: "An error occurred reading the file"})
+		return
+	}
+
+	generatedTemplate, err := file.GenerateTemplate(sampleFile, fileType)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	generatedTemplate.ID = template.ID
+	generatedTemplate.Name = template.Name
+	c.JSON(http.StatusOK, generatedTemplate)
+}
+
+// createTemplateColumns
+//
+//	@Summary		Create template columns
+//	@Description	Add several columns to a template at once, i.e. the columns generated from a sample file once they are reviewed
+//	@Tags			Template
+//	@Success		200	{object}	model.Template
+//	@Failure		400	{object}	types.Res
+//	@Router			/admin/v1/template/{id}/columns [post]
+//	@Param			id		path	string			true	"Template ID"
+//	@Param			body	body	types.Template	true	"Request body"
+func createTemplateColumns(c *gin.Context, getWorkspaceUser func(*gin.Context, string) (string, error), getAllowedValidateTypes func(string) map[string]bool) {
+	id := c.Param("id")
+	if len(id) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "No template ID provided"})
+		return
+	}
+	template, err := db.GetTemplate(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Template does not exist"})
+		return
+	}
+	userID, err := getWorkspaceUser(c, template.WorkspaceID.String())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, types.Res{Err: err.Error()})
+		return
+	}
+	user := model.User{ID: model.ParseID(userID)}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	bodyJSON, err := jsonb.FromBytes(body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: "Invalid JSON body"})
+		return
+	}
+	allowedValidateTypes := getAllowedValidateTypes(template.WorkspaceID.String())
+	// The new columns can reference the keys of the existing columns
+	templateType, err := types.ConvertRawTemplateColumns(bodyJSON, allowedValidateTypes, template.TemplateColumns)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	for _, tc := range templateType.TemplateColumns {
+		keyAlreadyExists := lo.ContainsBy(template.TemplateColumns, func(existing *model.TemplateColumn) bool {
+			return existing.Key == tc.Key
+		})
+		if keyAlreadyExists {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("A column already exists with the key '%s'", tc.Key)})
+			return
+		}
+		if _, err = parseSuggestedMappings(tc.SuggestedMappings, template, nil); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Invalid suggested mappings on the column %s: %v", tc.Key, err.Error())})
+			return
+		}
+	}
+
+	templateColumns, err := convertTemplateColumnsToModel(templateType, template.ID, template.WorkspaceID.String(), user)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+	for i, tc := range templateColumns {
+		tc.Index = null.IntFrom(int64(len(template.TemplateColumns) + i))
+	}
+	allTemplateColumns := append(append([]*model.TemplateColumn{}, template.TemplateColumns...), templateColumns...)
+	if _, err = model.ParseComputedColumns(allTemplateColumns); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: err.Error()})
+		return
+	}
+
+	err = tf.DB.Create(templateColumns).Error
+	if err != nil {
+		tf.Log.Errorw("Could not create template columns", "error", err, "template_id", template.ID)
+		c.AbortWithStatusJSON(http.StatusBadRequest, types.Res{Err: fmt.Sprintf("Could not create template columns: %v", err.Error())})
+		return
+	}
+	template.TemplateColumns = allTemplateColumns
+
+	c.JSON(http.StatusOK, template)
+}
+
+// convertTemplateColumnsToModel converts the columns of a template parsed from a request to template columns with
+// validations to be saved on the template
+func convertTemplateColumnsToModel(templateType *types.Template, templateID model.ID, workspaceID string, user model.User) ([]*model.TemplateColumn, error) {
+	templateColumns := make([]*model.TemplateColumn, 0, len(templateType.TemplateColumns))
+	for _, tc := range templateType.TemplateColumns {
+		templateColumn := &model.TemplateColumn{
+			ID:                tc.ID,
+			TemplateID:        templateID,
+			Name:              tc.Name,
+			Key:               tc.Key,
+			Required:          tc.Required,
+			DataType:          model.TemplateColumnDataType(tc.DataType),
+			Description:       null.NewString(tc.Description, len(tc.Description) != 0),
+			SuggestedMappings: tc.SuggestedMappings,
+			Synonyms:          tc.Synonyms,
+			Transforms:        tc.Transforms,
+			DefaultValue:      tc.DefaultValue,
+			Formula:           null.NewString(tc.Formula, len(tc.Formula) != 0),
+			IsList:            tc.IsList,
+			Delimiter:         tc.Delimiter,
+			CreatedBy:         user.ID,
+			UpdatedBy:         user.ID,
+		}
+
+		validations := make([]*types.Validation, 0, len(tc.Validations))
+		for _, v := range tc.Validations {
+			v.ValidationID = 0
+			validation, err := model.ParseValidation(v.ValidationID, tc.ID.String(), v.Validate, v.Options, v.Message, v.Severity, model.TemplateColumnDataType(tc.DataType))
+			if err != nil {
+				return nil, err
+			}
+			if err = db.ValidateLookupTableReference(workspaceID, validation); err != nil {
+				return nil, err
+			}
+			if tc.Required && model.IsImpliedByRequired(validation) {
+				// Required columns already check that each cell contains a value
+				continue
+			}
+			validations = append(validations, v)
+			templateColumn.Validations = append(templateColumn.Validations, validation)
+		}
+		// The validations removed aren't returned with the template either
+		tc.Validations = validations
+		templateColumns = append(templateColumns, templateColumn)
+	}
+	return templateColumns, nil
+}
+
+// validateReferencedKeys checks that any template column keys referenced by a row validation (i.e. a cross-column
+// rule) exist on the template or are the key of the template column itself
+func validateReferencedKeys(validation *model.Validation, templateColumn *model.TemplateColumn, template *model.Template) error {
+	for _, key := range validation.ReferencedKeys() {
+		exists := key == templateColumn.Key || lo.ContainsBy(template.TemplateColumns, func(tc *model.TemplateColumn) bool {
+			return tc.Key == key
+		})
+		if !exists {
+			return fmt.Errorf("The %s validation references the key %s, which does not exist on the template", validation.Validate, key)
+		}
+	}
+	return nil
+}
+
+// parseTransforms parses the transforms of a template column request, which are applied in the order provided
+func parseTransforms(transformRequests []TemplateColumnTransformRequest) (model.TemplateColumnTransforms, error) {
+	transforms := make(model.TemplateColumnTransforms, 0, len(transformRequests))
+	for _, t := range transformRequests {
+		transform, err := model.ParseTemplateColumnTransform(t.Transform, t.Options)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, transform)
+	}
+	return transforms, nil
+}
+
 func parseSuggestedMappings(suggestedMappings []string, template *model.Template, templateColumn *model.TemplateColumn) ([]string, error) {
 	if len(suggestedMappings) == 0 {
 		return suggestedMappings, nil
@@ -171,8 +171,9 @@ func regenerateWorkspaceAPIKey(c *gin.Context, getWorkspaceUser func(*gin.Contex
 }
 
 type ValidateAllowed struct {
-	Validate string `json:"validate"`
-	Allowed  bool   `json:"allowed"`
+	Validate     string                 `json:"validate"`
+	Allowed      bool                   `json:"allowed"`
+	OptionSchema map[string]interface{} `json:"option_schema,omitempty"`
 }
 
 // getWorkspaceDataTypeValidations
@@ -199,12 +200,13 @@ func getWorkspaceDataTypeValidations(c *gin.Context, getWorkspaceUser func(*gin.
 	allowedValidateTypes := getAllowedValidateTypes(workspaceID)
 	dataTypeValidations := make(map[string][]ValidateAllowed)
 
-	for dataType, validations := range evaluator.DataTypeValidations {
-		var newValidations []ValidateAllowed
-		for _, validation := range validations {
+	for dataType, definitions := range evaluator.DataTypeValidations() {
+		newValidations := make([]ValidateAllowed, 0, len(definitions))
+		for _, definition := range definitions {
 			newValidations = append(newValidations, ValidateAllowed{
-				Validate: validation,
-				Allowed:  allowedValidateTypes == nil || allowedValidateTypes[validation],
+				Validate:     definition.Name,
+				Allowed:      allowedValidateTypes == nil || allowedValidateTypes[definition.Name],
+				OptionSchema: definition.OptionSchema,
 			})
 		}
 		dataTypeValidations[dataType] = newValidations
@@ -98,6 +98,7 @@ export type TemplateColumnFormFields = {
   required: boolean;
   validations?: Validation[];
   suggested_mappings?: string[];
+  synonyms?: string[];
   data_type?: string;
   index?: number;
 };
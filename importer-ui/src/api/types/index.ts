@@ -36,7 +36,18 @@ export type TemplateColumn = {
   description?: string;
   required?: boolean;
   suggested_mappings?: string[];
+  synonyms?: string[];
   validations?: Validation[];
+  formula?: string;
+  default_value?: TemplateColumnDefault;
+  is_list?: boolean;
+  delimiter?: string;
+};
+
+export type TemplateColumnDefault = {
+  type: string;
+  value?: string;
+  key?: string;
 };
 
 export type Validation = {
@@ -53,7 +64,9 @@ export type Upload = {
   file_name: string;
   file_type: string;
   header_row_index: number;
+  header_row_end_index?: number;
   matched_header_row_index?: number;
+  matched_header_row_confidence?: number;
   id: string;
   is_stored: boolean;
   metadata: any;
@@ -71,6 +84,16 @@ export type UploadColumn = {
   name: string;
   sample_data: string[];
   suggested_template_column_id: string;
+  inferred_data_type?: string;
+  inferred_data_type_confidence?: number;
+  match_candidates?: ColumnMatchCandidate[];
+};
+
+export type ColumnMatchCandidate = {
+  template_column_id: string;
+  confidence: number;
+  name_score: number;
+  value_score: number | null;
 };
 
 export type UploadRow = {
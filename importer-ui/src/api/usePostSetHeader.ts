@@ -3,14 +3,15 @@ import { ApiResponse } from "./types";
 import { post } from "./api";
 
 export default function usePostSetHeader(uploadId: string): UseMutationResult<ApiResponse<any>> {
-  return useMutation(({ selectedHeaderRow }: any) => mutateHeader(uploadId, selectedHeaderRow));
+  return useMutation(({ selectedHeaderRow, numHeaderRows }: any) => mutateHeader(uploadId, selectedHeaderRow, numHeaderRows));
 }
 
-async function mutateHeader(uploadId: string, rowIndex: string): Promise<ApiResponse<any>> {
+async function mutateHeader(uploadId: string, rowIndex: string, numHeaderRows = 1): Promise<ApiResponse<any>> {
   const endpoint = `upload/${uploadId}/set-header-row`;
 
   const response = await post(endpoint, {
     index: Number(rowIndex),
+    end_index: Number(rowIndex) + Number(numHeaderRows) - 1,
   });
 
   if (!response.ok) throw response.error;
@@ -11,8 +11,12 @@ type Include = {
   template: string;
   use: boolean;
   selected?: boolean;
+  dataType?: string;
 };
 
+// The data types that can be set on the columns of schemaless imports, the inferred data type is proposed by default
+const schemalessDataTypes = ["string", "number", "boolean", "date", "email"];
+
 export default function useMapColumnsTable(
   items: UploadColumn[] = [],
   templateColumns: TemplateColumn[] = [],
@@ -35,6 +39,7 @@ export default function useMapColumnsTable(
           template: uc?.suggested_template_column_id || "",
           use: !!uc?.suggested_template_column_id,
           selected: !!uc?.suggested_template_column_id,
+          dataType: uc?.inferred_data_type || "string",
         },
       }),
       {}
@@ -46,7 +51,11 @@ export default function useMapColumnsTable(
   );
 
   const templateFields: { [key: string]: InputOption } = useMemo(
-    () => templateColumns.reduce((acc, field) => ({ ...acc, [field.name]: { value: field.id, required: field.required } }), {}),
+    // Computed columns are derived from the other columns, so they can't be mapped
+    () =>
+      templateColumns
+        .filter((field) => !field.formula)
+        .reduce((acc, field) => ({ ...acc, [field.name]: { value: field.id, required: field.required } }), {}),
     [JSON.stringify(templateColumns)]
   );
 
@@ -67,9 +76,13 @@ export default function useMapColumnsTable(
     setValues((prev) => ({ ...prev, [id]: { ...prev[id], template: value, use: !!value } }));
   };
 
+  const handleDataTypeChange = (id: string, dataType: string) => {
+    setValues((prev) => ({ ...prev, [id]: { ...prev[id], dataType } }));
+  };
+
   const rows = useMemo(() => {
     return items.map((item) => {
-      const { id, name, sample_data } = item;
+      const { id, name, sample_data, inferred_data_type, inferred_data_type_confidence } = item;
       const suggestion = values?.[id] || {};
       const samples = sample_data.filter((d) => d);
       const transformedName = name
@@ -95,13 +108,22 @@ export default function useMapColumnsTable(
         "Destination Column": {
           raw: "",
           content: schemaless ? (
-            <SchemalessInput
-              value={transformedName}
-              setValues={(value) => {
-                handleValueChange(id, value);
-              }}
-              readOnly={!!schemalessReadOnly}
-            />
+            <>
+              <SchemalessInput
+                value={transformedName}
+                setValues={(value) => {
+                  handleValueChange(id, value);
+                }}
+                readOnly={!!schemalessReadOnly}
+              />
+              <Input
+                options={schemalessDataTypeOptions(inferred_data_type, inferred_data_type_confidence)}
+                value={suggestion.dataType}
+                variants={["small"]}
+                onChange={(dataType: any) => handleDataTypeChange(id, dataType)}
+                disabled={!!schemalessReadOnly}
+              />
+            </>
           ) : (
             <DropdownFields
               options={templateFields}
@@ -129,6 +151,12 @@ export default function useMapColumnsTable(
   return { rows, formValues: values };
 }
 
+const schemalessDataTypeOptions = (inferredDataType?: string, confidence?: number): { [key: string]: InputOption } =>
+  schemalessDataTypes.reduce((acc, dataType) => {
+    const label = dataType === inferredDataType && confidence ? `${dataType} (inferred, ${Math.round(confidence * 100)}%)` : dataType;
+    return { ...acc, [label]: { value: dataType } };
+  }, {});
+
 const SchemalessInput = ({ value, setValues, readOnly }: { value: string; setValues: (value: string) => void; readOnly: boolean }) => {
   const { transformedValue, transformValue } = useTransformValue(value);
   const [inputValue, setInputValue] = useState(transformedValue);
@@ -34,6 +34,15 @@ export default function MapColumns({
     }, {});
     setSelectedColumns(columns);
 
+    if (schemaless) {
+      // The data types accepted for the destination columns of schemaless imports
+      const dataTypes = Object.keys(formValues).reduce((acc, key) => {
+        const { template, use, dataType } = formValues[key];
+        return { ...acc, ...(use && dataType ? { [template]: dataType } : {}) };
+      }, {});
+      mutate({ columns, data_types: dataTypes });
+      return;
+    }
     mutate(columns);
   };
 
@@ -4,6 +4,7 @@ import { Template, Upload } from "../../../api/types";
 type Include = {
   template: string;
   use: boolean;
+  dataType?: string;
 };
 export interface FormValues {
   [key: string]: Include;
@@ -105,12 +105,18 @@ function ReviewDataTable({ theme, uploadId, filter, template, onCellValueChanged
     let orderedColumns = [];
     if (template?.columns.length !== 0) {
       orderedColumns = orderedIds.map((id) => template?.columns?.find((col) => col.id === id)).filter(Boolean) || [];
+      // Computed columns and columns with a default value have values without being mapped, add them after the mapped
+      // columns so any errors on them can be seen and fixed
+      orderedColumns = [
+        ...orderedColumns,
+        ...(template?.columns?.filter((col) => (col.formula || col.default_value) && !orderedIds.includes(col.id)) || []),
+      ];
     } else {
       // If no columns exist, the upload is schemaless
       orderedColumns = orderedIds.map((id) => ({ name: id, key: id }));
     }
 
-    const generatedColumnDefs = orderedColumns.map(({ name: colName, key: colKey }: any) => {
+    const generatedColumnDefs = orderedColumns.map(({ name: colName, key: colKey, formula }: any) => {
       const displayDescription = template?.columns?.find((c) => c.key === colKey)?.description;
 
       return {
@@ -119,7 +125,8 @@ function ReviewDataTable({ theme, uploadId, filter, template, onCellValueChanged
         headerComponentParams: {
           displayDescription: displayDescription,
         },
-        editable: (params) => !disabled && params.data && params.data.values,
+        // Computed columns are read-only, they are recomputed when the cells they reference are edited
+        editable: (params) => !disabled && !formula && params.data && params.data.values,
         field: `values.${colKey}`,
         cellStyle: (params: any) => {
           if (params.data?.errors?.[colKey]) {
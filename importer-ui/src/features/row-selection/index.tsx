@@ -1,7 +1,9 @@
-import { useEffect } from "react";
+import { useEffect, useState } from "react";
 import { Alert } from "@chakra-ui/alert";
 import { Button } from "@chakra-ui/button";
 import Errors from "../../components/Errors";
+import Input from "../../components/Input";
+import { InputOption } from "../../components/Input/types";
 import Table from "../../components/Table";
 import Tooltip from "../../components/Tooltip";
 import usePostSetHeader from "../../api/usePostSetHeader";
@@ -9,8 +11,18 @@ import { RowSelectionProps } from "./types";
 import style from "./style/RowSelection.module.scss";
 import { PiWarningCircle } from "react-icons/pi";
 
+// The most rows a header can span, i.e. "Q1" over "Revenue" and "Cost"
+const maxHeaderRows = 5;
+
+const numHeaderRowsOptions: { [key: string]: InputOption } = Object.fromEntries(
+  Array.from({ length: maxHeaderRows }, (_, i) => [i === 0 ? "1 header row" : `${i + 1} header rows`, { value: String(i + 1) }])
+);
+
 export default function RowSelection({ upload, onSuccess, onCancel, selectedHeaderRow, setSelectedHeaderRow }: RowSelectionProps) {
   const { mutate, error, isSuccess, isLoading, data } = usePostSetHeader(upload?.id || "");
+  const [numHeaderRows, setNumHeaderRows] = useState<number>(
+    upload?.header_row_end_index != null && upload?.header_row_index != null ? upload.header_row_end_index - upload.header_row_index + 1 : 1
+  );
 
   const handleRadioChange = (e: React.ChangeEvent<HTMLInputElement>) => {
     setSelectedHeaderRow(Number(e.target.value));
@@ -62,7 +74,7 @@ export default function RowSelection({ upload, onSuccess, onCancel, selectedHead
 
   const handleNextClick = (e: any) => {
     e.preventDefault();
-    mutate({ selectedHeaderRow: selectedHeaderRow });
+    mutate({ selectedHeaderRow: selectedHeaderRow, numHeaderRows: numHeaderRows });
   };
 
   useEffect(() => {
@@ -91,6 +103,12 @@ export default function RowSelection({ upload, onSuccess, onCancel, selectedHead
                 heading={
                   <div className={style.headingCaption}>
                     <Tooltip title="Select the row which contains the column headers">Select Header Row</Tooltip>
+                    <Input
+                      options={numHeaderRowsOptions}
+                      value={String(numHeaderRows)}
+                      variants={["small"]}
+                      onChange={(value: any) => setNumHeaderRows(Number(value))}
+                    />
                   </div>
                 }
                 keyAsId="index"